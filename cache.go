@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/json"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/Camelket/mcp-browser-tools/internal/cache"
+	"github.com/Camelket/mcp-browser-tools/internal/config"
+)
+
+// cachedCapture is what get_html and get_screenshot keep in the result cache: the captured
+// content, before any per-call post-processing, and the metadata of the page load.
+type cachedCapture struct {
+	Data     []byte         `json:"data"`
+	Metadata map[string]any `json:"metadata"`
+}
+
+// captureCacheKey identifies a tool call by the tool name and all of its arguments, so calls
+// differing in any option (device, headers, mode, format...) never share an entry.
+func captureCacheKey(request mcp.CallToolRequest) string {
+	args, _ := json.Marshal(request.GetArguments()) // Map keys are sorted, so the encoding is stable
+	return cache.Key(request.Params.Name, request.GetString("url", ""), string(args))
+}
+
+// loadCapture returns the cached capture for key. It reports false if caching is disabled.
+func loadCapture(c cache.Cache, cfg *config.Config, key string) (*cachedCapture, bool) {
+	if c == nil || cfg.CacheTTL <= 0 {
+		return nil, false
+	}
+	data, ok := c.Get(key)
+	if !ok {
+		return nil, false
+	}
+	var capture cachedCapture
+	if err := json.Unmarshal(data, &capture); err != nil {
+		return nil, false
+	}
+	if capture.Metadata == nil {
+		capture.Metadata = make(map[string]any)
+	}
+	capture.Metadata["cache"] = "hit"
+	return &capture, true
+}
+
+// saveCapture caches content captured under key along with a copy of its metadata.
+func saveCapture(c cache.Cache, cfg *config.Config, key string, content []byte, metadata map[string]any) {
+	if c == nil || cfg.CacheTTL <= 0 {
+		return
+	}
+	data, err := json.Marshal(cachedCapture{Data: content, Metadata: metadata})
+	if err != nil {
+		return
+	}
+	c.Set(key, data, cfg.CacheTTL)
+}
@@ -0,0 +1,241 @@
+package analysis
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// WCAG conformance levels accepted by CheckContrast.
+const (
+	ContrastLevelAA  = "AA"
+	ContrastLevelAAA = "AAA"
+)
+
+// MaxContrastViolations is the number of violations CheckContrast returns at most.
+const MaxContrastViolations = 50
+
+// ContrastViolation is a text element whose contrast with its background is below the WCAG
+// minimum for its size.
+type ContrastViolation struct {
+	Selector   string  `json:"selector"`
+	Text       string  `json:"text"`     // Start of the element's own text
+	FGColor    string  `json:"fg_color"` // Text color as #rrggbb, blended over the background if translucent
+	BGColor    string  `json:"bg_color"` // Effective background color as #rrggbb
+	Ratio      float64 `json:"ratio"`    // Contrast ratio, rounded to two decimals
+	LargeText  bool    `json:"large_text,omitempty"`
+	FailsLevel string  `json:"fails_level"` // "AA" if the text fails AA (and so AAA), "AAA" if it only fails AAA
+}
+
+// contrastScript collects visible elements with their own text and resolves the colors the
+// text is actually drawn in: backgrounds of the element and its ancestors are composited over
+// the white canvas, and a translucent text color over that background. Elements on a
+// background image or gradient are skipped, as their background color is unknown.
+const contrastScript = `() => {
+	const parse = (value) => {
+		const m = value.match(/rgba?\(\s*([\d.]+)[,\s]+([\d.]+)[,\s]+([\d.]+)(?:\s*[,/]\s*([\d.]+%?))?\s*\)/);
+		if (!m) {
+			return null;
+		}
+		let alpha = m[4] === undefined ? 1 : parseFloat(m[4]);
+		if (m[4] && m[4].endsWith('%')) {
+			alpha /= 100;
+		}
+		return [parseFloat(m[1]), parseFloat(m[2]), parseFloat(m[3]), alpha];
+	};
+	const over = (top, bottom) => [0, 1, 2].map(i => top[i] * top[3] + bottom[i] * (1 - top[3])).concat(1);
+	const selectorOf = (el) => {
+		if (el.id) {
+			return '#' + CSS.escape(el.id);
+		}
+		const parts = [];
+		for (let node = el; node && node.nodeType === 1 && node !== document.documentElement; node = node.parentElement) {
+			if (node.id) {
+				parts.unshift('#' + CSS.escape(node.id));
+				break;
+			}
+			let part = node.localName;
+			const siblings = node.parentElement ? Array.from(node.parentElement.children).filter(s => s.localName === node.localName) : [];
+			if (siblings.length > 1) {
+				part += ':nth-of-type(' + (siblings.indexOf(node) + 1) + ')';
+			}
+			parts.unshift(part);
+		}
+		return parts.join(' > ');
+	};
+
+	const results = [];
+	for (const el of document.body ? document.body.querySelectorAll('*') : []) {
+		if (['SCRIPT', 'STYLE', 'NOSCRIPT', 'TEMPLATE', 'OPTION'].includes(el.tagName)) {
+			continue;
+		}
+		const text = Array.from(el.childNodes)
+			.filter(n => n.nodeType === Node.TEXT_NODE)
+			.map(n => n.textContent)
+			.join(' ')
+			.replace(/\s+/g, ' ')
+			.trim();
+		if (!text) {
+			continue;
+		}
+		const style = getComputedStyle(el);
+		const rect = el.getBoundingClientRect();
+		if (style.visibility !== 'visible' || parseFloat(style.opacity) === 0 || rect.width === 0 || rect.height === 0) {
+			continue;
+		}
+
+		const layers = [];
+		let unknown = false;
+		for (let node = el; node; node = node.parentElement) {
+			const nodeStyle = getComputedStyle(node);
+			if (nodeStyle.backgroundImage && nodeStyle.backgroundImage !== 'none') {
+				unknown = true;
+				break;
+			}
+			const bg = parse(nodeStyle.backgroundColor);
+			if (bg && bg[3] > 0) {
+				layers.push(bg);
+				if (bg[3] >= 1) {
+					break;
+				}
+			}
+		}
+		const fg = parse(style.color);
+		if (unknown || !fg) {
+			continue;
+		}
+		let background = [255, 255, 255, 1];
+		for (let i = layers.length - 1; i >= 0; i--) {
+			background = over(layers[i], background);
+		}
+		results.push({
+			selector: selectorOf(el),
+			text: text.slice(0, 80),
+			fg: over(fg, background).slice(0, 3),
+			bg: background.slice(0, 3),
+			fontSize: parseFloat(style.fontSize),
+			fontWeight: parseInt(style.fontWeight, 10) || 400,
+		});
+	}
+	return results;
+}`
+
+// textSample is an element collected by contrastScript.
+type textSample struct {
+	Selector   string     `json:"selector"`
+	Text       string     `json:"text"`
+	FG         [3]float64 `json:"fg"`
+	BG         [3]float64 `json:"bg"`
+	FontSize   float64    `json:"fontSize"`
+	FontWeight int        `json:"fontWeight"`
+}
+
+// CheckContrast returns the visible text elements of the page whose contrast ratio is below
+// the minimum of level ("AA" or "AAA"), worst first and at most MaxContrastViolations. Large
+// text (24px, or 18.66px bold) needs 3:1 for AA and 4.5:1 for AAA, other text 4.5:1 and 7:1.
+func CheckContrast(page playwright.Page, level string) ([]ContrastViolation, error) {
+	if page == nil {
+		return nil, fmt.Errorf("playwright.Page cannot be nil")
+	}
+	if level != ContrastLevelAA && level != ContrastLevelAAA {
+		return nil, fmt.Errorf("invalid contrast level %q: expected AA or AAA", level)
+	}
+	result, err := page.Evaluate(contrastScript)
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect text colors: %w", err)
+	}
+	var samples []textSample
+	if err := decodeEvaluated(result, &samples); err != nil {
+		return nil, fmt.Errorf("failed to decode text colors: %w", err)
+	}
+
+	violations := []ContrastViolation{}
+	for _, sample := range samples {
+		if v, ok := sample.violation(level); ok {
+			violations = append(violations, v)
+		}
+	}
+	sort.SliceStable(violations, func(i, j int) bool {
+		return violations[i].Ratio < violations[j].Ratio
+	})
+	if len(violations) > MaxContrastViolations {
+		violations = violations[:MaxContrastViolations]
+	}
+	return violations, nil
+}
+
+// violation checks the sample against level and describes it if it fails.
+func (s textSample) violation(level string) (ContrastViolation, bool) {
+	large := s.FontSize >= 24 || (s.FontSize >= 18.66 && s.FontWeight >= 700)
+	ratio := ContrastRatio(s.FG, s.BG)
+	minAA, minAAA := 4.5, 7.0
+	if large {
+		minAA, minAAA = 3, 4.5
+	}
+
+	var fails string
+	switch {
+	case ratio < minAA:
+		fails = ContrastLevelAA
+	case level == ContrastLevelAAA && ratio < minAAA:
+		fails = ContrastLevelAAA
+	default:
+		return ContrastViolation{}, false
+	}
+	return ContrastViolation{
+		Selector:   s.Selector,
+		Text:       s.Text,
+		FGColor:    hexColor(s.FG),
+		BGColor:    hexColor(s.BG),
+		Ratio:      math.Round(ratio*100) / 100,
+		LargeText:  large,
+		FailsLevel: fails,
+	}, true
+}
+
+// ContrastRatio returns the WCAG 2.1 contrast ratio of two opaque sRGB colors with 0-255
+// channels, from 1 (no contrast) to 21 (black on white).
+func ContrastRatio(a, b [3]float64) float64 {
+	la, lb := relativeLuminance(a), relativeLuminance(b)
+	if la < lb {
+		la, lb = lb, la
+	}
+	return (la + 0.05) / (lb + 0.05)
+}
+
+// relativeLuminance implements the WCAG 2.1 definition of relative luminance.
+func relativeLuminance(c [3]float64) float64 {
+	var linear [3]float64
+	for i, channel := range c {
+		v := channel / 255
+		if v <= 0.03928 {
+			linear[i] = v / 12.92
+		} else {
+			linear[i] = math.Pow((v+0.055)/1.055, 2.4)
+		}
+	}
+	return 0.2126*linear[0] + 0.7152*linear[1] + 0.0722*linear[2]
+}
+
+// decodeEvaluated converts the generic value returned by page.Evaluate into target.
+func decodeEvaluated(result interface{}, target interface{}) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
+}
+
+// hexColor formats a color as #rrggbb.
+func hexColor(c [3]float64) string {
+	var b strings.Builder
+	b.WriteByte('#')
+	for _, channel := range c {
+		fmt.Fprintf(&b, "%02x", int(math.Round(math.Max(0, math.Min(255, channel)))))
+	}
+	return b.String()
+}
@@ -0,0 +1,39 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContrastRatio(t *testing.T) {
+	black, white := [3]float64{0, 0, 0}, [3]float64{255, 255, 255}
+	assert.InDelta(t, 21, ContrastRatio(black, white), 0.001)
+	assert.InDelta(t, 21, ContrastRatio(white, black), 0.001)
+	assert.InDelta(t, 1, ContrastRatio(white, white), 0.001)
+	// #777777 on white is the classic borderline grey, just below 4.5:1.
+	assert.InDelta(t, 4.48, ContrastRatio([3]float64{0x77, 0x77, 0x77}, white), 0.01)
+}
+
+func TestTextSampleViolation(t *testing.T) {
+	grey := textSample{Selector: "p", FG: [3]float64{0x77, 0x77, 0x77}, BG: [3]float64{255, 255, 255}, FontSize: 16, FontWeight: 400}
+
+	v, ok := grey.violation(ContrastLevelAA)
+	assert.True(t, ok)
+	assert.Equal(t, ContrastViolation{Selector: "p", FGColor: "#777777", BGColor: "#ffffff", Ratio: 4.48, FailsLevel: "AA"}, v)
+
+	// The same grey is large enough for AA as a heading, but not for AAA.
+	heading := grey
+	heading.FontSize = 24
+	_, ok = heading.violation(ContrastLevelAA)
+	assert.False(t, ok)
+	v, ok = heading.violation(ContrastLevelAAA)
+	assert.True(t, ok)
+	assert.Equal(t, "AAA", v.FailsLevel)
+	assert.True(t, v.LargeText)
+
+	black := grey
+	black.FG = [3]float64{0, 0, 0}
+	_, ok = black.violation(ContrastLevelAAA)
+	assert.False(t, ok)
+}
@@ -0,0 +1,65 @@
+package analysis
+
+import (
+	"fmt"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// frameworkScript fingerprints the frontend frameworks of a page from the globals they expose
+// and, since production builds often hide those, from the markers they leave in the DOM.
+const frameworkScript = `() => {
+	const found = [];
+	const has = selector => document.querySelector(selector) !== null;
+	const reactRoot = () => Array.from(document.querySelectorAll('body, body > *')).some(el =>
+		Object.keys(el).some(key => key.startsWith('__reactContainer') || key.startsWith('_reactRootContainer')));
+	if (window.React || window.__REACT_DEVTOOLS_GLOBAL_HOOK__?.renderers?.size > 0 || has('[data-reactroot]') || reactRoot()) {
+		found.push('React');
+	}
+	if (window.__NEXT_DATA__ || has('#__next')) {
+		found.push('Next.js');
+	}
+	if (window.__VUE__ || window.Vue || has('[data-v-app]') || Array.from(document.querySelectorAll('#app, body > *')).some(el => el.__vue_app__ || el.__vue__)) {
+		found.push('Vue');
+	}
+	if (window.__NUXT__ || has('#__nuxt')) {
+		found.push('Nuxt');
+	}
+	if (window.angular) {
+		found.push('AngularJS');
+	}
+	if (window.ng || has('[ng-version]')) {
+		found.push('Angular');
+	}
+	if (window.svelte || window.__svelte || has('[class*="svelte-"]')) {
+		found.push('Svelte');
+	}
+	if (window.Ember) {
+		found.push('Ember');
+	}
+	if (window.Alpine || has('[x-data]')) {
+		found.push('Alpine.js');
+	}
+	return found;
+}`
+
+// DetectFramework returns the names of the frontend frameworks used by the loaded page, such
+// as "React", "Next.js", "Vue", "Angular", "Svelte", "Ember" or "Alpine.js". Detection is
+// heuristic: a framework bundled without its globals and DOM markers goes unnoticed.
+func DetectFramework(page playwright.Page) ([]string, error) {
+	if page == nil {
+		return nil, fmt.Errorf("playwright.Page cannot be nil")
+	}
+	result, err := page.Evaluate(frameworkScript)
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect frameworks: %w", err)
+	}
+	items, _ := result.([]interface{})
+	frameworks := make([]string, 0, len(items))
+	for _, item := range items {
+		if name, ok := item.(string); ok {
+			frameworks = append(frameworks, name)
+		}
+	}
+	return frameworks, nil
+}
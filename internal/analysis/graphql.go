@@ -0,0 +1,139 @@
+package analysis
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"regexp"
+
+	"github.com/Camelket/mcp-browser-tools/internal/playwright_integration"
+)
+
+// DefaultGraphQLResponseBytes is the default cap on the response of a single GraphQL operation.
+const DefaultGraphQLResponseBytes = 16 * 1024
+
+// GraphQLOperation is one GraphQL operation a page sent, with its result.
+type GraphQLOperation struct {
+	URL           string          `json:"url"`
+	OperationName string          `json:"operation_name,omitempty"` // From the request, or else the query's definition
+	Query         string          `json:"query,omitempty"`          // Empty for persisted queries sent by hash
+	Variables     json.RawMessage `json:"variables,omitempty"`
+	Status        int             `json:"status"`                // HTTP status of the request, 0 if it failed
+	BatchIndex    *int            `json:"batch_index,omitempty"` // Position within a batched request
+	// Response is the operation's result, usually {"data": ..., "errors": [...]}. It is omitted
+	// and ResponseTruncated set if it is larger than the cap or was cut by the network capture.
+	Response          json.RawMessage `json:"response,omitempty"`
+	ResponseTruncated bool            `json:"response_truncated,omitempty"`
+	Error             string          `json:"error,omitempty"` // Why the request failed, if it did
+}
+
+// graphQLRequest is the JSON body of a GraphQL request over HTTP.
+type graphQLRequest struct {
+	Query         *string         `json:"query"`
+	OperationName *string         `json:"operationName"`
+	Variables     json.RawMessage `json:"variables"`
+}
+
+// operationDefinition matches the first named operation of a GraphQL document.
+var operationDefinition = regexp.MustCompile(`\b(?:query|mutation|subscription)\s+([_A-Za-z][_0-9A-Za-z]*)`)
+
+// ExtractGraphQLOperations picks the GraphQL requests out of networkData: POST requests whose
+// body is a JSON object with a query or operationName, or an array of them for batched
+// requests, which are split into one operation each. Responses larger than maxResponseBytes are
+// left out; zero uses DefaultGraphQLResponseBytes. Operations are in capture order.
+func ExtractGraphQLOperations(networkData []playwright_integration.CapturedNetworkActivity, maxResponseBytes int) []GraphQLOperation {
+	if maxResponseBytes <= 0 {
+		maxResponseBytes = DefaultGraphQLResponseBytes
+	}
+	operations := []GraphQLOperation{}
+	for _, activity := range networkData {
+		if activity.Request.Method != http.MethodPost {
+			continue
+		}
+		requests, batched, ok := parseGraphQLBody(activity.Request.Body)
+		if !ok {
+			continue
+		}
+		responses := splitGraphQLResponse(activity.Response, batched, len(requests))
+		for i, request := range requests {
+			op := GraphQLOperation{
+				URL:       activity.Request.URL,
+				Variables: request.Variables,
+				Status:    activity.Response.Status,
+				Error:     activity.Failure,
+			}
+			if request.Query != nil {
+				op.Query = *request.Query
+			}
+			if request.OperationName != nil && *request.OperationName != "" {
+				op.OperationName = *request.OperationName
+			} else if m := operationDefinition.FindStringSubmatch(op.Query); m != nil {
+				op.OperationName = m[1]
+			}
+			if bytes.Equal(op.Variables, []byte("null")) {
+				op.Variables = nil
+			}
+			if batched {
+				index := i
+				op.BatchIndex = &index
+			}
+			if !activity.Failed {
+				if i < len(responses) && len(responses[i]) <= maxResponseBytes {
+					op.Response = responses[i]
+				} else {
+					op.ResponseTruncated = true
+				}
+			}
+			operations = append(operations, op)
+		}
+	}
+	return operations
+}
+
+// parseGraphQLBody decodes a GraphQL request body. It reports whether the body was a batch and
+// whether it is a GraphQL request at all.
+func parseGraphQLBody(body string) (requests []graphQLRequest, batched bool, ok bool) {
+	data := bytes.TrimSpace([]byte(body))
+	if len(data) == 0 {
+		return nil, false, false
+	}
+	if data[0] == '[' {
+		if err := json.Unmarshal(data, &requests); err != nil || len(requests) == 0 {
+			return nil, false, false
+		}
+		batched = true
+	} else {
+		var request graphQLRequest
+		if err := json.Unmarshal(data, &request); err != nil {
+			return nil, false, false
+		}
+		requests = []graphQLRequest{request}
+	}
+	for _, request := range requests {
+		if request.Query == nil && request.OperationName == nil {
+			return nil, false, false
+		}
+	}
+	return requests, batched, true
+}
+
+// splitGraphQLResponse returns the result of each operation of a request: the elements of a
+// batched response, or the whole body otherwise. It returns nil if the body is not the JSON
+// expected, e.g. because the capture cut it short.
+func splitGraphQLResponse(response playwright_integration.CapturedResponse, batched bool, count int) []json.RawMessage {
+	if response.BodyTruncated || response.Encoding != "" {
+		return nil
+	}
+	body := json.RawMessage(bytes.TrimSpace([]byte(response.Body)))
+	if !json.Valid(body) {
+		return nil
+	}
+	if !batched {
+		return []json.RawMessage{body}
+	}
+	var results []json.RawMessage
+	if err := json.Unmarshal(body, &results); err != nil || len(results) != count {
+		return nil
+	}
+	return results
+}
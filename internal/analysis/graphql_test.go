@@ -0,0 +1,101 @@
+package analysis
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Camelket/mcp-browser-tools/internal/playwright_integration"
+)
+
+func graphQLActivity(body, response string) playwright_integration.CapturedNetworkActivity {
+	return playwright_integration.CapturedNetworkActivity{
+		Request:  playwright_integration.CapturedRequest{URL: "https://example.com/graphql", Method: "POST", Body: body},
+		Response: playwright_integration.CapturedResponse{Status: 200, Body: response},
+	}
+}
+
+func TestExtractGraphQLOperations(t *testing.T) {
+	network := []playwright_integration.CapturedNetworkActivity{
+		graphQLActivity(
+			`{"operationName":"GetUser","query":"query GetUser($id: ID!) { user(id: $id) { name } }","variables":{"id":"1"}}`,
+			`{"data":{"user":{"name":"Ada"}}}`,
+		),
+		// Not GraphQL: a JSON POST without query or operationName, and a GET.
+		graphQLActivity(`{"event":"click"}`, `{}`),
+		{Request: playwright_integration.CapturedRequest{URL: "https://example.com/graphql?query=%7Bme%7D", Method: "GET"}},
+		// The operation name is taken from the query when the request has none.
+		graphQLActivity(`{"query":"mutation Like { like(id: 2) }","variables":null}`, `{"errors":[{"message":"denied"}]}`),
+	}
+
+	operations := ExtractGraphQLOperations(network, 0)
+	if assert.Len(t, operations, 2) {
+		assert.Equal(t, "GetUser", operations[0].OperationName)
+		assert.JSONEq(t, `{"id":"1"}`, string(operations[0].Variables))
+		assert.JSONEq(t, `{"data":{"user":{"name":"Ada"}}}`, string(operations[0].Response))
+		assert.Equal(t, 200, operations[0].Status)
+		assert.Nil(t, operations[0].BatchIndex)
+
+		assert.Equal(t, "Like", operations[1].OperationName)
+		assert.Nil(t, operations[1].Variables, "null variables are omitted")
+		assert.JSONEq(t, `{"errors":[{"message":"denied"}]}`, string(operations[1].Response))
+	}
+}
+
+func TestExtractGraphQLOperations_Batched(t *testing.T) {
+	network := []playwright_integration.CapturedNetworkActivity{graphQLActivity(
+		`[{"operationName":"A","query":"query A { a }"},{"operationName":"B","query":"query B { b }"}]`,
+		`[{"data":{"a":1}},{"data":{"b":2}}]`,
+	)}
+
+	operations := ExtractGraphQLOperations(network, 0)
+	if assert.Len(t, operations, 2) {
+		for i, name := range []string{"A", "B"} {
+			assert.Equal(t, name, operations[i].OperationName)
+			if assert.NotNil(t, operations[i].BatchIndex) {
+				assert.Equal(t, i, *operations[i].BatchIndex)
+			}
+		}
+		assert.JSONEq(t, `{"data":{"a":1}}`, string(operations[0].Response))
+		assert.JSONEq(t, `{"data":{"b":2}}`, string(operations[1].Response))
+	}
+}
+
+func TestExtractGraphQLOperations_CapsResponses(t *testing.T) {
+	large := `{"data":{"items":"` + strings.Repeat("x", 100) + `"}}`
+	truncated := graphQLActivity(`{"query":"{ items }"}`, `{"data":{"ite`)
+	truncated.Response.BodyTruncated = true
+	failed := graphQLActivity(`{"query":"{ items }"}`, "")
+	failed.Response = playwright_integration.CapturedResponse{}
+	failed.Failed, failed.Failure = true, "net::ERR_CONNECTION_RESET"
+
+	operations := ExtractGraphQLOperations([]playwright_integration.CapturedNetworkActivity{
+		graphQLActivity(`{"query":"{ items }"}`, large),
+		truncated,
+		failed,
+	}, 50)
+	if assert.Len(t, operations, 3) {
+		assert.Nil(t, operations[0].Response)
+		assert.True(t, operations[0].ResponseTruncated, "responses over the cap are left out")
+		assert.True(t, operations[1].ResponseTruncated, "bodies cut by the capture are left out")
+		assert.False(t, operations[2].ResponseTruncated)
+		assert.Equal(t, "net::ERR_CONNECTION_RESET", operations[2].Error)
+	}
+
+	data, err := json.Marshal(operations[2])
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"url":"https://example.com/graphql","query":"{ items }","status":0,"error":"net::ERR_CONNECTION_RESET"}`, string(data))
+}
+
+func TestParseGraphQLBody(t *testing.T) {
+	for _, body := range []string{"", "not json", `{"query": 1}`, `[]`, `[{"query":"{ a }"},{"other":true}]`, `"query"`} {
+		_, _, ok := parseGraphQLBody(body)
+		assert.False(t, ok, body)
+	}
+	requests, batched, ok := parseGraphQLBody(` {"operationName":"Persisted","extensions":{"persistedQuery":{"sha256Hash":"abc"}}}`)
+	assert.True(t, ok)
+	assert.False(t, batched)
+	assert.Nil(t, requests[0].Query, "persisted queries are sent without the query text")
+}
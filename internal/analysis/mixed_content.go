@@ -0,0 +1,30 @@
+package analysis
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/Camelket/mcp-browser-tools/internal/playwright_integration"
+)
+
+// DetectMixedContent returns the URLs of resources requested over plain http:// by the HTTPS
+// page at pageURL, in request order and without duplicates. It returns nil if pageURL is not
+// an HTTPS URL, since only secure pages can have mixed content.
+func DetectMixedContent(pageURL string, networkData []playwright_integration.CapturedNetworkActivity) []string {
+	u, err := url.Parse(pageURL)
+	if err != nil || !strings.EqualFold(u.Scheme, "https") {
+		return nil
+	}
+
+	var mixed []string
+	seen := make(map[string]bool)
+	for _, activity := range networkData {
+		resourceURL := activity.Request.URL
+		if !strings.HasPrefix(strings.ToLower(resourceURL), "http://") || seen[resourceURL] {
+			continue
+		}
+		seen[resourceURL] = true
+		mixed = append(mixed, resourceURL)
+	}
+	return mixed
+}
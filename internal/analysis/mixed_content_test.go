@@ -0,0 +1,31 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Camelket/mcp-browser-tools/internal/playwright_integration"
+)
+
+func TestDetectMixedContent(t *testing.T) {
+	activity := func(urls ...string) []playwright_integration.CapturedNetworkActivity {
+		var data []playwright_integration.CapturedNetworkActivity
+		for _, u := range urls {
+			data = append(data, playwright_integration.CapturedNetworkActivity{Request: playwright_integration.CapturedRequest{URL: u}})
+		}
+		return data
+	}
+	network := activity(
+		"https://example.com/",
+		"http://cdn.example.com/app.js",
+		"https://cdn.example.com/style.css",
+		"HTTP://img.example.com/logo.png",
+		"http://cdn.example.com/app.js",
+		"data:image/png;base64,AAAA",
+	)
+
+	assert.Equal(t, []string{"http://cdn.example.com/app.js", "HTTP://img.example.com/logo.png"}, DetectMixedContent("https://example.com/", network))
+	assert.Nil(t, DetectMixedContent("http://example.com/", network), "plain HTTP pages have no mixed content")
+	assert.Nil(t, DetectMixedContent("https://example.com/", activity("https://example.com/")))
+}
@@ -0,0 +1,91 @@
+// Package analysis inspects URLs, captured network data and loaded pages, e.g. to follow
+// redirect chains, rate security headers or detect frontend frameworks.
+package analysis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// MaxRedirectHops is the number of redirects GetRedirectChain follows before giving up.
+const MaxRedirectHops = 20
+
+// redirectTimeout bounds the whole redirect chain.
+const redirectTimeout = 30 * time.Second
+
+var (
+	// ErrRedirectLoop is returned when a redirect leads back to a URL already in the chain.
+	ErrRedirectLoop = errors.New("redirect loop detected")
+	// ErrTooManyRedirects is returned when a URL redirects more than MaxRedirectHops times.
+	ErrTooManyRedirects = fmt.Errorf("stopped after %d redirects", MaxRedirectHops)
+)
+
+// RedirectHop is a single response in a redirect chain. Location is empty for the final response.
+type RedirectHop struct {
+	URL        string `json:"url"`
+	StatusCode int    `json:"status_code"`
+	Location   string `json:"location,omitempty"`
+}
+
+// RedirectTracer follows redirect chains with a configurable transport.
+type RedirectTracer struct {
+	// Transport sends the requests; nil uses http.DefaultTransport.
+	Transport http.RoundTripper
+}
+
+// GetRedirectChain follows the redirects of rawURL with the default transport, see RedirectTracer.Trace.
+func GetRedirectChain(ctx context.Context, rawURL string) ([]RedirectHop, error) {
+	return (&RedirectTracer{}).Trace(ctx, rawURL)
+}
+
+// Trace requests rawURL and follows its redirects, returning one hop per response in order;
+// the last hop is the final, non-redirect response. On a redirect loop or after
+// MaxRedirectHops redirects, the hops recorded so far are returned along with ErrRedirectLoop
+// or ErrTooManyRedirects.
+func (t *RedirectTracer) Trace(ctx context.Context, rawURL string) ([]RedirectHop, error) {
+	ctx, cancel := context.WithTimeout(ctx, redirectTimeout)
+	defer cancel()
+
+	var hops []RedirectHop
+	seen := map[string]bool{}
+	client := &http.Client{
+		Transport: t.Transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			// req.Response is the redirect response that led to req.
+			hops = append(hops, RedirectHop{
+				URL:        via[len(via)-1].URL.String(),
+				StatusCode: req.Response.StatusCode,
+				Location:   req.URL.String(),
+			})
+			if seen[req.URL.String()] {
+				return ErrRedirectLoop
+			}
+			if len(via) > MaxRedirectHops {
+				return ErrTooManyRedirects
+			}
+			seen[req.URL.String()] = true
+			return nil
+		},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL %q: %w", rawURL, err)
+	}
+	seen[req.URL.String()] = true
+
+	resp, err := client.Do(req)
+	if err != nil {
+		if errors.Is(err, ErrRedirectLoop) || errors.Is(err, ErrTooManyRedirects) {
+			return hops, err
+		}
+		return hops, fmt.Errorf("failed to follow redirects of %s: %w", rawURL, err)
+	}
+	resp.Body.Close()
+
+	hops = append(hops, RedirectHop{URL: resp.Request.URL.String(), StatusCode: resp.StatusCode})
+	return hops, nil
+}
@@ -0,0 +1,43 @@
+package analysis
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRedirectChain(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/start", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/middle", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/middle", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/end", http.StatusFound)
+	})
+	mux.HandleFunc("/end", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/loop-a", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/loop-b", http.StatusFound)
+	})
+	mux.HandleFunc("/loop-b", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/loop-a", http.StatusFound)
+	})
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+
+	hops, err := GetRedirectChain(context.Background(), ts.URL+"/start")
+	assert.NoError(t, err)
+	assert.Equal(t, []RedirectHop{
+		{URL: ts.URL + "/start", StatusCode: http.StatusMovedPermanently, Location: ts.URL + "/middle"},
+		{URL: ts.URL + "/middle", StatusCode: http.StatusFound, Location: ts.URL + "/end"},
+		{URL: ts.URL + "/end", StatusCode: http.StatusOK},
+	}, hops)
+
+	hops, err = GetRedirectChain(context.Background(), ts.URL+"/loop-a")
+	assert.ErrorIs(t, err, ErrRedirectLoop)
+	assert.Len(t, hops, 2)
+}
@@ -0,0 +1,240 @@
+package analysis
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// robotsTimeout bounds fetching robots.txt.
+const robotsTimeout = 10 * time.Second
+
+// maxRobotsBytes is how much of a robots.txt file is parsed; RFC 9309 requires at least 500 KiB.
+const maxRobotsBytes = 512 * 1024
+
+// RobotsResult is the verdict of robots.txt for a URL.
+type RobotsResult struct {
+	Allowed bool `json:"allowed"`
+	// CrawlDelay is the Crawl-delay requested for all user agents, zero if none.
+	CrawlDelay time.Duration `json:"crawl_delay,omitempty"`
+	// Reason names the rule that decided, or why no rule applied.
+	Reason string `json:"reason"`
+}
+
+// RobotsDisallowedError is returned by callers that refuse to load a URL disallowed by robots.txt.
+type RobotsDisallowedError struct {
+	URL    string
+	Result RobotsResult
+}
+
+func (e *RobotsDisallowedError) Error() string {
+	return fmt.Sprintf("robots.txt disallows %s: %s", e.URL, e.Result.Reason)
+}
+
+// RobotsChecker fetches robots.txt with a configurable transport.
+type RobotsChecker struct {
+	// Transport sends the requests; nil uses http.DefaultTransport.
+	Transport http.RoundTripper
+}
+
+// CheckRobotsTxt checks targetURL against its origin's robots.txt with the default transport,
+// see RobotsChecker.Check.
+func CheckRobotsTxt(ctx context.Context, targetURL string) (RobotsResult, error) {
+	return (&RobotsChecker{}).Check(ctx, targetURL)
+}
+
+// Check fetches {origin}/robots.txt and reports whether the rules for User-agent: * allow
+// targetURL. Following RFC 9309, a missing robots.txt (any 4xx status) allows everything and a
+// server error disallows everything; the error is only non-nil if the URL is invalid or the
+// file cannot be fetched at all.
+func (c *RobotsChecker) Check(ctx context.Context, targetURL string) (RobotsResult, error) {
+	u, err := parseRobotsTarget(targetURL)
+	if err != nil {
+		return RobotsResult{}, err
+	}
+	rules, err := c.Fetch(ctx, targetURL)
+	if err != nil {
+		return RobotsResult{}, err
+	}
+	return rules.Check(u), nil
+}
+
+// Fetch fetches the robots.txt of targetURL's origin, so that callers checking many URLs of
+// the same origin request it only once. A missing or unavailable file yields rules that allow
+// or disallow everything, as described for Check.
+func (c *RobotsChecker) Fetch(ctx context.Context, targetURL string) (*RobotsRules, error) {
+	u, err := parseRobotsTarget(targetURL)
+	if err != nil {
+		return nil, err
+	}
+	robotsURL := u.Scheme + "://" + u.Host + "/robots.txt"
+
+	ctx, cancel := context.WithTimeout(ctx, robotsTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, robotsURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL %q: %w", robotsURL, err)
+	}
+	resp, err := (&http.Client{Transport: c.Transport}).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", robotsURL, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 500:
+		return &RobotsRules{verdict: &RobotsResult{Reason: fmt.Sprintf("robots.txt is unavailable (HTTP %d)", resp.StatusCode)}}, nil
+	case resp.StatusCode >= 400:
+		return &RobotsRules{verdict: &RobotsResult{Allowed: true, Reason: fmt.Sprintf("no robots.txt (HTTP %d)", resp.StatusCode)}}, nil
+	case resp.StatusCode >= 300:
+		// The client follows redirects, so this is a redirect without a usable Location.
+		return &RobotsRules{verdict: &RobotsResult{Allowed: true, Reason: fmt.Sprintf("robots.txt redirect could not be followed (HTTP %d)", resp.StatusCode)}}, nil
+	}
+	return ParseRobotsTxt(io.LimitReader(resp.Body, maxRobotsBytes)), nil
+}
+
+// parseRobotsTarget parses a URL robots.txt can apply to.
+func parseRobotsTarget(targetURL string) (*url.URL, error) {
+	u, err := url.Parse(targetURL)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("invalid URL %q: robots.txt only applies to http and https URLs", targetURL)
+	}
+	return u, nil
+}
+
+// robotsRule is a single Allow or Disallow line.
+type robotsRule struct {
+	allow   bool
+	pattern string
+}
+
+// RobotsRules are the rules of a robots.txt file that apply to User-agent: *.
+type RobotsRules struct {
+	rules      []robotsRule
+	crawlDelay time.Duration
+	verdict    *RobotsResult // Fixed result for every URL, set if the file could not be used
+}
+
+// ParseRobotsTxt reads the groups for User-agent: * from a robots.txt file. Rules of other
+// user agents, unknown fields and malformed lines are ignored.
+func ParseRobotsTxt(r io.Reader) *RobotsRules {
+	rules := &RobotsRules{}
+	// A group starts with one or more User-agent lines; the first rule ends the agent list.
+	inWildcardGroup, readingAgents := false, false
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), maxRobotsBytes)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		if key == "user-agent" {
+			if !readingAgents {
+				inWildcardGroup = false
+			}
+			readingAgents = true
+			if value == "*" {
+				inWildcardGroup = true
+			}
+			continue
+		}
+		readingAgents = false
+		if !inWildcardGroup {
+			continue
+		}
+		switch key {
+		case "allow", "disallow":
+			// An empty Disallow allows everything, which is also the default.
+			if value != "" {
+				rules.rules = append(rules.rules, robotsRule{allow: key == "allow", pattern: value})
+			}
+		case "crawl-delay":
+			if seconds, err := strconv.ParseFloat(value, 64); err == nil && seconds > 0 {
+				rules.crawlDelay = time.Duration(seconds * float64(time.Second))
+			}
+		}
+	}
+	return rules
+}
+
+// Check applies the rules to u's path and query. The longest matching pattern wins, and Allow
+// wins over Disallow on a tie; a URL that matches no rule is allowed.
+func (r *RobotsRules) Check(u *url.URL) RobotsResult {
+	if r.verdict != nil {
+		return *r.verdict
+	}
+	path := u.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	if u.RawQuery != "" {
+		path += "?" + u.RawQuery
+	}
+	result := RobotsResult{Allowed: true, CrawlDelay: r.crawlDelay, Reason: "no rule matches " + path}
+	if path == "/robots.txt" {
+		result.Reason = "robots.txt itself is always allowed"
+		return result
+	}
+
+	var best *robotsRule
+	for i, rule := range r.rules {
+		if !matchRobotsPattern(rule.pattern, path) {
+			continue
+		}
+		if best == nil || len(rule.pattern) > len(best.pattern) || (len(rule.pattern) == len(best.pattern) && rule.allow) {
+			best = &r.rules[i]
+		}
+	}
+	if best != nil {
+		result.Allowed = best.allow
+		if best.allow {
+			result.Reason = fmt.Sprintf("allowed by \"Allow: %s\"", best.pattern)
+		} else {
+			result.Reason = fmt.Sprintf("disallowed by \"Disallow: %s\"", best.pattern)
+		}
+	}
+	return result
+}
+
+// matchRobotsPattern reports whether pattern matches the start of path. In patterns, *
+// matches any sequence of characters and a trailing $ anchors the match at the end of path.
+func matchRobotsPattern(pattern, path string) bool {
+	anchored := strings.HasSuffix(pattern, "$")
+	if anchored {
+		pattern = strings.TrimSuffix(pattern, "$")
+	}
+	parts := strings.Split(pattern, "*")
+	if !strings.HasPrefix(path, parts[0]) {
+		return false
+	}
+	rest := path[len(parts[0]):]
+	if len(parts) == 1 {
+		return !anchored || rest == ""
+	}
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(rest, part)
+		if i < 0 {
+			return false
+		}
+		rest = rest[i+len(part):]
+	}
+	last := parts[len(parts)-1]
+	if anchored {
+		return strings.HasSuffix(rest, last)
+	}
+	return strings.Contains(rest, last)
+}
@@ -0,0 +1,96 @@
+package analysis
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRobotsRules(t *testing.T) {
+	rules := ParseRobotsTxt(strings.NewReader(`
+# Rules for a specific crawler do not apply to *.
+User-agent: Googlebot
+Disallow: /
+
+User-agent: other
+User-agent: *
+Disallow: /private/
+Allow: /private/public-*
+Disallow: /*.pdf$
+Disallow: /search?q=
+Disallow:
+Crawl-delay: 2.5
+`))
+
+	tests := []struct {
+		path    string
+		allowed bool
+	}{
+		{"/", true},
+		{"/about", true},
+		{"/private/", false},
+		{"/private/data", false},
+		{"/private/public-report", true},
+		{"/docs/file.pdf", false},
+		{"/docs/file.pdf?download=1", true},
+		{"/search?q=shoes", false},
+		{"/search", true},
+		{"/robots.txt", true},
+	}
+	for _, tt := range tests {
+		u, err := url.Parse("https://example.com" + tt.path)
+		assert.NoError(t, err)
+		result := rules.Check(u)
+		assert.Equal(t, tt.allowed, result.Allowed, "path %s: %s", tt.path, result.Reason)
+		assert.Equal(t, 2500*time.Millisecond, result.CrawlDelay)
+	}
+}
+
+func TestMatchRobotsPattern(t *testing.T) {
+	assert.True(t, matchRobotsPattern("/a", "/abc"))
+	assert.True(t, matchRobotsPattern("/a*c", "/abbbc/d"))
+	assert.True(t, matchRobotsPattern("/a*c$", "/abcbc"))
+	assert.False(t, matchRobotsPattern("/a*c$", "/abcd"))
+	assert.False(t, matchRobotsPattern("/a$", "/ab"))
+	assert.False(t, matchRobotsPattern("/b", "/abc"))
+}
+
+func TestCheckRobotsTxt(t *testing.T) {
+	status := http.StatusOK
+	mux := http.NewServeMux()
+	mux.HandleFunc("/robots.txt", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		w.Write([]byte("User-agent: *\nDisallow: /admin\n"))
+	})
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+
+	result, err := CheckRobotsTxt(context.Background(), ts.URL+"/admin/users")
+	assert.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Contains(t, result.Reason, "Disallow: /admin")
+
+	result, err = CheckRobotsTxt(context.Background(), ts.URL+"/home")
+	assert.NoError(t, err)
+	assert.True(t, result.Allowed)
+
+	// A missing robots.txt allows everything, an unavailable one nothing.
+	status = http.StatusNotFound
+	result, err = CheckRobotsTxt(context.Background(), ts.URL+"/admin")
+	assert.NoError(t, err)
+	assert.True(t, result.Allowed)
+
+	status = http.StatusServiceUnavailable
+	result, err = CheckRobotsTxt(context.Background(), ts.URL+"/home")
+	assert.NoError(t, err)
+	assert.False(t, result.Allowed)
+
+	_, err = CheckRobotsTxt(context.Background(), "file:///etc/passwd")
+	assert.Error(t, err)
+}
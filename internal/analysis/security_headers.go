@@ -0,0 +1,176 @@
+package analysis
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Ratings of a security header.
+const (
+	RatingGood    = "good"
+	RatingWeak    = "weak"
+	RatingMissing = "missing"
+)
+
+// hstsMinMaxAge is the smallest Strict-Transport-Security max-age rated good: one year, the
+// minimum required for browser preload lists.
+const hstsMinMaxAge = 365 * 24 * 60 * 60
+
+// SecurityHeader is the analysis of one response header.
+type SecurityHeader struct {
+	Header  string `json:"header"`
+	Present bool   `json:"present"`
+	Value   string `json:"value,omitempty"`
+	Rating  string `json:"rating"`         // RatingGood, RatingWeak or RatingMissing
+	Note    string `json:"note,omitempty"` // Why the header is rated weak, if it is
+}
+
+// SecurityHeaderReport rates the security headers of a document response.
+type SecurityHeaderReport struct {
+	HSTS                SecurityHeader `json:"hsts"`
+	CSP                 SecurityHeader `json:"csp"`
+	XContentTypeOptions SecurityHeader `json:"x_content_type_options"`
+	XFrameOptions       SecurityHeader `json:"x_frame_options"`
+	ReferrerPolicy      SecurityHeader `json:"referrer_policy"`
+	PermissionsPolicy   SecurityHeader `json:"permissions_policy"`
+}
+
+// AnalyzeSecurityHeaders rates the security headers among headers, whose names are matched
+// case-insensitively.
+func AnalyzeSecurityHeaders(headers map[string]string) SecurityHeaderReport {
+	csp := rateHeader(headers, "Content-Security-Policy", rateCSP)
+	xfo := rateHeader(headers, "X-Frame-Options", rateFrameOptions)
+	if !xfo.Present && cspDirective(csp.Value, "frame-ancestors") != "" {
+		xfo.Rating = RatingGood
+		xfo.Note = "superseded by the CSP frame-ancestors directive"
+	}
+	return SecurityHeaderReport{
+		HSTS:                rateHeader(headers, "Strict-Transport-Security", rateHSTS),
+		CSP:                 csp,
+		XContentTypeOptions: rateHeader(headers, "X-Content-Type-Options", rateContentTypeOptions),
+		XFrameOptions:       xfo,
+		ReferrerPolicy:      rateHeader(headers, "Referrer-Policy", rateReferrerPolicy),
+		PermissionsPolicy:   rateHeader(headers, "Permissions-Policy", ratePermissionsPolicy),
+	}
+}
+
+// rateHeader looks up name in headers and rates its value with rate, which returns a note
+// explaining a weak value or "" for a good one.
+func rateHeader(headers map[string]string, name string, rate func(value string) string) SecurityHeader {
+	h := SecurityHeader{Header: name, Rating: RatingMissing}
+	for k, v := range headers {
+		if strings.EqualFold(k, name) {
+			h.Present, h.Value = true, strings.TrimSpace(v)
+			break
+		}
+	}
+	if !h.Present {
+		return h
+	}
+	h.Rating = RatingGood
+	if h.Note = rate(h.Value); h.Note != "" {
+		h.Rating = RatingWeak
+	}
+	return h
+}
+
+func rateHSTS(value string) string {
+	maxAge := -1
+	for _, directive := range strings.Split(value, ";") {
+		name, arg, _ := strings.Cut(strings.TrimSpace(directive), "=")
+		if strings.EqualFold(strings.TrimSpace(name), "max-age") {
+			if n, err := strconv.Atoi(strings.Trim(strings.TrimSpace(arg), `"`)); err == nil {
+				maxAge = n
+			}
+		}
+	}
+	switch {
+	case maxAge < 0:
+		return "missing or invalid max-age"
+	case maxAge == 0:
+		return "max-age=0 disables HSTS"
+	case maxAge < hstsMinMaxAge:
+		return "max-age is shorter than one year"
+	}
+	return ""
+}
+
+func rateCSP(value string) string {
+	scripts := cspDirective(value, "script-src")
+	if scripts == "" {
+		scripts = cspDirective(value, "default-src")
+	}
+	if scripts == "" {
+		return "no script-src or default-src directive, so scripts are unrestricted"
+	}
+	for _, source := range strings.Fields(scripts) {
+		switch strings.ToLower(source) {
+		case "'unsafe-inline'":
+			if !strings.Contains(scripts, "'nonce-") && !strings.Contains(scripts, "'sha") {
+				return "allows inline scripts ('unsafe-inline')"
+			}
+		case "'unsafe-eval'":
+			return "allows eval ('unsafe-eval')"
+		case "*", "http:", "https:", "data:":
+			return "allows scripts from any source (" + source + ")"
+		}
+	}
+	return ""
+}
+
+// cspDirective returns the source list of a CSP directive, or "" if the policy lacks it.
+func cspDirective(policy, directive string) string {
+	for _, d := range strings.Split(policy, ";") {
+		fields := strings.Fields(d)
+		if len(fields) > 0 && strings.EqualFold(fields[0], directive) {
+			if len(fields) == 1 {
+				return "'none'"
+			}
+			return strings.Join(fields[1:], " ")
+		}
+	}
+	return ""
+}
+
+func rateContentTypeOptions(value string) string {
+	if !strings.EqualFold(value, "nosniff") {
+		return `the only valid value is "nosniff"`
+	}
+	return ""
+}
+
+func rateFrameOptions(value string) string {
+	switch strings.ToUpper(value) {
+	case "DENY", "SAMEORIGIN":
+		return ""
+	}
+	return "only DENY and SAMEORIGIN are supported by current browsers"
+}
+
+// weakReferrerPolicies send the full URL or the origin to other sites over insecure connections.
+var weakReferrerPolicies = map[string]bool{
+	"unsafe-url":                 true,
+	"no-referrer-when-downgrade": true,
+	"origin":                     true,
+	"origin-when-cross-origin":   true,
+}
+
+func rateReferrerPolicy(value string) string {
+	// Browsers apply the last policy they understand, so it is the one rated.
+	policies := strings.Split(value, ",")
+	policy := strings.ToLower(strings.TrimSpace(policies[len(policies)-1]))
+	if weakReferrerPolicies[policy] {
+		return policy + " leaks URLs to other sites"
+	}
+	if policy == "" {
+		return "empty policy falls back to the browser default"
+	}
+	return ""
+}
+
+func ratePermissionsPolicy(value string) string {
+	if value == "" {
+		return "empty policy restricts no features"
+	}
+	return ""
+}
@@ -0,0 +1,62 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyzeSecurityHeaders(t *testing.T) {
+	report := AnalyzeSecurityHeaders(map[string]string{
+		"strict-transport-security": "max-age=63072000; includeSubDomains; preload",
+		"content-security-policy":   "default-src 'self'; script-src 'self' 'unsafe-inline'",
+		"x-content-type-options":    "nosniff",
+		"referrer-policy":           "no-referrer, unsafe-url",
+	})
+
+	assert.Equal(t, RatingGood, report.HSTS.Rating)
+	assert.True(t, report.HSTS.Present)
+	assert.Equal(t, "max-age=63072000; includeSubDomains; preload", report.HSTS.Value)
+	assert.Equal(t, RatingWeak, report.CSP.Rating)
+	assert.Contains(t, report.CSP.Note, "unsafe-inline")
+	assert.Equal(t, RatingGood, report.XContentTypeOptions.Rating)
+	assert.Equal(t, RatingMissing, report.XFrameOptions.Rating)
+	assert.False(t, report.XFrameOptions.Present)
+	assert.Equal(t, RatingWeak, report.ReferrerPolicy.Rating, "the last policy applies")
+	assert.Equal(t, RatingMissing, report.PermissionsPolicy.Rating)
+}
+
+func TestSecurityHeaderRatings(t *testing.T) {
+	tests := []struct {
+		header, value, rating string
+	}{
+		{"Strict-Transport-Security", "max-age=300", RatingWeak},
+		{"Strict-Transport-Security", "max-age=0", RatingWeak},
+		{"Strict-Transport-Security", "includeSubDomains", RatingWeak},
+		{"Content-Security-Policy", "default-src 'self'", RatingGood},
+		{"Content-Security-Policy", "script-src 'nonce-abc' 'unsafe-inline'", RatingGood},
+		{"Content-Security-Policy", "script-src https:", RatingWeak},
+		{"Content-Security-Policy", "img-src *", RatingWeak},
+		{"X-Content-Type-Options", "sniff", RatingWeak},
+		{"X-Frame-Options", "sameorigin", RatingGood},
+		{"X-Frame-Options", "ALLOW-FROM https://example.com", RatingWeak},
+		{"Referrer-Policy", "strict-origin-when-cross-origin", RatingGood},
+		{"Permissions-Policy", "camera=(), geolocation=()", RatingGood},
+	}
+	for _, tt := range tests {
+		t.Run(tt.header+": "+tt.value, func(t *testing.T) {
+			report := AnalyzeSecurityHeaders(map[string]string{tt.header: tt.value})
+			for _, h := range []SecurityHeader{report.HSTS, report.CSP, report.XContentTypeOptions, report.XFrameOptions, report.ReferrerPolicy, report.PermissionsPolicy} {
+				if h.Header == tt.header {
+					assert.Equal(t, tt.rating, h.Rating, h.Note)
+				}
+			}
+		})
+	}
+}
+
+func TestFrameAncestorsSupersedesXFrameOptions(t *testing.T) {
+	report := AnalyzeSecurityHeaders(map[string]string{"Content-Security-Policy": "default-src 'self'; frame-ancestors 'none'"})
+	assert.False(t, report.XFrameOptions.Present)
+	assert.Equal(t, RatingGood, report.XFrameOptions.Rating)
+}
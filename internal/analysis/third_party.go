@@ -0,0 +1,80 @@
+package analysis
+
+import (
+	"fmt"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/publicsuffix"
+
+	"github.com/Camelket/mcp-browser-tools/internal/playwright_integration"
+)
+
+// ThirdPartyResource summarizes the requests a page made to one third-party domain.
+type ThirdPartyResource struct {
+	Domain        string   `json:"domain"` // Registered domain, e.g. "google-analytics.com"
+	RequestCount  int      `json:"request_count"`
+	ResourceTypes []string `json:"resource_types"` // Sorted, e.g. ["image", "script"]
+}
+
+// ListThirdPartyResources groups the requests in networkData whose registered domain differs
+// from that of pageURL, so "cdn.example.com" is first-party for "www.example.com" but
+// "example-cdn.net" is not. Results are ordered by request count, most first. Requests without
+// a host, such as data: URLs, are ignored.
+func ListThirdPartyResources(pageURL string, networkData []playwright_integration.CapturedNetworkActivity) ([]ThirdPartyResource, error) {
+	u, err := url.Parse(pageURL)
+	if err != nil || u.Hostname() == "" {
+		return nil, fmt.Errorf("invalid page URL %q", pageURL)
+	}
+	firstParty := registeredDomain(u.Hostname())
+
+	byDomain := make(map[string]*ThirdPartyResource)
+	for _, activity := range networkData {
+		r, err := url.Parse(activity.Request.URL)
+		if err != nil || r.Hostname() == "" {
+			continue
+		}
+		domain := registeredDomain(r.Hostname())
+		if domain == firstParty {
+			continue
+		}
+		resource, ok := byDomain[domain]
+		if !ok {
+			resource = &ThirdPartyResource{Domain: domain, ResourceTypes: []string{}}
+			byDomain[domain] = resource
+		}
+		resource.RequestCount++
+		if t := activity.Request.ResourceType; t != "" && !slices.Contains(resource.ResourceTypes, t) {
+			resource.ResourceTypes = append(resource.ResourceTypes, t)
+		}
+	}
+
+	resources := make([]ThirdPartyResource, 0, len(byDomain))
+	for _, resource := range byDomain {
+		slices.Sort(resource.ResourceTypes)
+		resources = append(resources, *resource)
+	}
+	slices.SortFunc(resources, func(a, b ThirdPartyResource) int {
+		if a.RequestCount != b.RequestCount {
+			return b.RequestCount - a.RequestCount
+		}
+		return strings.Compare(a.Domain, b.Domain)
+	})
+	return resources, nil
+}
+
+// registeredDomain returns the registered domain (eTLD+1) of host according to the public
+// suffix list. IP addresses and hosts without one, such as "localhost", are returned as is.
+func registeredDomain(host string) string {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if _, err := netip.ParseAddr(host); err == nil {
+		return host
+	}
+	domain, err := publicsuffix.EffectiveTLDPlusOne(host)
+	if err != nil {
+		return host
+	}
+	return domain
+}
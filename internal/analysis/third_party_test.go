@@ -0,0 +1,42 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Camelket/mcp-browser-tools/internal/playwright_integration"
+)
+
+func TestListThirdPartyResources(t *testing.T) {
+	request := func(url, resourceType string) playwright_integration.CapturedNetworkActivity {
+		return playwright_integration.CapturedNetworkActivity{Request: playwright_integration.CapturedRequest{URL: url, ResourceType: resourceType}}
+	}
+	network := []playwright_integration.CapturedNetworkActivity{
+		request("https://www.example.co.uk/", "document"),
+		request("https://static.example.co.uk/app.js", "script"),
+		request("https://www.googletagmanager.com/gtm.js", "script"),
+		request("https://www.google-analytics.com/collect", "xhr"),
+		request("https://region1.google-analytics.com/g/collect", "image"),
+		request("https://fonts.gstatic.com/s/roboto.woff2", "font"),
+		request("data:image/png;base64,AAAA", "image"),
+	}
+
+	resources, err := ListThirdPartyResources("https://www.example.co.uk/", network)
+	assert.NoError(t, err)
+	assert.Equal(t, []ThirdPartyResource{
+		{Domain: "google-analytics.com", RequestCount: 2, ResourceTypes: []string{"image", "xhr"}},
+		{Domain: "googletagmanager.com", RequestCount: 1, ResourceTypes: []string{"script"}},
+		{Domain: "gstatic.com", RequestCount: 1, ResourceTypes: []string{"font"}},
+	}, resources)
+
+	_, err = ListThirdPartyResources("not a url", network)
+	assert.Error(t, err)
+}
+
+func TestRegisteredDomain(t *testing.T) {
+	assert.Equal(t, "example.co.uk", registeredDomain("a.b.example.co.uk"))
+	assert.Equal(t, "example.com", registeredDomain("WWW.Example.com."))
+	assert.Equal(t, "127.0.0.1", registeredDomain("127.0.0.1"))
+	assert.Equal(t, "localhost", registeredDomain("localhost"))
+}
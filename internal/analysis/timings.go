@@ -0,0 +1,89 @@
+package analysis
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// ErrNoNavigationTiming is returned when the page has no navigation timing entry, e.g. about:blank.
+var ErrNoNavigationTiming = errors.New("page has no navigation timing entry")
+
+// navigationTimingScript reads the navigation entry of the current document; times are in
+// milliseconds since the time origin. Event end times are 0 until the event has finished.
+const navigationTimingScript = `() => {
+	const entry = performance.getEntriesByType('navigation')[0];
+	if (!entry) {
+		return null;
+	}
+	return {
+		fetchStart: entry.fetchStart,
+		responseStart: entry.responseStart,
+		domContentLoadedEventEnd: entry.domContentLoadedEventEnd,
+		loadEventEnd: entry.loadEventEnd,
+	};
+}`
+
+// NavigationTimings are the milestones of the main document's load, measured from fetchStart.
+// A milestone the page has not reached yet, e.g. Load while the page is still loading, is zero.
+type NavigationTimings struct {
+	TTFB             time.Duration // Time to the first byte of the response
+	DOMContentLoaded time.Duration // End of the DOMContentLoaded handlers
+	Load             time.Duration // End of the load handlers
+}
+
+// MarshalJSON encodes the timings as fractional milliseconds, the unit of the Navigation Timing API.
+func (t NavigationTimings) MarshalJSON() ([]byte, error) {
+	ms := func(d time.Duration) float64 {
+		return float64(d) / float64(time.Millisecond)
+	}
+	return json.Marshal(struct {
+		TTFB             float64 `json:"ttfb_ms"`
+		DOMContentLoaded float64 `json:"dom_content_loaded_ms"`
+		Load             float64 `json:"load_ms"`
+	}{ms(t.TTFB), ms(t.DOMContentLoaded), ms(t.Load)})
+}
+
+// MeasureNavigationTimings reads the Navigation Timing entry of the loaded page.
+func MeasureNavigationTimings(page playwright.Page) (NavigationTimings, error) {
+	if page == nil {
+		return NavigationTimings{}, fmt.Errorf("playwright.Page cannot be nil")
+	}
+	result, err := page.Evaluate(navigationTimingScript)
+	if err != nil {
+		return NavigationTimings{}, fmt.Errorf("failed to read navigation timings: %w", err)
+	}
+	entry, ok := result.(map[string]interface{})
+	if !ok {
+		return NavigationTimings{}, ErrNoNavigationTiming
+	}
+	fetchStart := timingValue(entry["fetchStart"])
+	since := func(name string) time.Duration {
+		end := timingValue(entry[name])
+		if end <= fetchStart {
+			return 0
+		}
+		return time.Duration((end - fetchStart) * float64(time.Millisecond))
+	}
+	return NavigationTimings{
+		TTFB:             since("responseStart"),
+		DOMContentLoaded: since("domContentLoadedEventEnd"),
+		Load:             since("loadEventEnd"),
+	}, nil
+}
+
+// timingValue converts a number returned by page.Evaluate, which may be an int or a float64.
+func timingValue(v interface{}) float64 {
+	switch n := v.(type) {
+	case float64:
+		return n
+	case int:
+		return float64(n)
+	case int64:
+		return float64(n)
+	}
+	return 0
+}
@@ -0,0 +1,18 @@
+package analysis
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNavigationTimingsJSON(t *testing.T) {
+	data, err := json.Marshal(NavigationTimings{
+		TTFB:             1500 * time.Microsecond,
+		DOMContentLoaded: 20 * time.Millisecond,
+	})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"ttfb_ms": 1.5, "dom_content_loaded_ms": 20, "load_ms": 0}`, string(data))
+}
@@ -6,6 +6,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/Camelket/mcp-browser-tools/internal/metrics"
 	"github.com/playwright-community/playwright-go"
 )
 
@@ -17,6 +18,10 @@ type BrowserInstanceManager struct {
 	inactivityTimer   *time.Timer
 	inactivityTimeout time.Duration // Configurable inactivity timeout
 	cancelTimeout     context.CancelFunc
+	proxy             *ProxySettings // Proxy passed to the browser at launch; nil connects directly
+	onLaunch          []func()       // Called after every browser launch, see OnLaunch
+	autoInstall       bool           // Install missing browsers, see SetAutoInstall
+	installAttempted  bool
 }
 
 // NewBrowserInstanceManager creates and returns a new BrowserInstanceManager.
@@ -27,14 +32,37 @@ func NewBrowserInstanceManager(logger *slog.Logger) *BrowserInstanceManager {
 	}
 }
 
-// SetInactivityTimeout sets the inactivity timeout duration.
+// SetInactivityTimeout sets the inactivity timeout duration. A zero or negative timeout
+// disables the timer, so the browser instance is never closed for inactivity.
 func (bim *BrowserInstanceManager) SetInactivityTimeout(timeout time.Duration) {
 	bim.mu.Lock()
 	defer bim.mu.Unlock()
 	bim.inactivityTimeout = timeout
+	if timeout <= 0 {
+		bim.stopInactivityTimer()
+	}
 	bim.logger.Debug("Inactivity timeout set", slog.Duration("timeout", timeout))
 }
 
+// SetProxy sets the proxy used by browser instances launched afterwards.
+func (bim *BrowserInstanceManager) SetProxy(proxy *ProxySettings) {
+	bim.mu.Lock()
+	defer bim.mu.Unlock()
+	bim.proxy = proxy
+	if proxy != nil {
+		bim.logger.Info("Browser proxy configured", slog.Any("proxy", *proxy))
+	}
+}
+
+// OnLaunch registers fn to be called after each browser launch, including relaunches after the
+// inactivity timeout, e.g. to drop state tied to the previous browser. fn is called with the
+// manager locked and must not call back into it.
+func (bim *BrowserInstanceManager) OnLaunch(fn func()) {
+	bim.mu.Lock()
+	defer bim.mu.Unlock()
+	bim.onLaunch = append(bim.onLaunch, fn)
+}
+
 // GetBrowserInstance returns the single, persistent Playwright browser instance.
 // If the instance does not exist or is closed, it launches a new one.
 // This method is thread-safe.
@@ -53,21 +81,35 @@ func (bim *BrowserInstanceManager) GetBrowserInstance(ctx context.Context) (play
 	bim.logger.Info("Launching new browser instance.")
 	bim.logger.Debug("Calling playwright.Run()...")
 	pw, err := playwright.Run()
+	if err != nil && bim.installOnce(err) {
+		pw, err = playwright.Run()
+	}
 	if err != nil {
 		bim.logger.Error("Failed to launch Playwright", slog.Any("error", err))
-		return nil, err
+		return nil, notInstalled(err)
 	}
 	bim.logger.Debug("playwright.Run() successful. Launching Chromium...")
 
-	browser, err := pw.Chromium.Launch(playwright.BrowserTypeLaunchOptions{Headless: playwright.Bool(true)})
+	launchOptions := playwright.BrowserTypeLaunchOptions{
+		Headless: playwright.Bool(true),
+		Proxy:    bim.proxy.ToPlaywright(),
+	}
+	browser, err := pw.Chromium.Launch(launchOptions)
+	if err != nil && bim.installOnce(err) {
+		browser, err = pw.Chromium.Launch(launchOptions)
+	}
 	if err != nil {
 		bim.logger.Error("Failed to launch browser", slog.Any("error", err))
-		return nil, err
+		return nil, notInstalled(err)
 	}
 	bim.logger.Debug("Chromium launched.")
+	metrics.BrowserLaunches.Inc()
 
 	bim.browser = browser
 	bim.logger.Info("Browser instance launched successfully.")
+	for _, fn := range bim.onLaunch {
+		fn()
+	}
 	bim.ResetInactivityTimer() // Start timer after launch
 	return bim.browser, nil
 }
@@ -77,14 +119,7 @@ func (bim *BrowserInstanceManager) CloseBrowserInstance() error {
 	bim.mu.Lock()
 	defer bim.mu.Unlock()
 
-	if bim.inactivityTimer != nil {
-		bim.inactivityTimer.Stop()
-		bim.inactivityTimer = nil
-	}
-	if bim.cancelTimeout != nil {
-		bim.cancelTimeout()
-		bim.cancelTimeout = nil
-	}
+	bim.stopInactivityTimer()
 
 	if bim.browser != nil {
 		bim.logger.Info("Closing browser instance.")
@@ -100,13 +135,25 @@ func (bim *BrowserInstanceManager) CloseBrowserInstance() error {
 	return nil
 }
 
-// ResetInactivityTimer resets an inactivity timer for the browser instance.
-func (bim *BrowserInstanceManager) ResetInactivityTimer() {
+// stopInactivityTimer stops and clears a running inactivity timer.
+func (bim *BrowserInstanceManager) stopInactivityTimer() {
 	if bim.inactivityTimer != nil {
 		bim.inactivityTimer.Stop()
+		bim.inactivityTimer = nil
 	}
 	if bim.cancelTimeout != nil {
 		bim.cancelTimeout()
+		bim.cancelTimeout = nil
+	}
+}
+
+// ResetInactivityTimer resets an inactivity timer for the browser instance.
+// It does nothing if the inactivity timeout is disabled.
+func (bim *BrowserInstanceManager) ResetInactivityTimer() {
+	bim.stopInactivityTimer()
+	if bim.inactivityTimeout <= 0 {
+		// time.AfterFunc would fire immediately for a non-positive duration.
+		return
 	}
 
 	var ctx context.Context
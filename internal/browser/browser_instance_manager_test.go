@@ -0,0 +1,24 @@
+package browser
+
+import (
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetInactivityTimeout_DisablesTimer(t *testing.T) {
+	for _, timeout := range []time.Duration{0, -time.Second} {
+		bim := NewBrowserInstanceManager(slog.New(slog.NewTextHandler(io.Discard, nil)))
+		bim.KeepAlive()
+		assert.NotNil(t, bim.inactivityTimer, "the default timeout schedules a timer")
+
+		bim.SetInactivityTimeout(timeout)
+		assert.Nil(t, bim.inactivityTimer, "disabling the timeout stops a running timer")
+
+		bim.KeepAlive()
+		assert.Nil(t, bim.inactivityTimer, "timeout %v must not schedule a timer", timeout)
+	}
+}
@@ -0,0 +1,104 @@
+package browser
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// installCommand installs the Playwright driver and Chromium build matching playwright-go.
+const installCommand = "go run github.com/playwright-community/playwright-go/cmd/playwright@v0.5200.0 install --with-deps chromium"
+
+// NotInstalledError is returned when the Playwright driver or its Chromium build is missing.
+type NotInstalledError struct {
+	Err error
+}
+
+func (e *NotInstalledError) Error() string {
+	return fmt.Sprintf("Playwright browsers are not installed: %v; run %q or set BROWSER_AUTO_INSTALL=true to install them on startup", e.Err, installCommand)
+}
+
+// Unwrap returns the underlying error.
+func (e *NotInstalledError) Unwrap() error {
+	return e.Err
+}
+
+// isNotInstalled reports whether err from playwright.Run or a browser launch means the driver
+// or the browser executable is missing.
+func isNotInstalled(err error) bool {
+	var notInstalled *NotInstalledError
+	if errors.As(err, &notInstalled) {
+		return true
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "please install the driver") || strings.Contains(msg, "Executable doesn't exist")
+}
+
+// SetAutoInstall makes CheckInstallation and browser launches download the Playwright driver
+// and Chromium when they are missing, once per manager.
+func (bim *BrowserInstanceManager) SetAutoInstall(enabled bool) {
+	bim.mu.Lock()
+	defer bim.mu.Unlock()
+	bim.autoInstall = enabled
+}
+
+// CheckInstallation verifies that the Playwright driver and Chromium are installed, so a missing
+// install is reported at startup instead of on the first tool call. With SetAutoInstall it
+// installs them if needed. It returns a *NotInstalledError if they are still missing.
+func (bim *BrowserInstanceManager) CheckInstallation() error {
+	bim.mu.Lock()
+	defer bim.mu.Unlock()
+
+	err := checkInstallation()
+	if err != nil && bim.installOnce(err) {
+		err = checkInstallation()
+	}
+	if err != nil {
+		return err
+	}
+	bim.logger.Debug("Playwright installation found.")
+	return nil
+}
+
+// installOnce runs the Playwright installer if err means it is missing, auto-install is on and
+// no earlier install was attempted. It reports whether the caller should try again.
+func (bim *BrowserInstanceManager) installOnce(err error) bool {
+	if !bim.autoInstall || bim.installAttempted || !isNotInstalled(err) {
+		return false
+	}
+	bim.installAttempted = true
+	bim.logger.Info("Playwright browsers are missing, installing them.", slog.Any("error", err))
+	if installErr := playwright.Install(&playwright.RunOptions{Browsers: []string{"chromium"}}); installErr != nil {
+		bim.logger.Error("Failed to install Playwright browsers", slog.Any("error", installErr))
+		return false
+	}
+	bim.logger.Info("Playwright browsers installed.")
+	return true
+}
+
+// checkInstallation starts the Playwright driver and looks for the Chromium executable.
+func checkInstallation() error {
+	pw, err := playwright.Run()
+	if err != nil {
+		return notInstalled(err)
+	}
+	defer pw.Stop()
+	path := pw.Chromium.ExecutablePath()
+	if _, err := os.Stat(path); err != nil {
+		return &NotInstalledError{Err: fmt.Errorf("chromium executable not found: %w", err)}
+	}
+	return nil
+}
+
+// notInstalled wraps err in a *NotInstalledError if it means Playwright is missing.
+func notInstalled(err error) error {
+	var existing *NotInstalledError
+	if !isNotInstalled(err) || errors.As(err, &existing) {
+		return err
+	}
+	return &NotInstalledError{Err: err}
+}
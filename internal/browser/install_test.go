@@ -0,0 +1,40 @@
+package browser
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotInstalled(t *testing.T) {
+	driverErr := fmt.Errorf("please install the driver (v1.52.0) first: %w", errors.New("driver not found"))
+	err := notInstalled(driverErr)
+	var notInstalledErr *NotInstalledError
+	if assert.ErrorAs(t, err, &notInstalledErr) {
+		assert.ErrorIs(t, err, driverErr)
+		assert.Contains(t, err.Error(), installCommand)
+		assert.Contains(t, err.Error(), "BROWSER_AUTO_INSTALL")
+	}
+	assert.Same(t, err, notInstalled(err), "errors are wrapped once")
+
+	launchErr := errors.New("BrowserType.launch: Executable doesn't exist at /root/.cache/ms-playwright/chromium-1169/chrome-linux/chrome")
+	assert.ErrorAs(t, notInstalled(launchErr), &notInstalledErr)
+
+	other := errors.New("browser has been closed")
+	assert.Same(t, other, notInstalled(other))
+}
+
+func TestInstallOnceRequiresAutoInstall(t *testing.T) {
+	bim := NewBrowserInstanceManager(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	missing := &NotInstalledError{Err: errors.New("driver not found")}
+	assert.False(t, bim.installOnce(missing), "auto-install is off by default")
+
+	bim.autoInstall = true
+	bim.installAttempted = true
+	assert.False(t, bim.installOnce(missing), "the installer runs at most once")
+	assert.False(t, bim.installOnce(errors.New("browser has been closed")))
+}
@@ -0,0 +1,102 @@
+// Package cache keeps recent tool results in memory so that repeated requests for the same URL
+// within a short window do not each launch a browser navigation.
+package cache
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Cache stores byte values under string keys for a limited time.
+type Cache interface {
+	// Get returns the value stored under key, or false if there is none or it has expired.
+	Get(key string) ([]byte, bool)
+	// Set stores value under key for ttl, replacing any previous value.
+	Set(key string, value []byte, ttl time.Duration)
+}
+
+// Key derives a cache key from the parts identifying a result, e.g. the tool name, the URL
+// and the encoded options. Parts are length-prefixed so that ("ab", "c") and ("a", "bc")
+// yield different keys.
+func Key(parts ...string) string {
+	h := sha256.New()
+	for _, part := range parts {
+		h.Write([]byte(strconv.Itoa(len(part)) + ":" + part))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// entry is a cached value with its expiry time.
+type entry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// memoryCache is an in-memory Cache that evicts the least recently used entry once it holds
+// maxEntries. It is safe for concurrent use.
+type memoryCache struct {
+	mu         sync.RWMutex
+	maxEntries int
+	entries    map[string]*list.Element // Values are *entry
+	order      *list.List               // Front is the most recently used
+	now        func() time.Time
+}
+
+// NewMemoryCache returns an in-memory cache holding at most maxEntries values. A maxEntries
+// of zero or less disables caching: Set does nothing.
+func NewMemoryCache(maxEntries int) Cache {
+	return &memoryCache{
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+		now:        time.Now,
+	}
+}
+
+func (c *memoryCache) Get(key string) ([]byte, bool) {
+	c.mu.RLock()
+	e, ok := c.entries[key]
+	c.mu.RUnlock()
+	if !ok {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// The entry may have been evicted or replaced while the lock was released.
+	if current, ok := c.entries[key]; !ok || current != e {
+		return nil, false
+	}
+	if !c.now().Before(e.Value.(*entry).expires) {
+		c.remove(e)
+		return nil, false
+	}
+	c.order.MoveToFront(e)
+	return e.Value.(*entry).value, true
+}
+
+func (c *memoryCache) Set(key string, value []byte, ttl time.Duration) {
+	if c.maxEntries <= 0 || ttl <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok {
+		c.remove(e)
+	}
+	for c.order.Len() >= c.maxEntries {
+		c.remove(c.order.Back())
+	}
+	c.entries[key] = c.order.PushFront(&entry{key: key, value: value, expires: c.now().Add(ttl)})
+}
+
+// remove deletes an entry. Callers must hold c.mu for writing.
+func (c *memoryCache) remove(e *list.Element) {
+	c.order.Remove(e)
+	delete(c.entries, e.Value.(*entry).key)
+}
@@ -0,0 +1,67 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryCacheGetSet(t *testing.T) {
+	c := NewMemoryCache(10)
+	c.Set("a", []byte("html"), time.Minute)
+
+	v, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("html"), v)
+	_, ok = c.Get("b")
+	assert.False(t, ok)
+
+	c.Set("a", []byte("newer"), time.Minute)
+	v, _ = c.Get("a")
+	assert.Equal(t, []byte("newer"), v)
+}
+
+func TestMemoryCacheExpires(t *testing.T) {
+	c := NewMemoryCache(10).(*memoryCache)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+	c.Set("a", []byte("html"), time.Minute)
+
+	now = now.Add(59 * time.Second)
+	_, ok := c.Get("a")
+	assert.True(t, ok)
+
+	now = now.Add(time.Second)
+	_, ok = c.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, 0, c.order.Len(), "expired entries are removed")
+}
+
+func TestMemoryCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewMemoryCache(2)
+	c.Set("a", []byte("1"), time.Minute)
+	c.Set("b", []byte("2"), time.Minute)
+	c.Get("a") // a is now more recently used than b
+	c.Set("c", []byte("3"), time.Minute)
+
+	_, ok := c.Get("b")
+	assert.False(t, ok, "the least recently used entry is evicted")
+	_, ok = c.Get("a")
+	assert.True(t, ok)
+	_, ok = c.Get("c")
+	assert.True(t, ok)
+}
+
+func TestMemoryCacheDisabled(t *testing.T) {
+	c := NewMemoryCache(0)
+	c.Set("a", []byte("1"), time.Minute)
+	_, ok := c.Get("a")
+	assert.False(t, ok)
+}
+
+func TestKey(t *testing.T) {
+	assert.Equal(t, Key("get_html", "https://example.com"), Key("get_html", "https://example.com"))
+	assert.NotEqual(t, Key("ab", "c"), Key("a", "bc"))
+	assert.Len(t, Key("x"), 64)
+}
@@ -0,0 +1,307 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Camelket/mcp-browser-tools/internal/browser"
+	"github.com/Camelket/mcp-browser-tools/internal/safety"
+)
+
+// Config holds server-wide defaults, read from environment variables at startup.
+type Config struct {
+	// NavigationTimeout is the default per-call timeout for tools that load a page.
+	NavigationTimeout time.Duration
+	// MaxNavigationTimeout is the largest timeout_ms a tool call may request.
+	MaxNavigationTimeout time.Duration
+	// DefaultTimeout is Playwright's timeout for page operations that are not given one, such
+	// as clicks and selector waits. Zero keeps Playwright's default of 30 seconds.
+	DefaultTimeout time.Duration
+	// MaxHTMLBytes caps the HTML returned by get_html and get_page_summary. Zero disables the cap.
+	MaxHTMLBytes int
+	// CaptureMaxBodyBytes and CaptureMaxTotalBytes bound the response bodies recorded by network
+	// capture, per body and per page. Longer bodies are truncated. Zero disables a limit.
+	CaptureMaxBodyBytes  int
+	CaptureMaxTotalBytes int
+	// CaptureMaxFrameBytes caps the payload recorded per WebSocket frame. Zero disables the cap.
+	CaptureMaxFrameBytes int
+	// MaxScriptBytes caps the size of scripts accepted by run_js_audit. Zero disables the cap.
+	MaxScriptBytes int
+	// ResourceStoreBytes bounds the memory used for screenshots and HTML served as MCP resources.
+	ResourceStoreBytes int
+	// ResourceTTL is how long a stored resource can be read. Zero keeps resources until evicted.
+	ResourceTTL time.Duration
+	// ResourceThresholdBytes is the payload size above which tools return a resource URI and a
+	// preview instead of the full content. Zero only does so when a tool call sets as_resource.
+	ResourceThresholdBytes int
+	// CacheTTL is how long get_html and get_screenshot reuse the capture of an identical
+	// earlier call instead of loading the page again. Zero disables the cache.
+	CacheTTL time.Duration
+	// CacheMaxEntries bounds the number of captures kept in the cache.
+	CacheMaxEntries int
+	// InactivityTimeout closes the browser after this long without use. Zero keeps it running.
+	InactivityTimeout time.Duration
+	// ViewportWidth and ViewportHeight are the default viewport size in CSS pixels.
+	// Zero leaves Playwright's default (1280x720) in place.
+	ViewportWidth  int
+	ViewportHeight int
+	// UserAgent is the default User-Agent for new pages. Empty keeps the browser's own.
+	// Aliases such as "chrome-stable" are expanded when the page is created.
+	UserAgent string
+	// NavigationRateLimit is the maximum number of navigations per second to a single origin.
+	// Zero disables rate limiting.
+	NavigationRateLimit float64
+	// HTTPUsername and HTTPPassword are default credentials for HTTP basic auth challenges.
+	HTTPUsername string
+	HTTPPassword string
+	// IgnoreHTTPSErrors disables TLS certificate validation by default. Tools can still
+	// enable it per call with ignore_https_errors.
+	IgnoreHTTPSErrors bool
+	// Proxy routes all browser traffic through a proxy. Nil connects directly.
+	Proxy *browser.ProxySettings
+	// AllowPrivateNetworks disables the SSRF protection that blocks loopback, private,
+	// link-local and metadata service addresses.
+	AllowPrivateNetworks bool
+	// AllowedHosts are exempt from the SSRF protection; a leading "." matches subdomains.
+	AllowedHosts []string
+	// HostAllowlist and HostDenylist restrict which hosts pages may load, see safety.Policy.
+	HostAllowlist []*safety.HostPattern
+	HostDenylist  []*safety.HostPattern
+	// MetricsAddr is the address of the Prometheus metrics listener, e.g. ":9090". Empty
+	// disables it, which is the default since the MCP server itself speaks stdio.
+	MetricsAddr string
+	// DebugScreenshotDir receives a screenshot of the page whenever a navigation, click or
+	// action step fails. Empty disables debug screenshots.
+	DebugScreenshotDir string
+	// AutoInstall downloads the Playwright driver and Chromium if they are missing at startup
+	// or when the browser is launched, instead of failing with installation instructions.
+	AutoInstall bool
+}
+
+// Viewport dimensions accepted from tool arguments and environment variables.
+const (
+	MinViewportSize = 200
+	MaxViewportSize = 4000
+)
+
+// ValidateViewportSize returns an error if a viewport dimension is outside the supported bounds.
+func ValidateViewportSize(name string, size int) error {
+	if size < MinViewportSize || size > MaxViewportSize {
+		return fmt.Errorf("%s must be between %d and %d pixels, got %d", name, MinViewportSize, MaxViewportSize, size)
+	}
+	return nil
+}
+
+// Default returns the configuration used when no environment variables are set.
+func Default() *Config {
+	return &Config{
+		NavigationTimeout:      30 * time.Second,
+		MaxNavigationTimeout:   5 * time.Minute,
+		InactivityTimeout:      1 * time.Minute,
+		MaxHTMLBytes:           500 * 1024,
+		MaxScriptBytes:         50 * 1024,
+		CaptureMaxBodyBytes:    256 * 1024,
+		CaptureMaxTotalBytes:   5 << 20,
+		CaptureMaxFrameBytes:   4 * 1024,
+		ResourceStoreBytes:     100 << 20,
+		ResourceTTL:            15 * time.Minute,
+		ResourceThresholdBytes: 1 << 20,
+		CacheMaxEntries:        100,
+	}
+}
+
+// Load returns the default configuration overridden by any environment variables that are set.
+func Load() (*Config, error) {
+	cfg := Default()
+
+	if v, ok := os.LookupEnv("BROWSER_NAVIGATION_TIMEOUT_MS"); ok {
+		timeout, err := parseMilliseconds(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid BROWSER_NAVIGATION_TIMEOUT_MS: %w", err)
+		}
+		cfg.NavigationTimeout = timeout
+	}
+	if v, ok := os.LookupEnv("BROWSER_MAX_NAVIGATION_TIMEOUT_MS"); ok {
+		timeout, err := parseMilliseconds(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid BROWSER_MAX_NAVIGATION_TIMEOUT_MS: %w", err)
+		}
+		cfg.MaxNavigationTimeout = timeout
+	}
+	if cfg.NavigationTimeout > cfg.MaxNavigationTimeout {
+		return nil, fmt.Errorf("BROWSER_NAVIGATION_TIMEOUT_MS (%v) exceeds BROWSER_MAX_NAVIGATION_TIMEOUT_MS (%v)", cfg.NavigationTimeout, cfg.MaxNavigationTimeout)
+	}
+
+	if v, ok := os.LookupEnv("BROWSER_DEFAULT_TIMEOUT_MS"); ok {
+		ms, err := strconv.Atoi(v)
+		if err != nil || ms < 0 {
+			return nil, fmt.Errorf("invalid BROWSER_DEFAULT_TIMEOUT_MS: must be a non-negative integer (0 keeps Playwright's default), got %q", v)
+		}
+		cfg.DefaultTimeout = time.Duration(ms) * time.Millisecond
+	}
+
+	if v, ok := os.LookupEnv("BROWSER_INACTIVITY_TIMEOUT_MS"); ok {
+		ms, err := strconv.Atoi(v)
+		if err != nil || ms < 0 {
+			return nil, fmt.Errorf("invalid BROWSER_INACTIVITY_TIMEOUT_MS: must be a non-negative integer (0 disables), got %q", v)
+		}
+		cfg.InactivityTimeout = time.Duration(ms) * time.Millisecond
+	}
+
+	if v, ok := os.LookupEnv("BROWSER_MAX_HTML_BYTES"); ok {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid BROWSER_MAX_HTML_BYTES: must be a non-negative integer (0 disables), got %q", v)
+		}
+		cfg.MaxHTMLBytes = n
+	}
+
+	for _, env := range []struct {
+		name   string
+		target *int
+	}{
+		{"BROWSER_RESOURCE_STORE_BYTES", &cfg.ResourceStoreBytes},
+		{"BROWSER_RESOURCE_THRESHOLD_BYTES", &cfg.ResourceThresholdBytes},
+		{"BROWSER_CACHE_MAX_ENTRIES", &cfg.CacheMaxEntries},
+		{"BROWSER_MAX_SCRIPT_BYTES", &cfg.MaxScriptBytes},
+		{"BROWSER_CAPTURE_MAX_BODY_BYTES", &cfg.CaptureMaxBodyBytes},
+		{"BROWSER_CAPTURE_MAX_TOTAL_BYTES", &cfg.CaptureMaxTotalBytes},
+		{"BROWSER_CAPTURE_MAX_FRAME_BYTES", &cfg.CaptureMaxFrameBytes},
+	} {
+		v, ok := os.LookupEnv(env.name)
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid %s: must be a non-negative integer, got %q", env.name, v)
+		}
+		*env.target = n
+	}
+	if v, ok := os.LookupEnv("BROWSER_RESOURCE_TTL_MS"); ok {
+		ms, err := strconv.Atoi(v)
+		if err != nil || ms < 0 {
+			return nil, fmt.Errorf("invalid BROWSER_RESOURCE_TTL_MS: must be a non-negative integer (0 disables expiry), got %q", v)
+		}
+		cfg.ResourceTTL = time.Duration(ms) * time.Millisecond
+	}
+
+	if v, ok := os.LookupEnv("BROWSER_CACHE_TTL_SECONDS"); ok {
+		seconds, err := strconv.Atoi(v)
+		if err != nil || seconds < 0 {
+			return nil, fmt.Errorf("invalid BROWSER_CACHE_TTL_SECONDS: must be a non-negative integer (0 disables the cache), got %q", v)
+		}
+		cfg.CacheTTL = time.Duration(seconds) * time.Second
+	}
+
+	if v, ok := os.LookupEnv("BROWSER_RATE_LIMIT_RPS"); ok {
+		rps, err := strconv.ParseFloat(v, 64)
+		if err != nil || rps < 0 {
+			return nil, fmt.Errorf("invalid BROWSER_RATE_LIMIT_RPS: must be a non-negative number, got %q", v)
+		}
+		cfg.NavigationRateLimit = rps
+	}
+
+	if v, ok := os.LookupEnv("BROWSER_IGNORE_HTTPS_ERRORS"); ok {
+		ignore, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid BROWSER_IGNORE_HTTPS_ERRORS: %w", err)
+		}
+		cfg.IgnoreHTTPSErrors = ignore
+	}
+
+	proxy, err := browser.ParseProxy(os.Getenv("MCP_BROWSER_PROXY"), os.Getenv("MCP_BROWSER_PROXY_BYPASS"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid MCP_BROWSER_PROXY: %w", err)
+	}
+	cfg.Proxy = proxy
+
+	if v, ok := os.LookupEnv("BROWSER_AUTO_INSTALL"); ok {
+		autoInstall, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid BROWSER_AUTO_INSTALL: %w", err)
+		}
+		cfg.AutoInstall = autoInstall
+	}
+
+	if v, ok := os.LookupEnv("MCP_BROWSER_ALLOW_PRIVATE"); ok {
+		allow, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid MCP_BROWSER_ALLOW_PRIVATE: %w", err)
+		}
+		cfg.AllowPrivateNetworks = allow
+	}
+	cfg.AllowedHosts = splitList(os.Getenv("MCP_BROWSER_ALLOWED_HOSTS"))
+
+	allow := splitList(os.Getenv("MCP_BROWSER_HOST_ALLOWLIST"))
+	deny := splitList(os.Getenv("MCP_BROWSER_HOST_DENYLIST"))
+	if path := os.Getenv("MCP_BROWSER_POLICY_FILE"); path != "" {
+		file, err := safety.LoadPolicyFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid MCP_BROWSER_POLICY_FILE: %w", err)
+		}
+		allow = append(allow, file.Allow...)
+		deny = append(deny, file.Deny...)
+	}
+	if cfg.HostAllowlist, err = safety.ParseHostPatterns(allow); err != nil {
+		return nil, fmt.Errorf("invalid host allow list: %w", err)
+	}
+	if cfg.HostDenylist, err = safety.ParseHostPatterns(deny); err != nil {
+		return nil, fmt.Errorf("invalid host deny list: %w", err)
+	}
+
+	cfg.MetricsAddr = os.Getenv("MCP_BROWSER_METRICS_ADDR")
+	cfg.DebugScreenshotDir = os.Getenv("BROWSER_DEBUG_SCREENSHOT_DIR")
+	cfg.UserAgent = os.Getenv("BROWSER_USER_AGENT")
+	cfg.HTTPUsername = os.Getenv("BROWSER_HTTP_USERNAME")
+	cfg.HTTPPassword = os.Getenv("BROWSER_HTTP_PASSWORD")
+
+	for _, env := range []struct {
+		name   string
+		target *int
+	}{
+		{"BROWSER_VIEWPORT_WIDTH", &cfg.ViewportWidth},
+		{"BROWSER_VIEWPORT_HEIGHT", &cfg.ViewportHeight},
+	} {
+		v, ok := os.LookupEnv(env.name)
+		if !ok {
+			continue
+		}
+		size, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", env.name, err)
+		}
+		if err := ValidateViewportSize(env.name, size); err != nil {
+			return nil, err
+		}
+		*env.target = size
+	}
+
+	return cfg, nil
+}
+
+// splitList splits a comma-separated environment variable, dropping empty entries.
+func splitList(v string) []string {
+	var items []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseMilliseconds parses a positive integer number of milliseconds.
+func parseMilliseconds(v string) (time.Duration, error) {
+	ms, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, err
+	}
+	if ms <= 0 {
+		return 0, fmt.Errorf("must be positive, got %d", ms)
+	}
+	return time.Duration(ms) * time.Millisecond, nil
+}
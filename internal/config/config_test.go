@@ -0,0 +1,144 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad_Defaults(t *testing.T) {
+	cfg, err := Load()
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, 30*time.Second, cfg.NavigationTimeout)
+	assert.Equal(t, 5*time.Minute, cfg.MaxNavigationTimeout)
+	assert.Zero(t, cfg.CacheTTL, "the cache is disabled by default")
+	assert.False(t, cfg.AllowPrivateNetworks)
+	assert.Empty(t, cfg.HostAllowlist)
+	assert.Empty(t, cfg.HostDenylist)
+	assert.Zero(t, cfg.ViewportWidth)
+	assert.Zero(t, cfg.ViewportHeight)
+	assert.Empty(t, cfg.UserAgent)
+}
+
+func TestLoad(t *testing.T) {
+	policyFile := filepath.Join(t.TempDir(), "policy.json")
+	assert.NoError(t, os.WriteFile(policyFile, []byte(`{"allow": ["*.example.com"], "deny": ["ads.example.com"]}`), 0o600))
+	malformedPolicyFile := filepath.Join(t.TempDir(), "policy.json")
+	assert.NoError(t, os.WriteFile(malformedPolicyFile, []byte(`{"allow": `), 0o600))
+
+	for _, tc := range []struct {
+		name    string
+		env     map[string]string
+		check   func(t *testing.T, cfg *Config)
+		wantErr string
+	}{
+		{
+			name: "timeouts",
+			env:  map[string]string{"BROWSER_NAVIGATION_TIMEOUT_MS": "45000", "BROWSER_MAX_NAVIGATION_TIMEOUT_MS": "60000"},
+			check: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, 45*time.Second, cfg.NavigationTimeout)
+				assert.Equal(t, time.Minute, cfg.MaxNavigationTimeout)
+			},
+		},
+		{
+			name:    "negative timeout",
+			env:     map[string]string{"BROWSER_NAVIGATION_TIMEOUT_MS": "-1"},
+			wantErr: "invalid BROWSER_NAVIGATION_TIMEOUT_MS",
+		},
+		{
+			name:    "malformed maximum timeout",
+			env:     map[string]string{"BROWSER_MAX_NAVIGATION_TIMEOUT_MS": "5m"},
+			wantErr: "invalid BROWSER_MAX_NAVIGATION_TIMEOUT_MS",
+		},
+		{
+			name:    "timeout above maximum",
+			env:     map[string]string{"BROWSER_NAVIGATION_TIMEOUT_MS": "60000", "BROWSER_MAX_NAVIGATION_TIMEOUT_MS": "30000"},
+			wantErr: "exceeds BROWSER_MAX_NAVIGATION_TIMEOUT_MS",
+		},
+		{
+			name:  "cache TTL",
+			env:   map[string]string{"BROWSER_CACHE_TTL_SECONDS": "90"},
+			check: func(t *testing.T, cfg *Config) { assert.Equal(t, 90*time.Second, cfg.CacheTTL) },
+		},
+		{
+			name:    "negative cache TTL",
+			env:     map[string]string{"BROWSER_CACHE_TTL_SECONDS": "-5"},
+			wantErr: "invalid BROWSER_CACHE_TTL_SECONDS",
+		},
+		{
+			name: "allow private networks",
+			env:  map[string]string{"MCP_BROWSER_ALLOW_PRIVATE": "true", "MCP_BROWSER_ALLOWED_HOSTS": "intranet.corp, .internal"},
+			check: func(t *testing.T, cfg *Config) {
+				assert.True(t, cfg.AllowPrivateNetworks)
+				assert.Equal(t, []string{"intranet.corp", ".internal"}, cfg.AllowedHosts)
+			},
+		},
+		{
+			name:    "malformed allow private networks",
+			env:     map[string]string{"MCP_BROWSER_ALLOW_PRIVATE": "sometimes"},
+			wantErr: "invalid MCP_BROWSER_ALLOW_PRIVATE",
+		},
+		{
+			name: "policy file",
+			env:  map[string]string{"MCP_BROWSER_POLICY_FILE": policyFile, "MCP_BROWSER_HOST_DENYLIST": "tracker.example"},
+			check: func(t *testing.T, cfg *Config) {
+				if assert.Len(t, cfg.HostAllowlist, 1) {
+					assert.Equal(t, "*.example.com", cfg.HostAllowlist[0].String())
+				}
+				assert.Len(t, cfg.HostDenylist, 2, "the file's entries are added to the environment's")
+			},
+		},
+		{
+			name:    "malformed policy file",
+			env:     map[string]string{"MCP_BROWSER_POLICY_FILE": malformedPolicyFile},
+			wantErr: "invalid MCP_BROWSER_POLICY_FILE",
+		},
+		{
+			name:    "missing policy file",
+			env:     map[string]string{"MCP_BROWSER_POLICY_FILE": filepath.Join(t.TempDir(), "missing.json")},
+			wantErr: "invalid MCP_BROWSER_POLICY_FILE",
+		},
+		{
+			name: "viewport",
+			env:  map[string]string{"BROWSER_VIEWPORT_WIDTH": "1920", "BROWSER_VIEWPORT_HEIGHT": "1080"},
+			check: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, 1920, cfg.ViewportWidth)
+				assert.Equal(t, 1080, cfg.ViewportHeight)
+			},
+		},
+		{
+			name:    "viewport too small",
+			env:     map[string]string{"BROWSER_VIEWPORT_WIDTH": "100"},
+			wantErr: "BROWSER_VIEWPORT_WIDTH must be between",
+		},
+		{
+			name:    "malformed viewport",
+			env:     map[string]string{"BROWSER_VIEWPORT_HEIGHT": "tall"},
+			wantErr: "invalid BROWSER_VIEWPORT_HEIGHT",
+		},
+		{
+			name:  "user agent",
+			env:   map[string]string{"BROWSER_USER_AGENT": "chrome-stable"},
+			check: func(t *testing.T, cfg *Config) { assert.Equal(t, "chrome-stable", cfg.UserAgent) },
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			for name, value := range tc.env {
+				t.Setenv(name, value)
+			}
+			cfg, err := Load()
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			if assert.NoError(t, err) {
+				tc.check(t, cfg)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package devices
+
+import (
+	"sort"
+	"strings"
+)
+
+// Device describes the viewport and browser characteristics used to emulate a device.
+type Device struct {
+	Name              string  `json:"name"`
+	Width             int     `json:"width"`
+	Height            int     `json:"height"`
+	DeviceScaleFactor float64 `json:"device_scale_factor"`
+	UserAgent         string  `json:"user_agent"`
+	IsMobile          bool    `json:"is_mobile"`
+	HasTouch          bool    `json:"has_touch"`
+}
+
+// registry holds the built-in device descriptors, keyed by lowercase name.
+var registry = map[string]Device{
+	"desktop": {
+		Name:              "desktop",
+		Width:             1280,
+		Height:            720,
+		DeviceScaleFactor: 1,
+	},
+	"iphone-13": {
+		Name:              "iphone-13",
+		Width:             390,
+		Height:            844,
+		DeviceScaleFactor: 3,
+		UserAgent:         "Mozilla/5.0 (iPhone; CPU iPhone OS 15_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/15.0 Mobile/15E148 Safari/604.1",
+		IsMobile:          true,
+		HasTouch:          true,
+	},
+	"iphone-se": {
+		Name:              "iphone-se",
+		Width:             375,
+		Height:            667,
+		DeviceScaleFactor: 2,
+		UserAgent:         "Mozilla/5.0 (iPhone; CPU iPhone OS 15_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/15.0 Mobile/15E148 Safari/604.1",
+		IsMobile:          true,
+		HasTouch:          true,
+	},
+	"pixel-7": {
+		Name:              "pixel-7",
+		Width:             412,
+		Height:            915,
+		DeviceScaleFactor: 2.625,
+		UserAgent:         "Mozilla/5.0 (Linux; Android 13; Pixel 7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Mobile Safari/537.36",
+		IsMobile:          true,
+		HasTouch:          true,
+	},
+	"galaxy-s9": {
+		Name:              "galaxy-s9",
+		Width:             360,
+		Height:            740,
+		DeviceScaleFactor: 4,
+		UserAgent:         "Mozilla/5.0 (Linux; Android 8.0.0; SM-G960F Build/R16NW) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Mobile Safari/537.36",
+		IsMobile:          true,
+		HasTouch:          true,
+	},
+	"ipad": {
+		Name:              "ipad",
+		Width:             810,
+		Height:            1080,
+		DeviceScaleFactor: 2,
+		UserAgent:         "Mozilla/5.0 (iPad; CPU OS 15_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/15.0 Mobile/15E148 Safari/604.1",
+		IsMobile:          true,
+		HasTouch:          true,
+	},
+}
+
+// Lookup returns the built-in device with the given name (case-insensitive).
+func Lookup(name string) (Device, bool) {
+	d, ok := registry[strings.ToLower(strings.TrimSpace(name))]
+	return d, ok
+}
+
+// Names returns the names of all built-in devices in sorted order.
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ChromeStableUserAgent is the User-Agent of a current desktop Chrome release on Windows.
+// Keep it in step with the Chrome stable channel so it is not flagged as outdated.
+const ChromeStableUserAgent = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/141.0.0.0 Safari/537.36"
+
+// userAgentAliases maps shorthand names accepted in place of a User-Agent string.
+var userAgentAliases = map[string]string{
+	"chrome-stable": ChromeStableUserAgent,
+}
+
+// ResolveUserAgent expands a User-Agent alias such as "chrome-stable" (case-insensitive).
+// Any other value is returned unchanged.
+func ResolveUserAgent(userAgent string) string {
+	if ua, ok := userAgentAliases[strings.ToLower(strings.TrimSpace(userAgent))]; ok {
+		return ua
+	}
+	return userAgent
+}
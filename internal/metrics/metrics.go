@@ -0,0 +1,183 @@
+// Package metrics collects operational counters and exposes them in the Prometheus text format.
+// Recording is always on and cheap; the values are only served if the server starts a metrics
+// listener (MCP_BROWSER_METRICS_ADDR), since the MCP server itself speaks stdio.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// The metrics exported by the server.
+var (
+	ToolCalls          = NewCounterVec("mcp_browser_tool_calls_total", "Tool calls by tool name.", "tool")
+	ToolErrors         = NewCounterVec("mcp_browser_tool_errors_total", "Tool calls that failed or returned an error result, by tool name.", "tool")
+	BrowserLaunches    = NewCounter("mcp_browser_browser_launches_total", "Browser processes launched, including relaunches after the inactivity timeout.")
+	NavigationDuration = NewHistogram("mcp_browser_navigation_duration_seconds", "Duration of page navigations, including failed ones.",
+		[]float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60})
+)
+
+// metric is anything that can write itself in the Prometheus text format.
+type metric interface {
+	write(w io.Writer)
+}
+
+var (
+	registryMu sync.Mutex
+	registry   []metric
+)
+
+func register(m metric) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry = append(registry, m)
+}
+
+// Counter is a monotonically increasing value.
+type Counter struct {
+	name, help string
+	value      atomic.Uint64
+}
+
+// NewCounter creates and registers a counter.
+func NewCounter(name, help string) *Counter {
+	c := &Counter{name: name, help: help}
+	register(c)
+	return c
+}
+
+// Inc increments the counter by one.
+func (c *Counter) Inc() {
+	c.value.Add(1)
+}
+
+// Value returns the current count.
+func (c *Counter) Value() uint64 {
+	return c.value.Load()
+}
+
+func (c *Counter) write(w io.Writer) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n%s %d\n", c.name, c.help, c.name, c.name, c.Value())
+}
+
+// CounterVec is a set of counters partitioned by the value of one label.
+type CounterVec struct {
+	name, help, label string
+	mu                sync.Mutex
+	values            map[string]uint64
+}
+
+// NewCounterVec creates and registers a counter partitioned by label.
+func NewCounterVec(name, help, label string) *CounterVec {
+	c := &CounterVec{name: name, help: help, label: label, values: make(map[string]uint64)}
+	register(c)
+	return c
+}
+
+// Inc increments the counter for the given label value by one.
+func (c *CounterVec) Inc(labelValue string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values[labelValue]++
+}
+
+// Value returns the current count for the given label value.
+func (c *CounterVec) Value(labelValue string) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.values[labelValue]
+}
+
+func (c *CounterVec) write(w io.Writer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n", c.name, c.help, c.name)
+	labelValues := make([]string, 0, len(c.values))
+	for v := range c.values {
+		labelValues = append(labelValues, v)
+	}
+	sort.Strings(labelValues)
+	for _, v := range labelValues {
+		fmt.Fprintf(w, "%s{%s=%s} %d\n", c.name, c.label, strconv.Quote(v), c.values[v])
+	}
+}
+
+// Histogram counts observations in cumulative buckets.
+type Histogram struct {
+	name, help string
+	buckets    []float64 // Upper bounds, ascending
+	mu         sync.Mutex
+	counts     []uint64 // Observations per bucket, not cumulative; the last entry is +Inf
+	sum        float64
+	total      uint64
+}
+
+// NewHistogram creates and registers a histogram with the given ascending bucket upper bounds.
+func NewHistogram(name, help string, buckets []float64) *Histogram {
+	h := &Histogram{name: name, help: help, buckets: buckets, counts: make([]uint64, len(buckets)+1)}
+	register(h)
+	return h
+}
+
+// Observe records one value.
+func (h *Histogram) Observe(v float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	i := sort.SearchFloat64s(h.buckets, v)
+	h.counts[i]++
+	h.sum += v
+	h.total++
+}
+
+// ObserveDuration records the time elapsed since start in seconds.
+func (h *Histogram) ObserveDuration(start time.Time) {
+	h.Observe(time.Since(start).Seconds())
+}
+
+func (h *Histogram) write(w io.Writer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s histogram\n", h.name, h.help, h.name)
+	var cumulative uint64
+	for i, bound := range append(h.buckets, math.Inf(1)) {
+		cumulative += h.counts[i]
+		fmt.Fprintf(w, "%s_bucket{le=%q} %d\n", h.name, formatFloat(bound), cumulative)
+	}
+	fmt.Fprintf(w, "%s_sum %s\n%s_count %d\n", h.name, formatFloat(h.sum), h.name, h.total)
+}
+
+// formatFloat renders a value the way Prometheus expects, with "+Inf" for infinity.
+func formatFloat(v float64) string {
+	if math.IsInf(v, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// WriteTo writes all registered metrics in the Prometheus text exposition format.
+func WriteTo(w io.Writer) {
+	registryMu.Lock()
+	metrics := append([]metric(nil), registry...)
+	registryMu.Unlock()
+	for _, m := range metrics {
+		m.write(w)
+	}
+}
+
+// Handler serves the registered metrics.
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		var sb strings.Builder
+		WriteTo(&sb)
+		io.WriteString(w, sb.String())
+	})
+}
@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCounterVecFormat(t *testing.T) {
+	c := NewCounterVec("test_calls_total", "Test calls.", "tool")
+	c.Inc("get_html")
+	c.Inc("get_html")
+	c.Inc("a11y_snapshot")
+
+	var sb strings.Builder
+	c.write(&sb)
+	assert.Equal(t, `# HELP test_calls_total Test calls.
+# TYPE test_calls_total counter
+test_calls_total{tool="a11y_snapshot"} 1
+test_calls_total{tool="get_html"} 2
+`, sb.String())
+}
+
+func TestHistogramFormat(t *testing.T) {
+	h := NewHistogram("test_duration_seconds", "Test durations.", []float64{0.5, 1})
+	h.Observe(0.2)
+	h.Observe(0.5)
+	h.Observe(3)
+
+	var sb strings.Builder
+	h.write(&sb)
+	assert.Equal(t, `# HELP test_duration_seconds Test durations.
+# TYPE test_duration_seconds histogram
+test_duration_seconds_bucket{le="0.5"} 2
+test_duration_seconds_bucket{le="1"} 2
+test_duration_seconds_bucket{le="+Inf"} 3
+test_duration_seconds_sum 3.7
+test_duration_seconds_count 3
+`, sb.String())
+}
+
+func TestHandler(t *testing.T) {
+	BrowserLaunches.Inc()
+
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	assert.Equal(t, 200, rec.Code)
+	assert.Contains(t, rec.Header().Get("Content-Type"), "text/plain")
+	assert.Contains(t, rec.Body.String(), "# TYPE mcp_browser_browser_launches_total counter")
+	assert.Contains(t, rec.Body.String(), "# TYPE mcp_browser_navigation_duration_seconds histogram")
+}
@@ -0,0 +1,164 @@
+package playwright_integration
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// AXNode is a node of the accessibility tree returned by AccessibilitySnapshot.
+type AXNode struct {
+	Role        string         `json:"role"`
+	Name        string         `json:"name,omitempty"`
+	Value       any            `json:"value,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Properties  map[string]any `json:"properties,omitempty"` // e.g. "focusable", "checked", "level"
+	Children    []*AXNode      `json:"children,omitempty"`
+}
+
+// cdpAXValue, cdpAXProperty and cdpAXNode mirror the parts of the Accessibility.getFullAXTree
+// response we use.
+type cdpAXValue struct {
+	Value any `json:"value"`
+}
+
+type cdpAXProperty struct {
+	Name  string     `json:"name"`
+	Value cdpAXValue `json:"value"`
+}
+
+type cdpAXNode struct {
+	NodeID      string          `json:"nodeId"`
+	Ignored     bool            `json:"ignored"`
+	Role        *cdpAXValue     `json:"role"`
+	Name        *cdpAXValue     `json:"name"`
+	Value       *cdpAXValue     `json:"value"`
+	Description *cdpAXValue     `json:"description"`
+	Properties  []cdpAXProperty `json:"properties"`
+	ParentID    string          `json:"parentId"`
+	ChildIDs    []string        `json:"childIds"`
+}
+
+// uninterestingRoles are structural roles that add no meaning unless the node is named.
+var uninterestingRoles = map[string]bool{
+	"generic":       true,
+	"none":          true,
+	"presentation":  true,
+	"InlineTextBox": true,
+	"LineBreak":     true,
+}
+
+// AccessibilitySnapshot returns the page's accessibility tree. With interestingOnly set,
+// ignored nodes and unnamed structural containers are dropped and their children moved up
+// to the nearest kept ancestor, which gives a much smaller semantic view of the page.
+// The tree is read through a Chrome DevTools Protocol session, so only Chromium is supported.
+func (pi *PlaywrightIntegration) AccessibilitySnapshot(ctx context.Context, page playwright.Page, interestingOnly bool) (*AXNode, error) {
+	if page == nil {
+		return nil, fmt.Errorf("playwright.Page cannot be nil")
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	session, err := page.Context().NewCDPSession(page)
+	if err != nil {
+		return nil, fmt.Errorf("accessibility snapshots require Chromium: %w", err)
+	}
+	defer session.Detach()
+
+	result, err := session.Send("Accessibility.getFullAXTree", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read accessibility tree: %w", err)
+	}
+	var tree struct {
+		Nodes []cdpAXNode `json:"nodes"`
+	}
+	if err := decodeScriptResult(result, &tree); err != nil {
+		return nil, fmt.Errorf("failed to decode accessibility tree: %w", err)
+	}
+
+	root := buildAXTree(tree.Nodes, interestingOnly)
+	if root == nil {
+		return nil, fmt.Errorf("page has no accessibility tree")
+	}
+	return root, nil
+}
+
+// buildAXTree assembles the flat CDP node list into a tree rooted at the first node without
+// a parent. The root is always kept, even when interestingOnly would drop it.
+func buildAXTree(nodes []cdpAXNode, interestingOnly bool) *AXNode {
+	byID := make(map[string]*cdpAXNode, len(nodes))
+	var rootID string
+	for i := range nodes {
+		byID[nodes[i].NodeID] = &nodes[i]
+		if nodes[i].ParentID == "" && rootID == "" {
+			rootID = nodes[i].NodeID
+		}
+	}
+	if rootID == "" {
+		return nil
+	}
+
+	var convert func(id string) *AXNode
+	var children func(n *cdpAXNode) []*AXNode
+	// children returns the kept descendants of a node, flattening dropped ones.
+	children = func(n *cdpAXNode) []*AXNode {
+		var kept []*AXNode
+		for _, childID := range n.ChildIDs {
+			child, ok := byID[childID]
+			if !ok {
+				continue
+			}
+			if interestingOnly && !isInterestingAXNode(child) {
+				kept = append(kept, children(child)...)
+				continue
+			}
+			kept = append(kept, convert(childID))
+		}
+		return kept
+	}
+	convert = func(id string) *AXNode {
+		n := byID[id]
+		node := &AXNode{
+			Role:        axString(n.Role),
+			Name:        axString(n.Name),
+			Description: axString(n.Description),
+			Children:    children(n),
+		}
+		if n.Value != nil {
+			node.Value = n.Value.Value
+		}
+		for _, p := range n.Properties {
+			if node.Properties == nil {
+				node.Properties = make(map[string]any, len(n.Properties))
+			}
+			node.Properties[p.Name] = p.Value.Value
+		}
+		return node
+	}
+	return convert(rootID)
+}
+
+// isInterestingAXNode reports whether a node is kept in an interestingOnly snapshot.
+func isInterestingAXNode(n *cdpAXNode) bool {
+	if n.Ignored {
+		return false
+	}
+	role := axString(n.Role)
+	if role == "InlineTextBox" {
+		return false
+	}
+	return !uninterestingRoles[role] || axString(n.Name) != ""
+}
+
+// axString returns the string form of a CDP AX value, or "" if it is absent.
+func axString(v *cdpAXValue) string {
+	if v == nil || v.Value == nil {
+		return ""
+	}
+	if s, ok := v.Value.(string); ok {
+		return s
+	}
+	return fmt.Sprint(v.Value)
+}
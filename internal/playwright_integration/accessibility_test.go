@@ -0,0 +1,35 @@
+package playwright_integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildAXTree(t *testing.T) {
+	value := func(v any) *cdpAXValue { return &cdpAXValue{Value: v} }
+	nodes := []cdpAXNode{
+		{NodeID: "1", Role: value("RootWebArea"), Name: value("Test"), ChildIDs: []string{"2"}},
+		{NodeID: "2", ParentID: "1", Role: value("generic"), ChildIDs: []string{"3", "4"}},
+		{NodeID: "3", ParentID: "2", Role: value("heading"), Name: value("Title"), ChildIDs: []string{"5"},
+			Properties: []cdpAXProperty{{Name: "level", Value: cdpAXValue{Value: float64(1)}}}},
+		{NodeID: "4", ParentID: "2", Role: value("none"), Ignored: true},
+		{NodeID: "5", ParentID: "3", Role: value("InlineTextBox"), Name: value("Title")},
+	}
+	full := buildAXTree(nodes, false)
+	if assert.NotNil(t, full) && assert.Len(t, full.Children, 1) {
+		assert.Equal(t, "generic", full.Children[0].Role)
+		assert.Len(t, full.Children[0].Children, 2)
+	}
+
+	interesting := buildAXTree(nodes, true)
+	if assert.NotNil(t, interesting) && assert.Len(t, interesting.Children, 1) {
+		heading := interesting.Children[0]
+		assert.Equal(t, "heading", heading.Role)
+		assert.Equal(t, "Title", heading.Name)
+		assert.Equal(t, float64(1), heading.Properties["level"])
+		assert.Empty(t, heading.Children)
+	}
+
+	assert.Nil(t, buildAXTree(nil, true))
+}
@@ -0,0 +1,166 @@
+package playwright_integration
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// Action types accepted by RunActions.
+const (
+	ActionNavigate   = "navigate"
+	ActionClick      = "click"
+	ActionType       = "type"
+	ActionWait       = "wait"
+	ActionScreenshot = "screenshot"
+	ActionWaitForURL = "wait_for_url"
+)
+
+// MaxActions is the largest number of steps RunActions accepts in one call.
+const MaxActions = 50
+
+// MaxActionWait is the longest fixed delay a wait step may request.
+const MaxActionWait = 30 * time.Second
+
+// Action is one step of a RunActions sequence.
+type Action struct {
+	Type     string `json:"type"`               // One of the Action* constants
+	URL      string `json:"url,omitempty"`      // Page to load, for navigate
+	Selector string `json:"selector,omitempty"` // Target element for click and type; for wait, the element to wait for
+	Frame    string `json:"frame,omitempty"`    // Selector of the iframe containing Selector, if any
+	Text     string `json:"text,omitempty"`     // Text entered by type, replacing the field's current value
+	// URLPattern is the URL a wait_for_url step waits for: a Playwright glob, or a regular
+	// expression wrapped in slashes.
+	URLPattern string `json:"url_pattern,omitempty"`
+	// DurationMs is a fixed delay for wait steps without a selector.
+	DurationMs int `json:"duration_ms,omitempty"`
+	// FullPage captures the whole scrollable page in a screenshot step.
+	FullPage bool `json:"full_page,omitempty"`
+}
+
+// Validate checks that the fields required by the action's type are set.
+func (a Action) Validate() error {
+	switch a.Type {
+	case ActionNavigate:
+		if a.URL == "" {
+			return fmt.Errorf("navigate requires url")
+		}
+	case ActionClick, ActionType:
+		if a.Selector == "" {
+			return fmt.Errorf("%s requires selector", a.Type)
+		}
+	case ActionWait:
+		if a.Selector == "" && a.DurationMs <= 0 {
+			return fmt.Errorf("wait requires selector or a positive duration_ms")
+		}
+		if time.Duration(a.DurationMs)*time.Millisecond > MaxActionWait {
+			return fmt.Errorf("wait duration_ms must not exceed %d", MaxActionWait.Milliseconds())
+		}
+	case ActionWaitForURL:
+		if a.URLPattern == "" {
+			return fmt.Errorf("wait_for_url requires url_pattern")
+		}
+		if _, err := urlMatcher(a.URLPattern); err != nil {
+			return err
+		}
+	case ActionScreenshot:
+	default:
+		return fmt.Errorf("unknown action type %q: expected navigate, click, type, wait, wait_for_url or screenshot", a.Type)
+	}
+	return nil
+}
+
+// ActionResult describes the page after one step of RunActions.
+type ActionResult struct {
+	Step       int    `json:"step"` // Zero-based index of the step
+	Type       string `json:"type"`
+	URL        string `json:"url"` // URL of the page after the step
+	Screenshot []byte `json:"-"`   // Set by screenshot steps
+}
+
+// ActionOptions configures RunActions.
+type ActionOptions struct {
+	// Navigation is used for navigate steps.
+	Navigation *NavigationOptions
+	// StepTimeout bounds each click, type, selector wait and URL wait. Zero uses Playwright's default timeout.
+	StepTimeout time.Duration
+}
+
+// RunActions performs actions in order on a single page, so cookies, form state and scroll
+// position carry over from one step to the next. It stops at the first failing step.
+func (pi *PlaywrightIntegration) RunActions(ctx context.Context, page playwright.Page, actions []Action, opts ActionOptions) ([]ActionResult, error) {
+	if page == nil {
+		return nil, fmt.Errorf("playwright.Page cannot be nil")
+	}
+	if len(actions) > MaxActions {
+		return nil, fmt.Errorf("too many actions: got %d, at most %d are allowed", len(actions), MaxActions)
+	}
+	for i, action := range actions {
+		if err := action.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid step %d: %w", i, err)
+		}
+	}
+
+	var timeout *float64
+	if opts.StepTimeout > 0 {
+		timeout = playwright.Float(float64(opts.StepTimeout.Milliseconds()))
+	}
+
+	results := make([]ActionResult, 0, len(actions))
+	for i, action := range actions {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+		pi.logger.Debug("Running action", "step", i, "type", action.Type)
+
+		result := ActionResult{Step: i, Type: action.Type}
+		var err error
+		switch action.Type {
+		case ActionNavigate:
+			_, err = pi.NavigateToURLOnPage(ctx, page, action.URL, opts.Navigation)
+		case ActionClick:
+			_, err = pi.ClickElement(ctx, page, action.Selector, ClickOptions{Timeout: opts.StepTimeout, Frame: action.Frame})
+		case ActionType:
+			var locator playwright.Locator
+			if locator, err = pi.locate(page, action.Frame, action.Selector); err == nil {
+				err = locator.First().Fill(action.Text, playwright.LocatorFillOptions{Timeout: timeout})
+			}
+		case ActionWait:
+			err = pi.waitAction(ctx, page, action, timeout)
+		case ActionWaitForURL:
+			_, err = pi.WaitForURL(ctx, page, action.URLPattern, opts.StepTimeout.Seconds())
+		case ActionScreenshot:
+			result.Screenshot, err = pi.CaptureScreenshot(ctx, page, PageScreenshotOptions{FullPage: action.FullPage})
+		}
+		if err != nil {
+			return results, pi.debugScreenshot(page, fmt.Errorf("step %d (%s) failed: %w", i, action.Type, err))
+		}
+		result.URL = page.URL()
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// waitAction waits for the action's selector to become visible, or for its fixed delay.
+func (pi *PlaywrightIntegration) waitAction(ctx context.Context, page playwright.Page, action Action, timeout *float64) error {
+	if action.Selector != "" {
+		locator, err := pi.locate(page, action.Frame, action.Selector)
+		if err != nil {
+			return err
+		}
+		return locator.First().WaitFor(playwright.LocatorWaitForOptions{
+			State:   playwright.WaitForSelectorStateVisible,
+			Timeout: timeout,
+		})
+	}
+	timer := time.NewTimer(time.Duration(action.DurationMs) * time.Millisecond)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
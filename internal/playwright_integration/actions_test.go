@@ -0,0 +1,37 @@
+package playwright_integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActionValidate(t *testing.T) {
+	valid := []Action{
+		{Type: ActionNavigate, URL: "https://example.com"},
+		{Type: ActionClick, Selector: "button"},
+		{Type: ActionType, Selector: "input", Text: ""},
+		{Type: ActionWait, Selector: "#done"},
+		{Type: ActionWait, DurationMs: 500},
+		{Type: ActionWaitForURL, URLPattern: "**/done"},
+		{Type: ActionWaitForURL, URLPattern: "/step=[0-9]+/"},
+		{Type: ActionScreenshot},
+	}
+	for _, action := range valid {
+		assert.NoError(t, action.Validate(), "%+v", action)
+	}
+
+	invalid := []Action{
+		{Type: ActionNavigate},
+		{Type: ActionClick},
+		{Type: ActionType, Text: "hello"},
+		{Type: ActionWait},
+		{Type: ActionWait, DurationMs: 60000},
+		{Type: ActionWaitForURL},
+		{Type: ActionWaitForURL, URLPattern: "/[/"},
+		{Type: "scroll"},
+	}
+	for _, action := range invalid {
+		assert.Error(t, action.Validate(), "%+v", action)
+	}
+}
@@ -0,0 +1,104 @@
+package playwright_integration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// auditWrapper runs an audit script given as an expression or as an async function body (see
+// auditExpression and auditBody). It catches what the script throws and returns the result as
+// JSON text, so values that cannot be serialized fail loudly instead of turning into empty
+// objects. Since runtime errors are caught, an evaluation error means the wrapped script did
+// not parse, and none of it ran.
+const auditWrapper = `async () => {
+	let result;
+	try {
+		result = await %s;
+	} catch (e) {
+		return { error: { message: String(e && e.message !== undefined ? e.message : e), stack: (e && e.stack) || "" } };
+	}
+	try {
+		return { json: JSON.stringify(result === undefined ? null : result) };
+	} catch (e) {
+		return { error: { message: "result is not JSON-serializable: " + e.message, stack: "" } };
+	}
+}`
+
+// The script sits on its own lines so a trailing line comment cannot swallow the closing brackets.
+const (
+	auditExpression = "(\n%s\n)"
+	auditBody       = "(async () => {\n%s\n})()"
+)
+
+// ScriptError is returned by RunAudit when the audit script does not parse, throws, or
+// returns a value that cannot be converted to JSON.
+type ScriptError struct {
+	Message string `json:"message"`
+	Stack   string `json:"stack,omitempty"`
+}
+
+func (e *ScriptError) Error() string {
+	if e.Stack == "" {
+		return "audit script failed: " + e.Message
+	}
+	return fmt.Sprintf("audit script failed: %s\n%s", e.Message, e.Stack)
+}
+
+// auditOutcome is the value returned by auditWrapper.
+type auditOutcome struct {
+	JSON  *string      `json:"json"`
+	Error *ScriptError `json:"error"`
+}
+
+// RunAudit evaluates script in the page and returns its result as JSON. script is either a
+// JavaScript expression, such as document.querySelectorAll('img:not([alt])').length, or the
+// body of an async function that returns the result with a return statement. A script that
+// does not parse as an expression is run as a function body; it never runs twice.
+func (pi *PlaywrightIntegration) RunAudit(ctx context.Context, page playwright.Page, script string) (json.RawMessage, error) {
+	if page == nil {
+		return nil, fmt.Errorf("playwright.Page cannot be nil")
+	}
+	if strings.TrimSpace(script) == "" {
+		return nil, fmt.Errorf("audit script is empty")
+	}
+
+	expression := strings.TrimRight(strings.TrimSpace(script), ";")
+	result, err := pi.ExecuteScript(ctx, page, fmt.Sprintf(auditWrapper, fmt.Sprintf(auditExpression, expression)))
+	if err != nil && ctx.Err() == nil && isSyntaxError(err) {
+		pi.logger.Debug("Audit script is not an expression, running it as a function body")
+		result, err = pi.ExecuteScript(ctx, page, fmt.Sprintf(auditWrapper, fmt.Sprintf(auditBody, script)))
+	}
+	if err != nil {
+		var pwErr *playwright.Error
+		if ctx.Err() == nil && errors.As(err, &pwErr) {
+			return nil, &ScriptError{Message: pwErr.Message, Stack: pwErr.Stack}
+		}
+		return nil, err
+	}
+
+	var outcome auditOutcome
+	if err := decodeScriptResult(result, &outcome); err != nil {
+		return nil, fmt.Errorf("failed to decode audit result: %w", err)
+	}
+	if outcome.Error != nil {
+		return nil, outcome.Error
+	}
+	if outcome.JSON == nil {
+		return nil, fmt.Errorf("audit script returned no result")
+	}
+	return json.RawMessage(*outcome.JSON), nil
+}
+
+// isSyntaxError reports whether a failed evaluation was rejected by the JavaScript parser.
+func isSyntaxError(err error) bool {
+	var pwErr *playwright.Error
+	if errors.As(err, &pwErr) {
+		return pwErr.Name == "SyntaxError" || strings.Contains(pwErr.Message, "SyntaxError")
+	}
+	return strings.Contains(err.Error(), "SyntaxError")
+}
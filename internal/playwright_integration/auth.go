@@ -0,0 +1,50 @@
+package playwright_integration
+
+import (
+	"errors"
+	"log/slog"
+	"strings"
+)
+
+// ErrAuthenticationFailed is returned when a page answers 401 Unauthorized even though
+// HTTP credentials were supplied.
+var ErrAuthenticationFailed = errors.New("authentication failed")
+
+// ErrProxyAuthenticationFailed is returned when the configured proxy rejects its credentials.
+var ErrProxyAuthenticationFailed = errors.New("proxy authentication failed")
+
+// isProxyAuthError reports whether a navigation error was caused by the proxy refusing
+// to authenticate the browser.
+func isProxyAuthError(err error) bool {
+	return strings.Contains(err.Error(), "net::ERR_PROXY_AUTH")
+}
+
+// HTTPCredentials are the username and password used to answer HTTP authentication challenges.
+type HTTPCredentials struct {
+	Username string
+	Password string
+}
+
+// LogValue implements slog.LogValuer so the password never reaches log output.
+func (c HTTPCredentials) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("username", c.Username),
+		slog.String("password", redactedValue),
+	)
+}
+
+// redactedValue replaces the values of credential headers in captured network data.
+const redactedValue = "[REDACTED]"
+
+// redactHeaders returns a copy of headers with the values of headers carrying credentials
+// replaced, so they never appear in captured network data.
+func redactHeaders(headers map[string]string) map[string]string {
+	redacted := make(map[string]string, len(headers))
+	for name, value := range headers {
+		if strings.EqualFold(name, "Authorization") || strings.EqualFold(name, "Proxy-Authorization") {
+			value = redactedValue
+		}
+		redacted[name] = value
+	}
+	return redacted
+}
@@ -0,0 +1,108 @@
+package playwright_integration
+
+import (
+	_ "embed"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// TrackersPreset is the block rule that enables the embedded tracker domain list.
+const TrackersPreset = "trackers"
+
+//go:embed trackers.txt
+var trackersList string
+
+// blockableResourceTypes are the Playwright resource types accepted as block rules.
+var blockableResourceTypes = map[string]bool{
+	"image":       true,
+	"font":        true,
+	"media":       true,
+	"stylesheet":  true,
+	"script":      true,
+	"texttrack":   true,
+	"xhr":         true,
+	"fetch":       true,
+	"eventsource": true,
+	"websocket":   true,
+	"manifest":    true,
+	"other":       true,
+}
+
+// resourceBlocker decides whether a request should be aborted.
+type resourceBlocker struct {
+	resourceTypes  map[string]bool
+	patterns       []*regexp.Regexp
+	trackerDomains []string
+}
+
+// newResourceBlocker compiles block rules. Each rule is a Playwright resource type
+// (e.g. "image", "font"), the TrackersPreset, or a URL glob such as "**/*.woff2".
+func newResourceBlocker(rules []string) (*resourceBlocker, error) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	b := &resourceBlocker{resourceTypes: make(map[string]bool)}
+	for _, rule := range rules {
+		rule = strings.TrimSpace(rule)
+		switch {
+		case rule == "":
+			continue
+		case rule == TrackersPreset:
+			b.trackerDomains = parseTrackerDomains(trackersList)
+		case blockableResourceTypes[rule]:
+			b.resourceTypes[rule] = true
+		default:
+			if !strings.ContainsAny(rule, "*/?{") {
+				return nil, fmt.Errorf("unknown block rule %q: expected a resource type, %q, or a URL pattern", rule, TrackersPreset)
+			}
+			pattern, err := compileGlob(rule)
+			if err != nil {
+				return nil, err
+			}
+			b.patterns = append(b.patterns, pattern)
+		}
+	}
+	return b, nil
+}
+
+// shouldBlock reports whether a request with the given URL and resource type matches a rule.
+func (b *resourceBlocker) shouldBlock(requestURL, resourceType string) bool {
+	if b == nil {
+		return false
+	}
+	if b.resourceTypes[resourceType] {
+		return true
+	}
+	for _, p := range b.patterns {
+		if p.MatchString(requestURL) {
+			return true
+		}
+	}
+	if len(b.trackerDomains) > 0 {
+		if u, err := url.Parse(requestURL); err == nil {
+			host := strings.ToLower(u.Hostname())
+			for _, domain := range b.trackerDomains {
+				if host == domain || strings.HasSuffix(host, "."+domain) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// parseTrackerDomains reads the embedded tracker list, skipping comments and blank lines.
+func parseTrackerDomains(list string) []string {
+	var domains []string
+	for _, line := range strings.Split(list, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		domains = append(domains, strings.ToLower(line))
+	}
+	return domains
+}
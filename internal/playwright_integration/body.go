@@ -0,0 +1,161 @@
+package playwright_integration
+
+import (
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// textContentTypes are non-text/* media types whose bodies are captured as text.
+var textContentTypes = map[string]bool{
+	"application/json":                  true,
+	"application/javascript":            true,
+	"application/ecmascript":            true,
+	"application/xml":                   true,
+	"application/xhtml+xml":             true,
+	"application/x-www-form-urlencoded": true,
+	"application/graphql":               true,
+}
+
+// isTextContentType reports whether a Content-Type header describes a textual body.
+// A missing Content-Type is treated as text, since such bodies are usually empty or plain text.
+func isTextContentType(contentType string) bool {
+	if strings.TrimSpace(contentType) == "" {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return strings.HasPrefix(mediaType, "text/") ||
+		strings.HasSuffix(mediaType, "+json") ||
+		strings.HasSuffix(mediaType, "+xml") ||
+		textContentTypes[mediaType]
+}
+
+// bodyPlaceholder decides whether a response body should be captured. It returns false for
+// textual bodies. Redirects have no body to capture and get an empty placeholder; binary bodies
+// get "[binary N bytes]" when the size is known from Content-Length, or "[binary]" otherwise.
+func bodyPlaceholder(status int, headers map[string]string) (string, bool) {
+	if status >= 300 && status < 400 {
+		return "", true
+	}
+	if isTextContentType(headerValue(headers, "Content-Type")) {
+		return "", false
+	}
+	if size, err := strconv.Atoi(headerValue(headers, "Content-Length")); err == nil {
+		return binaryPlaceholder(size), true
+	}
+	return "[binary]", true
+}
+
+// binaryPlaceholder describes a binary body of the given size.
+func binaryPlaceholder(size int) string {
+	return fmt.Sprintf("[binary %d bytes]", size)
+}
+
+// Default limits of SetCaptureLimits.
+const (
+	DefaultMaxBodyBytes    = 256 * 1024
+	DefaultMaxCaptureBytes = 5 * 1024 * 1024
+)
+
+// SetCaptureLimits bounds the response bodies recorded by network capture: maxBodyBytes per
+// body and maxCaptureBytes for all bodies captured on a page since its last
+// SetupNetworkInterception.
+// Longer bodies are cut and flagged with BodyTruncated. Zero disables a limit.
+func (pi *PlaywrightIntegration) SetCaptureLimits(maxBodyBytes, maxCaptureBytes int) {
+	pi.captureMu.Lock()
+	defer pi.captureMu.Unlock()
+	pi.maxBodyBytes = maxBodyBytes
+	pi.maxCaptureBytes = maxCaptureBytes
+}
+
+// reserveBodyBytes returns how many of a body's size bytes may be recorded under the per-body
+// limit and, if state is not nil, the total capture limit of its page, whose budget it then
+// consumes.
+func (pi *PlaywrightIntegration) reserveBodyBytes(state *pageCapture, size int) int {
+	pi.captureMu.Lock()
+	defer pi.captureMu.Unlock()
+	if pi.maxBodyBytes > 0 && size > pi.maxBodyBytes {
+		size = pi.maxBodyBytes
+	}
+	if state != nil && pi.maxCaptureBytes > 0 {
+		size = max(0, min(size, pi.maxCaptureBytes-state.bodyBytes))
+		state.bodyBytes += size
+	}
+	return size
+}
+
+// captureResponseBody records the body of response in resp, whose Status and Headers must be
+// set: the text of textual bodies, decoded to UTF-8 by decodeBody and base64-encoded if it is
+// still not valid UTF-8, or a placeholder for redirects and binary content. Bodies captured for
+// a page's state count against its total capture limit; state may be nil.
+func (pi *PlaywrightIntegration) captureResponseBody(response playwright.Response, resp *CapturedResponse, state *pageCapture) {
+	placeholder, skip := bodyPlaceholder(resp.Status, resp.Headers)
+	if skip {
+		if placeholder == "[binary]" {
+			// Without a Content-Length the size is only known from the body itself.
+			if body, err := response.Body(); err == nil {
+				placeholder = binaryPlaceholder(len(body))
+				resp.BodySize = len(body)
+			}
+		} else if size, err := strconv.Atoi(headerValue(resp.Headers, "Content-Length")); err == nil {
+			resp.BodySize = size
+		}
+		resp.Body = placeholder
+		return
+	}
+
+	body, err := response.Body()
+	if err != nil {
+		pi.logger.Warn("Failed to get response body", "url", response.URL(), "error", err)
+		return
+	}
+	body, resp.Charset = decodeBody(body, resp.Headers)
+	resp.BodySize = len(body)
+	resp.Body, resp.Encoding, resp.BodyTruncated = encodeBody(body, pi.reserveBodyBytes(state, len(body)))
+}
+
+// encodeBody returns at most limit bytes of body as a string. Bodies that are not valid UTF-8
+// are base64-encoded, with encoding "base64", so the capture always marshals to valid JSON.
+// Truncated UTF-8 text is cut at a character boundary.
+func encodeBody(body []byte, limit int) (text, encoding string, truncated bool) {
+	if len(body) > limit {
+		truncated = true
+		body = trimPartialRune(body[:limit])
+	}
+	if utf8.Valid(body) {
+		return string(body), "", truncated
+	}
+	return base64.StdEncoding.EncodeToString(body), "base64", truncated
+}
+
+// trimPartialRune drops an incomplete UTF-8 sequence that truncation left at the end of b.
+func trimPartialRune(b []byte) []byte {
+	for i := 1; i <= utf8.UTFMax && i <= len(b); i++ {
+		if !utf8.RuneStart(b[len(b)-i]) {
+			continue
+		}
+		if r, size := utf8.DecodeRune(b[len(b)-i:]); r == utf8.RuneError && size == 1 {
+			return b[:len(b)-i]
+		}
+		break
+	}
+	return b
+}
+
+// headerValue looks up a header by name, ignoring case.
+func headerValue(headers map[string]string, name string) string {
+	for k, v := range headers {
+		if strings.EqualFold(k, name) {
+			return v
+		}
+	}
+	return ""
+}
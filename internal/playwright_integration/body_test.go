@@ -0,0 +1,64 @@
+package playwright_integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBodyPlaceholder_Redirect(t *testing.T) {
+	placeholder, skip := bodyPlaceholder(302, map[string]string{"location": "/next", "content-type": "text/html"})
+	assert.True(t, skip)
+	assert.Empty(t, placeholder)
+}
+
+func TestBodyPlaceholder_Image(t *testing.T) {
+	placeholder, skip := bodyPlaceholder(200, map[string]string{"content-type": "image/png", "content-length": "12345"})
+	assert.True(t, skip)
+	assert.Equal(t, "[binary 12345 bytes]", placeholder)
+
+	placeholder, skip = bodyPlaceholder(200, map[string]string{"Content-Type": "image/webp"})
+	assert.True(t, skip)
+	assert.Equal(t, "[binary]", placeholder)
+}
+
+func TestBodyPlaceholder_Text(t *testing.T) {
+	for _, contentType := range []string{"text/html; charset=utf-8", "application/json", "application/ld+json", ""} {
+		_, skip := bodyPlaceholder(200, map[string]string{"content-type": contentType})
+		assert.False(t, skip, contentType)
+	}
+}
+
+func TestEncodeBody(t *testing.T) {
+	text, encoding, truncated := encodeBody([]byte(`{"ok": true}`), 100)
+	assert.Equal(t, `{"ok": true}`, text)
+	assert.Empty(t, encoding)
+	assert.False(t, truncated)
+
+	// "ü" is two bytes; a cut through it must not leave half a character behind.
+	text, encoding, truncated = encodeBody([]byte("grün"), 3)
+	assert.Equal(t, "gr", text)
+	assert.Empty(t, encoding)
+	assert.True(t, truncated)
+
+	text, encoding, truncated = encodeBody([]byte{0xff, 0xfe, 'a', 'b'}, 100)
+	assert.Equal(t, "//5hYg==", text)
+	assert.Equal(t, "base64", encoding)
+	assert.False(t, truncated)
+}
+
+func TestReserveBodyBytes(t *testing.T) {
+	pi := &PlaywrightIntegration{}
+	pi.SetCaptureLimits(100, 250)
+	state := &pageCapture{}
+
+	assert.Equal(t, 100, pi.reserveBodyBytes(state, 1000), "cut to the per-body limit")
+	assert.Equal(t, 100, pi.reserveBodyBytes(state, 100))
+	assert.Equal(t, 80, pi.reserveBodyBytes(nil, 80), "unshared bodies leave the total alone")
+	assert.Equal(t, 50, pi.reserveBodyBytes(state, 80), "cut to the remaining total")
+	assert.Equal(t, 0, pi.reserveBodyBytes(state, 10))
+	assert.Equal(t, 100, pi.reserveBodyBytes(&pageCapture{}, 100), "each page has its own total")
+
+	pi.SetCaptureLimits(0, 0)
+	assert.Equal(t, 1<<20, pi.reserveBodyBytes(state, 1<<20))
+}
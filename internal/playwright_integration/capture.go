@@ -0,0 +1,57 @@
+package playwright_integration
+
+import (
+	"github.com/Camelket/mcp-browser-tools/internal/safety"
+	"github.com/playwright-community/playwright-go"
+)
+
+// pageCapture is the capture state of one page. Pages capture independently, so concurrent
+// tool calls each read their own traffic and policy violations. All fields are guarded by PlaywrightIntegration.captureMu.
+type pageCapture struct {
+	networkData     []CapturedNetworkActivity
+	pending         []pendingRequest     // Routed requests awaiting a response or failure, in request order
+	bodyBytes       int                  // Body bytes captured, counted against maxCaptureBytes
+	blockedRequests int                  // Requests aborted by block rules
+	webSockets      []*WebSocketActivity // WebSockets opened since CaptureWebSockets
+	protocols       map[string]string    // Network protocol by response URL, see watchProtocols
+	policyViolation *safety.BlockedError // Last navigation request blocked by the URL policy
+}
+
+// captureState returns the capture state of page, creating it if needed. The state is dropped
+// when the page closes. captureMu must be held.
+func (pi *PlaywrightIntegration) captureState(page playwright.Page) *pageCapture {
+	if state, ok := pi.captures[page]; ok {
+		return state
+	}
+	if pi.captures == nil {
+		pi.captures = make(map[playwright.Page]*pageCapture)
+	}
+	page.OnClose(func(playwright.Page) {
+		pi.captureMu.Lock()
+		defer pi.captureMu.Unlock()
+		delete(pi.captures, page)
+	})
+	state := &pageCapture{}
+	pi.captures[page] = state
+	return state
+}
+
+// resetCapture starts a new network capture on page and returns its state, which the page's
+// event handlers record into. Handlers of an earlier capture keep writing to the old state, so
+// they cannot add to the new one. WebSockets recorded by CaptureWebSockets are kept.
+func (pi *PlaywrightIntegration) resetCapture(page playwright.Page) *pageCapture {
+	pi.captureMu.Lock()
+	defer pi.captureMu.Unlock()
+	state := &pageCapture{webSockets: pi.captureState(page).webSockets}
+	pi.captures[page] = state
+	return state
+}
+
+// recordedCapture returns the capture state of page for reading, or an empty state if nothing
+// was captured on it. captureMu must be held.
+func (pi *PlaywrightIntegration) recordedCapture(page playwright.Page) *pageCapture {
+	if state, ok := pi.captures[page]; ok {
+		return state
+	}
+	return &pageCapture{}
+}
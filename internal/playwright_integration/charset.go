@@ -0,0 +1,110 @@
+package playwright_integration
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"regexp"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	"github.com/playwright-community/playwright-go"
+	"golang.org/x/text/encoding/htmlindex"
+)
+
+// maxDecodedBodyBytes bounds the size of a decompressed body; larger bodies are kept compressed.
+const maxDecodedBodyBytes = 50 << 20
+
+// metaCharset matches the charset of a <meta charset> or <meta http-equiv="Content-Type"> tag.
+var metaCharset = regexp.MustCompile(`(?i)<meta[^>]+charset\s*=\s*["']?\s*([-\w.:]+)`)
+
+// decodeBody turns a captured text body into UTF-8. Bodies still compressed as their
+// Content-Encoding says are decompressed first; browsers normally hand them over decoded.
+// The charset comes from the Content-Type header, a byte order mark or, for HTML, a meta tag
+// in the first 1024 bytes, and is decoded as browsers do, so ISO-8859-1 is read as
+// windows-1252. Unknown charsets are left as they are. It returns the body and the charset,
+// "" if none is declared.
+func decodeBody(body []byte, headers map[string]string) ([]byte, string) {
+	body = decompressBody(body, headerValue(headers, "Content-Encoding"))
+	charset := bodyCharset(body, headerValue(headers, "Content-Type"))
+	if charset == "" {
+		return body, ""
+	}
+	enc, err := htmlindex.Get(charset)
+	if err != nil {
+		return body, charset
+	}
+	if name, _ := htmlindex.Name(enc); name == "utf-8" {
+		return body, charset
+	}
+	if decoded, err := enc.NewDecoder().Bytes(body); err == nil {
+		body = decoded
+	}
+	return body, charset
+}
+
+// bodyCharset returns the lowercased charset label of a body, or "" if none is declared.
+func bodyCharset(body []byte, contentType string) string {
+	mediaType, params, _ := mime.ParseMediaType(contentType)
+	if charset := strings.TrimSpace(params["charset"]); charset != "" {
+		return strings.ToLower(charset)
+	}
+	if bytes.HasPrefix(body, []byte("\xef\xbb\xbf")) {
+		return "utf-8"
+	}
+	if mediaType == "text/html" || mediaType == "application/xhtml+xml" || mediaType == "" {
+		if m := metaCharset.FindSubmatch(body[:min(len(body), 1024)]); m != nil {
+			return strings.ToLower(string(m[1]))
+		}
+	}
+	return ""
+}
+
+// decompressBody decodes a gzip, deflate or br body whose data is still compressed. Other
+// encodings and bodies that fail to decode are returned unchanged.
+func decompressBody(body []byte, contentEncoding string) []byte {
+	var reader io.Reader
+	var err error
+	switch strings.ToLower(strings.TrimSpace(contentEncoding)) {
+	case "gzip", "x-gzip":
+		if !bytes.HasPrefix(body, []byte{0x1f, 0x8b}) {
+			return body
+		}
+		reader, err = gzip.NewReader(bytes.NewReader(body))
+	case "deflate":
+		// "deflate" should be zlib-wrapped, but some servers send raw deflate data.
+		if len(body) >= 2 && body[0]&0x0f == 8 && (int(body[0])<<8|int(body[1]))%31 == 0 {
+			reader, err = zlib.NewReader(bytes.NewReader(body))
+		} else {
+			reader = flate.NewReader(bytes.NewReader(body))
+		}
+	case "br":
+		reader = brotli.NewReader(bytes.NewReader(body))
+	default:
+		return body
+	}
+	if err != nil {
+		return body
+	}
+	decoded, err := io.ReadAll(io.LimitReader(reader, maxDecodedBodyBytes+1))
+	if err != nil || len(decoded) > maxDecodedBodyBytes {
+		return body
+	}
+	return decoded
+}
+
+// DocumentCharset returns the character encoding the browser decoded the page's document
+// with, e.g. "UTF-8" or "windows-1252".
+func (pi *PlaywrightIntegration) DocumentCharset(ctx context.Context, page playwright.Page) (string, error) {
+	result, err := pi.ExecuteScript(ctx, page, "() => document.characterSet")
+	if err != nil {
+		return "", fmt.Errorf("failed to read document charset: %w", err)
+	}
+	charset, _ := result.(string)
+	return charset, nil
+}
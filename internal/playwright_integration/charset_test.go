@@ -0,0 +1,98 @@
+package playwright_integration
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/stretchr/testify/assert"
+)
+
+// latin1Page is an ISO-8859-1 encoded page that declares its charset in a meta tag.
+var latin1Page = []byte("<html><head><meta charset=\"iso-8859-1\"></head><body>Caf\xe9 \x80 \xbd</body></html>")
+
+func gzipped(t *testing.T, data []byte) []byte {
+	var b bytes.Buffer
+	w := gzip.NewWriter(&b)
+	_, err := w.Write(data)
+	assert.NoError(t, err)
+	assert.NoError(t, w.Close())
+	return b.Bytes()
+}
+
+func TestDecodeBodyTranscodesLatin1(t *testing.T) {
+	body, charset := decodeBody(latin1Page, map[string]string{"content-type": "text/html"})
+	assert.Equal(t, "iso-8859-1", charset)
+	assert.Equal(t, `<html><head><meta charset="iso-8859-1"></head><body>Café € ½</body></html>`, string(body))
+
+	body, charset = decodeBody([]byte("na\xefve"), map[string]string{"Content-Type": "text/plain; charset=Windows-1252"})
+	assert.Equal(t, "windows-1252", charset)
+	assert.Equal(t, "naïve", string(body))
+}
+
+func TestDecodeBodyTranscodesShiftJIS(t *testing.T) {
+	// "日本語のページ" in Shift_JIS, declared in a meta tag.
+	page := []byte("<html><head><meta http-equiv=\"Content-Type\" content=\"text/html; charset=Shift_JIS\"></head>" +
+		"<body>\x93\xfa\x96\x7b\x8c\xea\x82\xcc\x83\x79\x81\x5b\x83\x57</body></html>")
+	body, charset := decodeBody(page, map[string]string{"content-type": "text/html"})
+	assert.Equal(t, "shift_jis", charset)
+	assert.Contains(t, string(body), "<body>日本語のページ</body>")
+
+	body, charset = decodeBody([]byte("\x93\xfa\x96\x7b"), map[string]string{"content-type": "text/plain; charset=sjis"})
+	assert.Equal(t, "sjis", charset)
+	assert.Equal(t, "日本", string(body))
+}
+
+func TestDecodeBodyLeavesUnknownCharsets(t *testing.T) {
+	raw := []byte("\x93\xfa\x96\x7b")
+	body, charset := decodeBody(raw, map[string]string{"content-type": "text/plain; charset=x-made-up"})
+	assert.Equal(t, "x-made-up", charset)
+	assert.Equal(t, raw, body)
+
+	body, charset = decodeBody([]byte("\xef\xbb\xbfhello"), map[string]string{"content-type": "text/plain"})
+	assert.Equal(t, "utf-8", charset)
+	assert.Equal(t, "\xef\xbb\xbfhello", string(body))
+
+	body, charset = decodeBody([]byte("plain"), nil)
+	assert.Equal(t, "", charset)
+	assert.Equal(t, "plain", string(body))
+}
+
+func TestDecodeBodyDecompresses(t *testing.T) {
+	json := []byte(`{"name":"gzipped"}`)
+	headers := map[string]string{"content-type": "application/json", "content-encoding": "gzip"}
+	body, _ := decodeBody(gzipped(t, json), headers)
+	assert.Equal(t, json, body)
+
+	// Bodies the browser already decoded are left alone despite the header.
+	body, _ = decodeBody(json, headers)
+	assert.Equal(t, json, body)
+
+	var b bytes.Buffer
+	w := zlib.NewWriter(&b)
+	w.Write(json)
+	w.Close()
+	body, _ = decodeBody(b.Bytes(), map[string]string{"content-encoding": "deflate"})
+	assert.Equal(t, json, body)
+
+	compressed := gzipped(t, latin1Page)
+	body, charset := decodeBody(compressed, map[string]string{"content-type": "text/html", "content-encoding": "gzip"})
+	assert.Equal(t, "iso-8859-1", charset)
+	assert.Contains(t, string(body), "Café")
+
+	b.Reset()
+	br := brotli.NewWriter(&b)
+	br.Write(latin1Page)
+	br.Close()
+	body, charset = decodeBody(b.Bytes(), map[string]string{"content-type": "text/html", "content-encoding": "br"})
+	assert.Equal(t, "iso-8859-1", charset)
+	assert.Contains(t, string(body), "Café € ½")
+	body, _ = decodeBody(json, map[string]string{"content-encoding": "br"})
+	assert.Equal(t, json, body, "decoded bodies are left alone for br too")
+
+	truncated := compressed[:len(compressed)/2]
+	body, _ = decodeBody(truncated, map[string]string{"content-encoding": "gzip"})
+	assert.Equal(t, truncated, body, "undecodable bodies are kept as they are")
+}
@@ -0,0 +1,81 @@
+package playwright_integration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// ClickOptions configures ClickElement.
+type ClickOptions struct {
+	// WaitForURL, if set, is a URL pattern the page is expected to reach after the click,
+	// e.g. through client-side routing or a form submission.
+	WaitForURL string
+	// RegexPattern treats WaitForURL as a regular expression instead of a Playwright glob.
+	RegexPattern bool
+	// Timeout bounds the click and the wait for the URL. Zero uses Playwright's default timeout.
+	Timeout time.Duration
+	// Frame, if set, is the selector of the iframe containing the element.
+	Frame string
+}
+
+// ClickResult describes the page after ClickElement.
+type ClickResult struct {
+	URL        string `json:"url"`         // URL of the page after the click
+	URLMatched bool   `json:"url_matched"` // Whether the page reached ClickOptions.WaitForURL; false if no pattern was given
+}
+
+// ClickElement clicks the first element matching selector. If opts.WaitForURL is set it then
+// waits for the page URL to match; a timeout is not an error, since the click may have had
+// other effects, and is reported through ClickResult.URLMatched instead.
+func (pi *PlaywrightIntegration) ClickElement(ctx context.Context, page playwright.Page, selector string, opts ClickOptions) (*ClickResult, error) {
+	if page == nil {
+		return nil, fmt.Errorf("playwright.Page cannot be nil")
+	}
+
+	var matcher interface{}
+	if opts.WaitForURL != "" {
+		var err error
+		if matcher, err = compileURLPattern(opts.WaitForURL, opts.RegexPattern); err != nil {
+			return nil, err
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	locator, err := pi.locate(page, opts.Frame, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	clickOptions := playwright.LocatorClickOptions{}
+	if opts.Timeout > 0 {
+		clickOptions.Timeout = playwright.Float(float64(opts.Timeout.Milliseconds()))
+	}
+	pi.logger.Debug("Clicking element", "selector", selector, "frame", opts.Frame)
+	if err := locator.First().Click(clickOptions); err != nil {
+		return nil, pi.debugScreenshot(page, fmt.Errorf("failed to click element %q: %w", selector, err))
+	}
+
+	result := &ClickResult{}
+	if matcher != nil {
+		waitOptions := playwright.PageWaitForURLOptions{}
+		if opts.Timeout > 0 {
+			waitOptions.Timeout = playwright.Float(float64(opts.Timeout.Milliseconds()))
+		}
+		err := page.WaitForURL(matcher, waitOptions)
+		switch {
+		case err == nil:
+			result.URLMatched = true
+		case errors.Is(err, playwright.ErrTimeout):
+			pi.logger.Info("URL did not match after click", "pattern", opts.WaitForURL, "url", page.URL())
+		default:
+			return nil, pi.debugScreenshot(page, fmt.Errorf("failed waiting for URL %s: %w", opts.WaitForURL, err))
+		}
+	}
+	result.URL = page.URL()
+	return result, nil
+}
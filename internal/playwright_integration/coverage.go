@@ -0,0 +1,263 @@
+package playwright_integration
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// DefaultCoverageLimit is the number of coverage entries returned by default.
+const DefaultCoverageLimit = 20
+
+// Coverage entry types.
+const (
+	CoverageTypeJS  = "js"
+	CoverageTypeCSS = "css"
+)
+
+// CoverageEntry is how much of a script or stylesheet the page used. Inline scripts and
+// stylesheets are counted under the document URL. Sizes are in characters of source, which
+// equals bytes for ASCII.
+type CoverageEntry struct {
+	URL         string  `json:"url"`
+	TotalBytes  int     `json:"total_bytes"`
+	UsedBytes   int     `json:"used_bytes"`
+	UsedPercent float64 `json:"used_percent"` // Rounded to one decimal
+	Type        string  `json:"type"`         // "js" or "css"
+}
+
+// coverageRange is a range of source offsets executed count times.
+type coverageRange struct {
+	Start int `json:"startOffset"`
+	End   int `json:"endOffset"`
+	Count int `json:"count"`
+}
+
+// coverageSource is a script or stylesheet reported by the CDP session.
+type coverageSource struct {
+	url    string
+	length int
+}
+
+// RecordCoverage records which JavaScript and CSS the page uses while run executes, typically
+// the navigation, and returns an entry per script and stylesheet URL, least used first. Scripts
+// and stylesheets without a URL, such as those created by eval, are left out. Coverage is read
+// through a Chrome DevTools Protocol session, so only Chromium is supported.
+func (pi *PlaywrightIntegration) RecordCoverage(ctx context.Context, page playwright.Page, run func() error) ([]CoverageEntry, error) {
+	if page == nil {
+		return nil, fmt.Errorf("playwright.Page cannot be nil")
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	session, err := page.Context().NewCDPSession(page)
+	if err != nil {
+		return nil, fmt.Errorf("coverage requires Chromium: %w", err)
+	}
+	defer session.Detach()
+
+	var mu sync.Mutex
+	scripts := make(map[string]coverageSource)
+	styleSheets := make(map[string]coverageSource)
+	session.On("Debugger.scriptParsed", func(params map[string]interface{}) {
+		id, _ := params["scriptId"].(string)
+		url, _ := params["url"].(string)
+		length, _ := params["length"].(float64)
+		mu.Lock()
+		defer mu.Unlock()
+		scripts[id] = coverageSource{url: url, length: int(length)}
+	})
+	session.On("CSS.styleSheetAdded", func(params map[string]interface{}) {
+		header, ok := params["header"].(map[string]interface{})
+		if !ok {
+			return
+		}
+		id, _ := header["styleSheetId"].(string)
+		url, _ := header["sourceURL"].(string)
+		length, _ := header["length"].(float64)
+		mu.Lock()
+		defer mu.Unlock()
+		styleSheets[id] = coverageSource{url: url, length: int(length)}
+	})
+
+	for _, step := range []struct {
+		method string
+		params map[string]interface{}
+	}{
+		{"Profiler.enable", nil},
+		{"Profiler.startPreciseCoverage", map[string]interface{}{"callCount": false, "detailed": true}},
+		{"Debugger.enable", nil},
+		{"Debugger.setSkipAllPauses", map[string]interface{}{"skip": true}},
+		{"DOM.enable", nil},
+		{"CSS.enable", nil},
+		{"CSS.startRuleUsageTracking", nil},
+	} {
+		if _, err := session.Send(step.method, step.params); err != nil {
+			return nil, fmt.Errorf("failed to start coverage (%s): %w", step.method, err)
+		}
+	}
+	pi.logger.Debug("Coverage started")
+
+	if err := run(); err != nil {
+		return nil, err
+	}
+
+	jsResult, err := session.Send("Profiler.takePreciseCoverage", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JavaScript coverage: %w", withContextErr(ctx, err))
+	}
+	var jsCoverage struct {
+		Result []struct {
+			ScriptID  string `json:"scriptId"`
+			Functions []struct {
+				Ranges []coverageRange `json:"ranges"`
+			} `json:"functions"`
+		} `json:"result"`
+	}
+	if err := decodeScriptResult(jsResult, &jsCoverage); err != nil {
+		return nil, fmt.Errorf("failed to decode JavaScript coverage: %w", err)
+	}
+	cssResult, err := session.Send("CSS.stopRuleUsageTracking", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSS coverage: %w", withContextErr(ctx, err))
+	}
+	var cssCoverage struct {
+		RuleUsage []struct {
+			StyleSheetID string `json:"styleSheetId"`
+			Start        int    `json:"startOffset"`
+			End          int    `json:"endOffset"`
+			Used         bool   `json:"used"`
+		} `json:"ruleUsage"`
+	}
+	if err := decodeScriptResult(cssResult, &cssCoverage); err != nil {
+		return nil, fmt.Errorf("failed to decode CSS coverage: %w", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	entries := newCoverageEntries()
+	for _, script := range jsCoverage.Result {
+		source, ok := scripts[script.ScriptID]
+		if !ok || source.url == "" {
+			continue
+		}
+		var ranges []coverageRange
+		for _, function := range script.Functions {
+			ranges = append(ranges, function.Ranges...)
+		}
+		entries.add(CoverageTypeJS, source, usedLength(ranges))
+	}
+	cssRanges := make(map[string][]coverageRange)
+	for _, rule := range cssCoverage.RuleUsage {
+		if rule.Used {
+			cssRanges[rule.StyleSheetID] = append(cssRanges[rule.StyleSheetID], coverageRange{Start: rule.Start, End: rule.End, Count: 1})
+		}
+	}
+	for id, source := range styleSheets {
+		if source.url == "" {
+			continue
+		}
+		entries.add(CoverageTypeCSS, source, usedLength(cssRanges[id]))
+	}
+
+	result := entries.sorted()
+	pi.logger.Debug("Coverage collected", "entries", len(result))
+	return result, nil
+}
+
+// coverageEntries sums the coverage of sources sharing a URL and type.
+type coverageEntries struct {
+	byKey map[[2]string]*CoverageEntry
+}
+
+func newCoverageEntries() *coverageEntries {
+	return &coverageEntries{byKey: make(map[[2]string]*CoverageEntry)}
+}
+
+func (c *coverageEntries) add(kind string, source coverageSource, used int) {
+	key := [2]string{kind, source.url}
+	entry, ok := c.byKey[key]
+	if !ok {
+		entry = &CoverageEntry{URL: source.url, Type: kind}
+		c.byKey[key] = entry
+	}
+	entry.TotalBytes += source.length
+	entry.UsedBytes += min(used, source.length)
+}
+
+// sorted returns the entries by UsedPercent ascending, then by unused bytes descending.
+func (c *coverageEntries) sorted() []CoverageEntry {
+	result := make([]CoverageEntry, 0, len(c.byKey))
+	for _, entry := range c.byKey {
+		if entry.TotalBytes > 0 {
+			entry.UsedPercent = math.Round(float64(entry.UsedBytes)/float64(entry.TotalBytes)*1000) / 10
+		}
+		result = append(result, *entry)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.UsedPercent != b.UsedPercent {
+			return a.UsedPercent < b.UsedPercent
+		}
+		if unusedA, unusedB := a.TotalBytes-a.UsedBytes, b.TotalBytes-b.UsedBytes; unusedA != unusedB {
+			return unusedA > unusedB
+		}
+		if a.URL != b.URL {
+			return a.URL < b.URL
+		}
+		return a.Type < b.Type
+	})
+	return result
+}
+
+// usedLength returns the number of offsets covered by ranges with a positive count. Ranges may
+// nest, as V8 reports a function's blocks inside the function; the innermost range that
+// contains an offset decides its count.
+func usedLength(ranges []coverageRange) int {
+	type point struct {
+		offset int
+		end    bool
+		r      coverageRange
+	}
+	points := make([]point, 0, 2*len(ranges))
+	for _, r := range ranges {
+		if r.End > r.Start {
+			points = append(points, point{r.Start, false, r}, point{r.End, true, r})
+		}
+	}
+	sort.SliceStable(points, func(i, j int) bool {
+		a, b := points[i], points[j]
+		if a.offset != b.offset {
+			return a.offset < b.offset
+		}
+		if a.end != b.end {
+			return a.end // Close ranges before opening the next
+		}
+		la, lb := a.r.End-a.r.Start, b.r.End-b.r.Start
+		if a.end {
+			return la < lb // Inner ranges close first
+		}
+		return la > lb // Outer ranges open first
+	})
+
+	used := 0
+	var stack []int
+	last := 0
+	for _, p := range points {
+		if len(stack) > 0 && stack[len(stack)-1] > 0 && p.offset > last {
+			used += p.offset - last
+		}
+		last = p.offset
+		if p.end {
+			stack = stack[:len(stack)-1]
+		} else {
+			stack = append(stack, p.r.Count)
+		}
+	}
+	return used
+}
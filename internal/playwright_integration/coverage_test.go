@@ -0,0 +1,38 @@
+package playwright_integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsedLength(t *testing.T) {
+	assert.Equal(t, 0, usedLength(nil))
+	// A script of 100 characters with an unexecuted function at 20-60 holding an executed
+	// block at 30-40.
+	assert.Equal(t, 70, usedLength([]coverageRange{
+		{Start: 0, End: 100, Count: 1},
+		{Start: 20, End: 60, Count: 0},
+		{Start: 30, End: 40, Count: 2},
+	}))
+	// Overlapping and adjacent used CSS rules are counted once.
+	assert.Equal(t, 30, usedLength([]coverageRange{
+		{Start: 0, End: 10, Count: 1},
+		{Start: 10, End: 20, Count: 1},
+		{Start: 15, End: 30, Count: 1},
+	}))
+}
+
+func TestCoverageEntriesSorted(t *testing.T) {
+	entries := newCoverageEntries()
+	entries.add(CoverageTypeJS, coverageSource{url: "https://example.com/", length: 100}, 100)
+	entries.add(CoverageTypeJS, coverageSource{url: "https://example.com/", length: 100}, 0)
+	entries.add(CoverageTypeCSS, coverageSource{url: "https://example.com/site.css", length: 1000}, 100)
+	entries.add(CoverageTypeJS, coverageSource{url: "https://example.com/app.js", length: 300}, 30)
+
+	assert.Equal(t, []CoverageEntry{
+		{URL: "https://example.com/site.css", TotalBytes: 1000, UsedBytes: 100, UsedPercent: 10, Type: CoverageTypeCSS},
+		{URL: "https://example.com/app.js", TotalBytes: 300, UsedBytes: 30, UsedPercent: 10, Type: CoverageTypeJS},
+		{URL: "https://example.com/", TotalBytes: 200, UsedBytes: 100, UsedPercent: 50, Type: CoverageTypeJS},
+	}, entries.sorted())
+}
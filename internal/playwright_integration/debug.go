@@ -0,0 +1,74 @@
+package playwright_integration
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// debugScreenshotTimeout bounds the capture of a debug screenshot, so a hung page does not
+// delay the error it documents.
+const debugScreenshotTimeout = 5 * time.Second
+
+// DebugScreenshotError annotates a failed navigation or action with a screenshot of the page
+// at the time of the failure, see SetDebugScreenshotDir.
+type DebugScreenshotError struct {
+	Path string // PNG file showing the failure state
+	Err  error
+}
+
+func (e *DebugScreenshotError) Error() string {
+	return fmt.Sprintf("%v (debug screenshot: %s)", e.Err, e.Path)
+}
+
+// Unwrap returns the underlying error.
+func (e *DebugScreenshotError) Unwrap() error {
+	return e.Err
+}
+
+// SetDebugScreenshotDir makes failed navigations, clicks and action steps save a screenshot of
+// the page to dir and return a *DebugScreenshotError naming the file. Empty disables it.
+func (pi *PlaywrightIntegration) SetDebugScreenshotDir(dir string) {
+	pi.debugScreenshotDir = dir
+}
+
+// debugScreenshot saves a screenshot of page if debug screenshots are enabled and returns err
+// wrapped in a *DebugScreenshotError. Errors that already carry a screenshot are returned
+// unchanged. If the page cannot be captured, e.g. because it was closed when its context was
+// cancelled, the failure is logged and err is returned unchanged.
+func (pi *PlaywrightIntegration) debugScreenshot(page playwright.Page, err error) error {
+	var existing *DebugScreenshotError
+	if err == nil || pi.debugScreenshotDir == "" || page == nil || page.IsClosed() || errors.As(err, &existing) {
+		return err
+	}
+
+	data, shotErr := page.Screenshot(playwright.PageScreenshotOptions{
+		Timeout: playwright.Float(float64(debugScreenshotTimeout.Milliseconds())),
+	})
+	if shotErr != nil {
+		pi.logger.Warn("Failed to capture debug screenshot", "error", shotErr)
+		return err
+	}
+	if mkErr := os.MkdirAll(pi.debugScreenshotDir, 0o755); mkErr != nil {
+		pi.logger.Warn("Failed to create debug screenshot directory", "dir", pi.debugScreenshotDir, "error", mkErr)
+		return err
+	}
+	file, fileErr := os.CreateTemp(pi.debugScreenshotDir, "error-"+time.Now().UTC().Format("20060102-150405")+"-*.png")
+	if fileErr != nil {
+		pi.logger.Warn("Failed to create debug screenshot", "error", fileErr)
+		return err
+	}
+	_, writeErr := file.Write(data)
+	if closeErr := file.Close(); writeErr == nil {
+		writeErr = closeErr
+	}
+	if writeErr != nil {
+		pi.logger.Warn("Failed to write debug screenshot", "path", file.Name(), "error", writeErr)
+		return err
+	}
+	pi.logger.Info("Saved debug screenshot", "path", file.Name(), "url", page.URL(), "error", err)
+	return &DebugScreenshotError{Path: file.Name(), Err: err}
+}
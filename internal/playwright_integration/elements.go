@@ -0,0 +1,165 @@
+package playwright_integration
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// DefaultMaxElements is the number of elements returned by QueryElements when no limit is given.
+const DefaultMaxElements = 100
+
+// ElementContent holds the content of a single element matched by QueryElements.
+type ElementContent struct {
+	InnerHTML  string            `json:"inner_html"`
+	InnerText  string            `json:"inner_text"`
+	Attributes map[string]string `json:"attributes"`
+}
+
+// elementAttributesScript returns all attributes of an element as a name-value object.
+const elementAttributesScript = `el => Object.fromEntries(Array.from(el.attributes, a => [a.name, a.value]))`
+
+// QueryElements returns the content of the elements matching a Playwright selector, such as a
+// CSS selector or "xpath=//h1", inside the iframe matching frame or, if frame is empty, in the
+// main frame. At most maxResults elements are returned (DefaultMaxElements if maxResults is not
+// positive); the total number of matches is returned as well.
+func (pi *PlaywrightIntegration) QueryElements(ctx context.Context, page playwright.Page, frame, selector string, maxResults int) ([]ElementContent, int, error) {
+	if page == nil {
+		return nil, 0, fmt.Errorf("playwright.Page cannot be nil")
+	}
+	if maxResults <= 0 {
+		maxResults = DefaultMaxElements
+	}
+
+	root, err := pi.locate(page, frame, selector)
+	if err != nil {
+		return nil, 0, err
+	}
+	locators, err := root.All()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query selector %q: %w", selector, err)
+	}
+	total := len(locators)
+	if total > maxResults {
+		locators = locators[:maxResults]
+	}
+
+	elements := make([]ElementContent, 0, len(locators))
+	for i, locator := range locators {
+		if err := ctx.Err(); err != nil {
+			return nil, 0, err
+		}
+		innerHTML, err := locator.InnerHTML()
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to read inner HTML of element %d: %w", i, err)
+		}
+		innerText, err := locator.InnerText()
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to read inner text of element %d: %w", i, err)
+		}
+		result, err := locator.Evaluate(elementAttributesScript, nil)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to read attributes of element %d: %w", i, err)
+		}
+		attributes := map[string]string{}
+		if err := decodeScriptResult(result, &attributes); err != nil {
+			return nil, 0, fmt.Errorf("failed to decode attributes of element %d: %w", i, err)
+		}
+		elements = append(elements, ElementContent{
+			InnerHTML:  innerHTML,
+			InnerText:  innerText,
+			Attributes: attributes,
+		})
+	}
+
+	pi.logger.Debug("Queried elements", "selector", selector, "total", total, "returned", len(elements))
+	return elements, total, nil
+}
+
+// queryAllScript returns the trimmed text content, or the named attribute, of every element.
+// Elements without the attribute yield null.
+const queryAllScript = `(elements, attribute) => elements.map(el =>
+	attribute ? el.getAttribute(attribute) : (el.textContent || '').trim())`
+
+// QueryAll returns the text content of every element matching a Playwright selector, or the
+// value of the named attribute if attribute is not empty. Unlike QueryElements it reads all
+// matches in a single round trip, which suits scraping lists and tables. Elements lacking the
+// attribute are returned as nil. A non-empty frame scopes the query to that iframe.
+func (pi *PlaywrightIntegration) QueryAll(ctx context.Context, page playwright.Page, frame, selector string, attribute string) ([]*string, error) {
+	if page == nil {
+		return nil, fmt.Errorf("playwright.Page cannot be nil")
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	locator, err := pi.locate(page, frame, selector)
+	if err != nil {
+		return nil, err
+	}
+	result, err := locator.EvaluateAll(queryAllScript, attribute)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query selector %q: %w", selector, err)
+	}
+	var values []*string
+	if err := decodeScriptResult(result, &values); err != nil {
+		return nil, fmt.Errorf("failed to decode values of %q: %w", selector, err)
+	}
+	if values == nil {
+		values = []*string{}
+	}
+
+	pi.logger.Debug("Extracted elements", "selector", selector, "attribute", attribute, "count", len(values))
+	return values, nil
+}
+
+// outerHTMLScript joins the outer HTML of all matched elements, one per line.
+const outerHTMLScript = `elements => elements.map(el => el.outerHTML).join('\n')`
+
+// GetOuterHTML returns the outer HTML of every element matching a Playwright selector, one
+// element per line. It returns an error if nothing matches.
+func (pi *PlaywrightIntegration) GetOuterHTML(ctx context.Context, page playwright.Page, selector string) (string, error) {
+	if page == nil {
+		return "", fmt.Errorf("playwright.Page cannot be nil")
+	}
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	locator := page.Locator(selector)
+	count, err := locator.Count()
+	if err != nil {
+		return "", fmt.Errorf("failed to query selector %q: %w", selector, err)
+	}
+	if count == 0 {
+		return "", fmt.Errorf("no elements match selector %q", selector)
+	}
+	result, err := locator.EvaluateAll(outerHTMLScript)
+	if err != nil {
+		return "", fmt.Errorf("failed to read outer HTML of %q: %w", selector, err)
+	}
+	outerHTML, _ := result.(string)
+	return outerHTML, nil
+}
+
+// CountElements returns the number of elements matching a Playwright selector without
+// reading their content. A non-empty frame scopes the count to that iframe.
+func (pi *PlaywrightIntegration) CountElements(ctx context.Context, page playwright.Page, frame, selector string) (int, error) {
+	if page == nil {
+		return 0, fmt.Errorf("playwright.Page cannot be nil")
+	}
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	locator, err := pi.locate(page, frame, selector)
+	if err != nil {
+		return 0, err
+	}
+	count, err := locator.Count()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count elements matching %q: %w", selector, err)
+	}
+	pi.logger.Debug("Counted elements", "selector", selector, "count", count)
+	return count, nil
+}
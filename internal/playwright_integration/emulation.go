@@ -0,0 +1,50 @@
+package playwright_integration
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // Validate time zone names even on hosts without a zoneinfo database
+)
+
+// Geolocation is a position reported to pages through the Geolocation API.
+type Geolocation struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// ParseGeolocation parses a "latitude,longitude" pair such as "52.52,13.405".
+func ParseGeolocation(spec string) (*Geolocation, error) {
+	lat, lng, ok := strings.Cut(spec, ",")
+	if !ok {
+		return nil, fmt.Errorf("expected \"latitude,longitude\", got %q", spec)
+	}
+	latitude, err := strconv.ParseFloat(strings.TrimSpace(lat), 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid latitude %q: %w", lat, err)
+	}
+	longitude, err := strconv.ParseFloat(strings.TrimSpace(lng), 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid longitude %q: %w", lng, err)
+	}
+	if latitude < -90 || latitude > 90 {
+		return nil, fmt.Errorf("latitude must be between -90 and 90, got %g", latitude)
+	}
+	if longitude < -180 || longitude > 180 {
+		return nil, fmt.Errorf("longitude must be between -180 and 180, got %g", longitude)
+	}
+	return &Geolocation{Latitude: latitude, Longitude: longitude}, nil
+}
+
+// ValidateTimezone returns an error if name is not an IANA time zone name.
+func ValidateTimezone(name string) error {
+	// LoadLocation also accepts "" and "Local", which browsers do not understand.
+	if name == "" || name == "Local" {
+		return fmt.Errorf("expected an IANA time zone name such as \"Europe/Berlin\" or \"America/New_York\", got %q", name)
+	}
+	if _, err := time.LoadLocation(name); err != nil {
+		return fmt.Errorf("expected an IANA time zone name such as \"Europe/Berlin\" or \"America/New_York\", got %q", name)
+	}
+	return nil
+}
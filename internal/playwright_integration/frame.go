@@ -0,0 +1,41 @@
+package playwright_integration
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// ErrFrameNotFound is returned when no iframe on the page matches the requested frame selector.
+var ErrFrameNotFound = errors.New("frame not found")
+
+// FrameLocator returns a locator scoped to the content of the first iframe matching
+// iframeSelector, e.g. "iframe#checkout" or "iframe[title=Payment]". It returns
+// ErrFrameNotFound if the page has no matching iframe.
+func (pi *PlaywrightIntegration) FrameLocator(page playwright.Page, iframeSelector string) (playwright.FrameLocator, error) {
+	if page == nil {
+		return nil, fmt.Errorf("playwright.Page cannot be nil")
+	}
+	count, err := page.Locator(iframeSelector).Count()
+	if err != nil {
+		return nil, fmt.Errorf("failed to query frame selector %q: %w", iframeSelector, err)
+	}
+	if count == 0 {
+		return nil, fmt.Errorf("%w: no iframe matches %q", ErrFrameNotFound, iframeSelector)
+	}
+	return page.FrameLocator(iframeSelector).First(), nil
+}
+
+// locate returns a locator for selector in the iframe matching frame, or in the main frame if
+// frame is empty.
+func (pi *PlaywrightIntegration) locate(page playwright.Page, frame, selector string) (playwright.Locator, error) {
+	if frame == "" {
+		return page.Locator(selector), nil
+	}
+	frameLocator, err := pi.FrameLocator(page, frame)
+	if err != nil {
+		return nil, err
+	}
+	return frameLocator.Locator(selector), nil
+}
@@ -0,0 +1,86 @@
+package playwright_integration
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// compileGlob converts a Playwright-style URL glob into a regular expression.
+// A single "*" matches any run of characters except "/", "**" matches across path
+// segments (as in the "**/*" route used for interception), "?" matches one character,
+// and "{a,b}" matches either alternative.
+func compileGlob(pattern string) (*regexp.Regexp, error) {
+	var sb strings.Builder
+	sb.WriteString("^")
+	inGroup := false
+
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch c {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				sb.WriteString(".*")
+				i++
+			} else {
+				sb.WriteString("[^/]*")
+			}
+		case '?':
+			sb.WriteString(".")
+		case '{':
+			inGroup = true
+			sb.WriteString("(?:")
+		case '}':
+			inGroup = false
+			sb.WriteString(")")
+		case ',':
+			if inGroup {
+				sb.WriteString("|")
+			} else {
+				sb.WriteString(",")
+			}
+		case '\\':
+			if i+1 < len(pattern) {
+				i++
+				sb.WriteString(regexp.QuoteMeta(string(pattern[i])))
+			} else {
+				sb.WriteString(`\\`)
+			}
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	sb.WriteString("$")
+
+	re, err := regexp.Compile(sb.String())
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL pattern %q: %w", pattern, err)
+	}
+	return re, nil
+}
+
+// urlMatcher converts a URL pattern into a value accepted by Playwright's URL matching
+// APIs. Patterns wrapped in slashes ("/api/v[0-9]+/users/") are regular expressions;
+// anything else is a Playwright glob.
+func urlMatcher(pattern string) (interface{}, error) {
+	if len(pattern) >= 2 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+		return compileURLPattern(pattern[1:len(pattern)-1], true)
+	}
+	return compileURLPattern(pattern, false)
+}
+
+// compileURLPattern converts a glob, or a regular expression if regex is set, into a value
+// accepted by Playwright's URL matching APIs.
+func compileURLPattern(pattern string, regex bool) (interface{}, error) {
+	if regex {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid URL regex %q: %w", pattern, err)
+		}
+		return re, nil
+	}
+	if _, err := compileGlob(pattern); err != nil {
+		return nil, err
+	}
+	return pattern, nil
+}
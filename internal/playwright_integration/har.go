@@ -0,0 +1,224 @@
+package playwright_integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// harVersion is the HAR specification version produced by ToHAR.
+const harVersion = "1.2"
+
+// harCreator identifies this server in generated HAR files.
+var harCreator = harNameVersion{Name: "mcp-browser-tools", Version: "1.0.0"}
+
+type harDocument struct {
+	Log harLog `json:"log"`
+}
+
+type harLog struct {
+	Version string         `json:"version"`
+	Creator harNameVersion `json:"creator"`
+	Pages   []harPage      `json:"pages"`
+	Entries []harEntry     `json:"entries"`
+}
+
+type harNameVersion struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harPage struct {
+	StartedDateTime string         `json:"startedDateTime"`
+	ID              string         `json:"id"`
+	Title           string         `json:"title"`
+	PageTimings     harPageTimings `json:"pageTimings"`
+}
+
+type harPageTimings struct {
+	OnContentLoad float64 `json:"onContentLoad"`
+	OnLoad        float64 `json:"onLoad"`
+}
+
+type harEntry struct {
+	Pageref         string      `json:"pageref"`
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+	Comment         string      `json:"comment,omitempty"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+type harTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+// ToHAR converts captured network activity into a HAR 1.2 document for a single page.
+// Entries captured without a start time start at capturedAt. The capture has no breakdown of
+// request timings, so the whole duration is reported as waiting; unknown sizes are -1, as the
+// specification allows.
+func ToHAR(entries []CapturedNetworkActivity, pageURL string, capturedAt time.Time) ([]byte, error) {
+	started := capturedAt.UTC().Format(time.RFC3339Nano)
+	const pageID = "page_1"
+
+	doc := harDocument{Log: harLog{
+		Version: harVersion,
+		Creator: harCreator,
+		Pages: []harPage{{
+			StartedDateTime: started,
+			ID:              pageID,
+			Title:           pageURL,
+			PageTimings:     harPageTimings{OnContentLoad: -1, OnLoad: -1},
+		}},
+		Entries: make([]harEntry, 0, len(entries)),
+	}}
+
+	for _, activity := range entries {
+		entry := harEntry{
+			Pageref:         pageID,
+			StartedDateTime: started,
+			Request: harRequest{
+				Method:      activity.Request.Method,
+				URL:         activity.Request.URL,
+				HTTPVersion: "HTTP/1.1",
+				Cookies:     []harNameValue{},
+				Headers:     harHeaders(activity.Request.Headers),
+				QueryString: harQueryString(activity.Request.URL),
+				HeadersSize: -1,
+				BodySize:    len(activity.Request.Body),
+			},
+			Response: harResponse{
+				Status:      activity.Response.Status,
+				StatusText:  http.StatusText(activity.Response.Status),
+				HTTPVersion: "HTTP/1.1",
+				Cookies:     []harNameValue{},
+				Headers:     harHeaders(activity.Response.Headers),
+				Content: harContent{
+					Size:     harBodySize(activity.Response),
+					MimeType: headerValue(activity.Response.Headers, "Content-Type"),
+					Text:     activity.Response.Body,
+					Encoding: activity.Response.Encoding,
+				},
+				RedirectURL: headerValue(activity.Response.Headers, "Location"),
+				HeadersSize: -1,
+				BodySize:    -1,
+			},
+			Time:    activity.Response.DurationMs,
+			Timings: harTimings{Wait: activity.Response.DurationMs},
+		}
+		if !activity.Request.StartedAt.IsZero() {
+			entry.StartedDateTime = activity.Request.StartedAt.UTC().Format(time.RFC3339Nano)
+		}
+		if activity.Request.Body != "" {
+			entry.Request.PostData = &harPostData{
+				MimeType: headerValue(activity.Request.Headers, "Content-Type"),
+				Text:     activity.Request.Body,
+			}
+		}
+		if activity.Mocked {
+			entry.Comment = "served from a mock endpoint"
+		}
+		if activity.Failed {
+			// HAR has no field for failures; browsers export them with status 0 and the error text.
+			entry.Comment = "request failed: " + activity.Failure
+		}
+		doc.Log.Entries = append(doc.Log.Entries, entry)
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode HAR: %w", err)
+	}
+	return data, nil
+}
+
+// harBodySize returns the size of the full response body, which the captured text only
+// matches if it was neither truncated nor replaced by a placeholder.
+func harBodySize(response CapturedResponse) int {
+	if response.BodySize > 0 {
+		return response.BodySize
+	}
+	return len(response.Body)
+}
+
+// harHeaders converts a header map into HAR name-value pairs, sorted by name for stable output.
+func harHeaders(headers map[string]string) []harNameValue {
+	pairs := make([]harNameValue, 0, len(headers))
+	for name, value := range headers {
+		pairs = append(pairs, harNameValue{Name: name, Value: value})
+	}
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].Name < pairs[j].Name })
+	return pairs
+}
+
+// harQueryString returns the query parameters of rawURL as HAR name-value pairs.
+func harQueryString(rawURL string) []harNameValue {
+	pairs := []harNameValue{}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return pairs
+	}
+	for _, param := range strings.Split(u.RawQuery, "&") {
+		if param == "" {
+			continue
+		}
+		name, value, _ := strings.Cut(param, "=")
+		if n, err := url.QueryUnescape(name); err == nil {
+			name = n
+		}
+		if v, err := url.QueryUnescape(value); err == nil {
+			value = v
+		}
+		pairs = append(pairs, harNameValue{Name: name, Value: value})
+	}
+	return pairs
+}
@@ -0,0 +1,103 @@
+package playwright_integration
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToHAR(t *testing.T) {
+	capturedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	data, err := ToHAR([]CapturedNetworkActivity{{
+		Request: CapturedRequest{
+			URL:     "https://example.com/api?q=go+lang&page=2",
+			Method:  "POST",
+			Headers: map[string]string{"content-type": "application/json"},
+			Body:    `{"a":1}`,
+		},
+		Response: CapturedResponse{
+			Status:  200,
+			Headers: map[string]string{"content-type": "application/json"},
+			Body:    `{"ok":true}`,
+		},
+	}}, "https://example.com", capturedAt)
+	assert.NoError(t, err)
+
+	var har struct {
+		Log struct {
+			Version string `json:"version"`
+			Creator struct {
+				Name string `json:"name"`
+			} `json:"creator"`
+			Pages []struct {
+				ID              string `json:"id"`
+				StartedDateTime string `json:"startedDateTime"`
+			} `json:"pages"`
+			Entries []struct {
+				Pageref string `json:"pageref"`
+				Request struct {
+					Method      string            `json:"method"`
+					QueryString []harNameValue    `json:"queryString"`
+					PostData    map[string]string `json:"postData"`
+				} `json:"request"`
+				Response struct {
+					Status     int    `json:"status"`
+					StatusText string `json:"statusText"`
+					Content    struct {
+						MimeType string `json:"mimeType"`
+						Text     string `json:"text"`
+					} `json:"content"`
+				} `json:"response"`
+			} `json:"entries"`
+		} `json:"log"`
+	}
+	assert.NoError(t, json.Unmarshal(data, &har))
+
+	assert.Equal(t, "1.2", har.Log.Version)
+	assert.Equal(t, "mcp-browser-tools", har.Log.Creator.Name)
+	assert.Len(t, har.Log.Pages, 1)
+	assert.Equal(t, "2024-05-01T12:00:00Z", har.Log.Pages[0].StartedDateTime)
+	if assert.Len(t, har.Log.Entries, 1) {
+		entry := har.Log.Entries[0]
+		assert.Equal(t, har.Log.Pages[0].ID, entry.Pageref)
+		assert.Equal(t, "POST", entry.Request.Method)
+		assert.Equal(t, []harNameValue{{Name: "q", Value: "go lang"}, {Name: "page", Value: "2"}}, entry.Request.QueryString)
+		assert.Equal(t, `{"a":1}`, entry.Request.PostData["text"])
+		assert.Equal(t, "OK", entry.Response.StatusText)
+		assert.Equal(t, "application/json", entry.Response.Content.MimeType)
+		assert.Equal(t, `{"ok":true}`, entry.Response.Content.Text)
+	}
+}
+
+func TestToHARUsesCapturedTimings(t *testing.T) {
+	capturedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	data, err := ToHAR([]CapturedNetworkActivity{
+		{
+			Request:  CapturedRequest{URL: "https://example.com/", Method: "GET", StartedAt: capturedAt.Add(-2 * time.Second)},
+			Response: CapturedResponse{Status: 200, DurationMs: 42.5},
+		},
+		{Request: CapturedRequest{URL: "https://example.com/old", Method: "GET"}},
+	}, "https://example.com", capturedAt)
+	assert.NoError(t, err)
+
+	var har struct {
+		Log struct {
+			Entries []struct {
+				StartedDateTime string  `json:"startedDateTime"`
+				Time            float64 `json:"time"`
+				Timings         struct {
+					Wait float64 `json:"wait"`
+				} `json:"timings"`
+			} `json:"entries"`
+		} `json:"log"`
+	}
+	assert.NoError(t, json.Unmarshal(data, &har))
+	if assert.Len(t, har.Log.Entries, 2) {
+		assert.Equal(t, "2024-05-01T11:59:58Z", har.Log.Entries[0].StartedDateTime)
+		assert.Equal(t, 42.5, har.Log.Entries[0].Time)
+		assert.Equal(t, 42.5, har.Log.Entries[0].Timings.Wait)
+		assert.Equal(t, "2024-05-01T12:00:00Z", har.Log.Entries[1].StartedDateTime, "entries without a start time use capturedAt")
+	}
+}
@@ -0,0 +1,83 @@
+package playwright_integration
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// HeaderInjectionRule adds headers to outgoing requests whose URL matches URLPattern,
+// e.g. an Authorization header for "**/api/**" only, leaving static assets untouched.
+type HeaderInjectionRule struct {
+	URLPattern string            `json:"url_pattern"` // Playwright-style glob
+	Headers    map[string]string `json:"headers"`
+}
+
+// compiledHeaderRule pairs a HeaderInjectionRule with its compiled URL pattern.
+type compiledHeaderRule struct {
+	rule    HeaderInjectionRule
+	pattern *regexp.Regexp
+}
+
+// SetHeaderInjectionRules replaces the header injection rules applied on every page routed
+// through SetupNetworkInterception. The per-call InterceptionOptions.HeaderRules are applied
+// after them. Passing nil removes all rules.
+func (pi *PlaywrightIntegration) SetHeaderInjectionRules(rules []HeaderInjectionRule) error {
+	compiled, err := compileHeaderRules(rules)
+	if err != nil {
+		return err
+	}
+	pi.headerRules = compiled
+	return nil
+}
+
+// HasHeaderInjectionRules reports whether any rules are set with SetHeaderInjectionRules, in
+// which case requests must be routed through SetupNetworkInterception for them to apply.
+func (pi *PlaywrightIntegration) HasHeaderInjectionRules() bool {
+	return len(pi.headerRules) > 0
+}
+
+// ValidateHeaderInjectionRules checks that every rule has a valid URL pattern and headers.
+func ValidateHeaderInjectionRules(rules []HeaderInjectionRule) error {
+	_, err := compileHeaderRules(rules)
+	return err
+}
+
+// compileHeaderRules compiles the URL patterns of rules once, rather than per request.
+func compileHeaderRules(rules []HeaderInjectionRule) ([]compiledHeaderRule, error) {
+	compiled := make([]compiledHeaderRule, 0, len(rules))
+	for i, rule := range rules {
+		if rule.URLPattern == "" {
+			return nil, fmt.Errorf("header injection rule %d is missing url_pattern", i)
+		}
+		if err := ValidateExtraHeaders(rule.Headers); err != nil {
+			return nil, fmt.Errorf("header injection rule %d: %w", i, err)
+		}
+		pattern, err := compileGlob(rule.URLPattern)
+		if err != nil {
+			return nil, fmt.Errorf("header injection rule %d: %w", i, err)
+		}
+		compiled = append(compiled, compiledHeaderRule{rule: rule, pattern: pattern})
+	}
+	return compiled, nil
+}
+
+// injectHeaders returns headers merged with those of every rule matching url, or nil if no
+// rule matches. Later rules override earlier ones.
+func injectHeaders(rules []compiledHeaderRule, url string, headers map[string]string) map[string]string {
+	var merged map[string]string
+	for _, r := range rules {
+		if !r.pattern.MatchString(url) {
+			continue
+		}
+		if merged == nil {
+			merged = make(map[string]string, len(headers)+len(r.rule.Headers))
+			for name, value := range headers {
+				merged[name] = value
+			}
+		}
+		for name, value := range r.rule.Headers {
+			merged[name] = value
+		}
+	}
+	return merged
+}
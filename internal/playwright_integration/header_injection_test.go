@@ -0,0 +1,42 @@
+package playwright_integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInjectHeaders(t *testing.T) {
+	rules, err := compileHeaderRules([]HeaderInjectionRule{
+		{URLPattern: "**/api/**", Headers: map[string]string{"Authorization": "Bearer token"}},
+	})
+	assert.NoError(t, err)
+
+	original := map[string]string{"accept": "*/*"}
+	injected := injectHeaders(rules, "https://example.com/api/items", original)
+	assert.Equal(t, map[string]string{"accept": "*/*", "Authorization": "Bearer token"}, injected)
+	assert.Len(t, original, 1, "the request's own headers must not be modified")
+
+	assert.Nil(t, injectHeaders(rules, "https://example.com/static/app.js", original))
+	assert.Nil(t, injectHeaders(nil, "https://example.com/api/items", original))
+}
+
+func TestValidateHeaderInjectionRules(t *testing.T) {
+	assert.NoError(t, ValidateHeaderInjectionRules(nil))
+	assert.NoError(t, ValidateHeaderInjectionRules([]HeaderInjectionRule{{URLPattern: "**/*", Headers: map[string]string{"X-A": "b"}}}))
+	assert.ErrorContains(t, ValidateHeaderInjectionRules([]HeaderInjectionRule{{Headers: map[string]string{"X-A": "b"}}}), "missing url_pattern")
+}
+
+func TestSetHeaderInjectionRules(t *testing.T) {
+	pi := &PlaywrightIntegration{}
+	assert.False(t, pi.HasHeaderInjectionRules())
+
+	assert.NoError(t, pi.SetHeaderInjectionRules([]HeaderInjectionRule{{URLPattern: "**/api/**", Headers: map[string]string{"X-A": "b"}}}))
+	assert.True(t, pi.HasHeaderInjectionRules())
+
+	assert.Error(t, pi.SetHeaderInjectionRules([]HeaderInjectionRule{{Headers: map[string]string{"X-A": "b"}}}))
+	assert.True(t, pi.HasHeaderInjectionRules(), "invalid rules leave the previous ones in place")
+
+	assert.NoError(t, pi.SetHeaderInjectionRules(nil))
+	assert.False(t, pi.HasHeaderInjectionRules())
+}
@@ -0,0 +1,34 @@
+package playwright_integration
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// forbiddenHeaders are request headers the browser controls itself. Chromium silently drops
+// or overrides them, so setting them as extra headers would never have the intended effect.
+var forbiddenHeaders = map[string]bool{
+	"Host":              true,
+	"Content-Length":    true,
+	"Connection":        true,
+	"Keep-Alive":        true,
+	"Transfer-Encoding": true,
+	"Te":                true,
+	"Trailer":           true,
+	"Upgrade":           true,
+	"Expect":            true,
+}
+
+// ValidateExtraHeaders returns an error if any header cannot be sent as an extra HTTP header.
+func ValidateExtraHeaders(headers map[string]string) error {
+	for name := range headers {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("header name cannot be empty")
+		}
+		if forbiddenHeaders[http.CanonicalHeaderKey(name)] {
+			return fmt.Errorf("header %q is controlled by the browser and cannot be overridden", name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package playwright_integration
+
+import (
+	"fmt"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// Accepted values for the CSS media emulation options of PageOptions.
+var (
+	ColorSchemes   = []string{"light", "dark"}
+	ReducedMotions = []string{"reduce", "no-preference"}
+	MediaTypes     = []string{"screen", "print"}
+)
+
+// hasMediaEmulation reports whether any CSS media feature is emulated.
+func (o *PageOptions) hasMediaEmulation() bool {
+	return o != nil && (o.ColorScheme != "" || o.ReducedMotion != "" || o.Media != "")
+}
+
+// emulateMedia applies the CSS media emulation of opts to the page. It must run before
+// navigation so media queries evaluated during load see the emulated values.
+func emulateMedia(page playwright.Page, opts *PageOptions) error {
+	var options playwright.PageEmulateMediaOptions
+	if opts.ColorScheme != "" {
+		colorScheme := playwright.ColorScheme(opts.ColorScheme)
+		options.ColorScheme = &colorScheme
+	}
+	if opts.ReducedMotion != "" {
+		reducedMotion := playwright.ReducedMotion(opts.ReducedMotion)
+		options.ReducedMotion = &reducedMotion
+	}
+	if opts.Media != "" {
+		media := playwright.Media(opts.Media)
+		options.Media = &media
+	}
+	if err := page.EmulateMedia(options); err != nil {
+		return fmt.Errorf("failed to emulate media: %w", err)
+	}
+	return nil
+}
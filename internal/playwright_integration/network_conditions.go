@@ -0,0 +1,91 @@
+package playwright_integration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// ErrOfflineEmulation is returned when navigation fails because offline emulation is active.
+var ErrOfflineEmulation = errors.New("offline emulation active")
+
+// NetworkConditions describes emulated network characteristics for a page.
+// Throughput values are in kilobits per second; zero means unthrottled.
+type NetworkConditions struct {
+	Offline      bool    `json:"offline"`
+	LatencyMs    float64 `json:"latency_ms"`
+	DownloadKbps float64 `json:"download_kbps"`
+	UploadKbps   float64 `json:"upload_kbps"`
+}
+
+// networkConditionPresets mirrors the throttling presets offered by Chrome DevTools. Recent
+// DevTools versions call Slow 3G "3G" and Fast 3G "Slow 4G"; both names are accepted.
+var networkConditionPresets = map[string]NetworkConditions{
+	"slow3g":  {LatencyMs: 2000, DownloadKbps: 400, UploadKbps: 400},
+	"3g":      {LatencyMs: 2000, DownloadKbps: 400, UploadKbps: 400},
+	"fast3g":  {LatencyMs: 562.5, DownloadKbps: 1440, UploadKbps: 675},
+	"slow4g":  {LatencyMs: 562.5, DownloadKbps: 1440, UploadKbps: 675},
+	"offline": {Offline: true},
+}
+
+// ParseNetworkConditions parses a preset name, case-insensitively ("3g", "slow3g", "slow4g",
+// "fast3g", "offline"), or a JSON object such as
+// {"latency_ms": 300, "download_kbps": 1000, "upload_kbps": 500}.
+func ParseNetworkConditions(spec string) (*NetworkConditions, error) {
+	spec = strings.TrimSpace(spec)
+	if preset, ok := networkConditionPresets[strings.ToLower(spec)]; ok {
+		return &preset, nil
+	}
+	if !strings.HasPrefix(spec, "{") {
+		return nil, fmt.Errorf("unknown network conditions preset %q: expected 3g, slow3g, slow4g, fast3g, offline, or a JSON object", spec)
+	}
+
+	var conditions NetworkConditions
+	if err := json.Unmarshal([]byte(spec), &conditions); err != nil {
+		return nil, fmt.Errorf("invalid network conditions: %w", err)
+	}
+	if conditions.LatencyMs < 0 || conditions.DownloadKbps < 0 || conditions.UploadKbps < 0 {
+		return nil, fmt.Errorf("invalid network conditions: latency and throughput must not be negative")
+	}
+	return &conditions, nil
+}
+
+// ApplyNetworkConditions emulates the given network conditions on a page through a
+// Chrome DevTools Protocol session, so it is only supported on Chromium.
+func (pi *PlaywrightIntegration) ApplyNetworkConditions(ctx context.Context, page playwright.Page, conditions *NetworkConditions) error {
+	if page == nil {
+		return fmt.Errorf("playwright.Page cannot be nil")
+	}
+	if conditions == nil {
+		return nil
+	}
+
+	session, err := page.Context().NewCDPSession(page)
+	if err != nil {
+		return fmt.Errorf("network condition emulation requires Chromium: %w", err)
+	}
+
+	// CDP expects throughput in bytes per second, with -1 disabling throttling.
+	throughput := func(kbps float64) float64 {
+		if kbps <= 0 {
+			return -1
+		}
+		return kbps * 1024 / 8
+	}
+	if _, err := session.Send("Network.emulateNetworkConditions", map[string]interface{}{
+		"offline":            conditions.Offline,
+		"latency":            conditions.LatencyMs,
+		"downloadThroughput": throughput(conditions.DownloadKbps),
+		"uploadThroughput":   throughput(conditions.UploadKbps),
+	}); err != nil {
+		return fmt.Errorf("failed to emulate network conditions: %w", err)
+	}
+
+	pi.logger.Debug("Applied network conditions", "offline", conditions.Offline, "latency_ms", conditions.LatencyMs,
+		"download_kbps", conditions.DownloadKbps, "upload_kbps", conditions.UploadKbps)
+	return nil
+}
@@ -0,0 +1,32 @@
+package playwright_integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseNetworkConditions(t *testing.T) {
+	conditions, err := ParseNetworkConditions("3G")
+	assert.NoError(t, err)
+	assert.Equal(t, &NetworkConditions{LatencyMs: 2000, DownloadKbps: 400, UploadKbps: 400}, conditions)
+
+	slow4g, err := ParseNetworkConditions(" Slow4G ")
+	assert.NoError(t, err)
+	fast3g, err := ParseNetworkConditions("fast3g")
+	assert.NoError(t, err)
+	assert.Equal(t, fast3g, slow4g)
+
+	conditions, err = ParseNetworkConditions("offline")
+	assert.NoError(t, err)
+	assert.True(t, conditions.Offline)
+
+	conditions, err = ParseNetworkConditions(`{"latency_ms": 300, "download_kbps": 1000}`)
+	assert.NoError(t, err)
+	assert.Equal(t, &NetworkConditions{LatencyMs: 300, DownloadKbps: 1000}, conditions)
+
+	_, err = ParseNetworkConditions("5g")
+	assert.ErrorContains(t, err, "unknown network conditions preset")
+	_, err = ParseNetworkConditions(`{"latency_ms": -1}`)
+	assert.ErrorContains(t, err, "must not be negative")
+}
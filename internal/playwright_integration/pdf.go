@@ -0,0 +1,159 @@
+package playwright_integration
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/playwright-community/playwright-go"
+)
+
+// PDFFormats are the paper formats accepted by PDFOptions.
+var PDFFormats = []string{"Letter", "Legal", "Tabloid", "Ledger", "A0", "A1", "A2", "A3", "A4", "A5", "A6"}
+
+// MaxPDFBatch is the largest number of URLs RenderPDFs accepts in one call.
+const MaxPDFBatch = 20
+
+// PDFOptions configures RenderPDF.
+type PDFOptions struct {
+	Format    string // One of PDFFormats; empty uses Letter
+	Landscape bool
+}
+
+// Validate checks the paper format.
+func (o PDFOptions) Validate() error {
+	if o.Format != "" && !slices.Contains(PDFFormats, o.Format) {
+		return fmt.Errorf("unsupported PDF format %q: expected one of %s", o.Format, strings.Join(PDFFormats, ", "))
+	}
+	return nil
+}
+
+// RenderPDF prints the page to PDF with its print stylesheet and background graphics.
+// Printing to PDF is only supported by Chromium.
+func (pi *PlaywrightIntegration) RenderPDF(ctx context.Context, page playwright.Page, opts PDFOptions) ([]byte, error) {
+	if page == nil {
+		return nil, fmt.Errorf("playwright.Page cannot be nil")
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	pdfOptions := playwright.PagePdfOptions{
+		Landscape:       playwright.Bool(opts.Landscape),
+		PrintBackground: playwright.Bool(true),
+	}
+	if opts.Format != "" {
+		pdfOptions.Format = playwright.String(opts.Format)
+	}
+	pi.logger.Debug("Rendering PDF", "url", page.URL(), "format", opts.Format, "landscape", opts.Landscape)
+	data, err := page.PDF(pdfOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render PDF of %s: %w", page.URL(), err)
+	}
+	return data, nil
+}
+
+// PageOpener creates a page and loads url in it. The caller is responsible for closing the page.
+type PageOpener func(ctx context.Context, url string) (playwright.Page, error)
+
+// RenderPDFs loads each URL in its own page with open and renders it with RenderPDF, loading
+// at most concurrency pages at a time. A nil open loads the pages with NavigateToURL and
+// default options. The PDFs are returned in the order of urls; the first failure fails the
+// whole batch.
+func (pi *PlaywrightIntegration) RenderPDFs(ctx context.Context, urls []string, opts PDFOptions, open PageOpener, concurrency int) ([][]byte, error) {
+	if len(urls) == 0 {
+		return nil, fmt.Errorf("at least one URL is required")
+	}
+	if len(urls) > MaxPDFBatch {
+		return nil, fmt.Errorf("too many URLs: got %d, at most %d are allowed", len(urls), MaxPDFBatch)
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	if open == nil {
+		open = func(ctx context.Context, url string) (playwright.Page, error) {
+			return pi.NavigateToURL(ctx, url, nil)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pdfs := make([][]byte, len(urls))
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	fail := func(url string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if firstErr == nil {
+			firstErr = fmt.Errorf("failed to render %s: %w", url, err)
+			cancel()
+		}
+	}
+	sem := make(chan struct{}, concurrency)
+	for i, url := range urls {
+		sem <- struct{}{}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(i int, url string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			page, err := open(ctx, url)
+			if err != nil {
+				fail(url, err)
+				return
+			}
+			defer page.Close()
+			if pdfs[i], err = pi.RenderPDF(ctx, page, opts); err != nil {
+				fail(url, err)
+			}
+		}(i, url)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return pdfs, nil
+}
+
+// disablePDFConfigDir keeps pdfcpu from creating its configuration directory, which exits the
+// process if it fails.
+var disablePDFConfigDir sync.Once
+
+// MergePDFs concatenates pdfs, in order, into a single PDF document.
+func MergePDFs(pdfs [][]byte) ([]byte, error) {
+	if len(pdfs) == 0 {
+		return nil, fmt.Errorf("at least one PDF is required")
+	}
+	disablePDFConfigDir.Do(api.DisableConfigDir)
+
+	readers := make([]io.ReadSeeker, len(pdfs))
+	for i, pdf := range pdfs {
+		readers[i] = bytes.NewReader(pdf)
+	}
+	var merged bytes.Buffer
+	if err := api.MergeRaw(readers, &merged, false, nil); err != nil {
+		return nil, fmt.Errorf("failed to merge PDFs: %w", err)
+	}
+	return merged.Bytes(), nil
+}
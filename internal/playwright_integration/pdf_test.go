@@ -0,0 +1,80 @@
+package playwright_integration
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPDFOptionsValidate(t *testing.T) {
+	assert.NoError(t, PDFOptions{}.Validate())
+	assert.NoError(t, PDFOptions{Format: "A4", Landscape: true}.Validate())
+	assert.ErrorContains(t, PDFOptions{Format: "a4"}.Validate(), "unsupported PDF format")
+}
+
+func TestRenderPDFsRejectsInvalidBatches(t *testing.T) {
+	pi := &PlaywrightIntegration{}
+	_, err := pi.RenderPDFs(context.Background(), nil, PDFOptions{}, nil, 1)
+	assert.ErrorContains(t, err, "at least one URL")
+
+	urls := make([]string, MaxPDFBatch+1)
+	_, err = pi.RenderPDFs(context.Background(), urls, PDFOptions{}, nil, 1)
+	assert.ErrorContains(t, err, "too many URLs")
+
+	_, err = pi.RenderPDFs(context.Background(), []string{"https://example.com"}, PDFOptions{Format: "Postcard"}, nil, 1)
+	assert.ErrorContains(t, err, "unsupported PDF format")
+}
+
+// blankPDF returns a PDF document with the given number of empty pages.
+func blankPDF(pages int) []byte {
+	objects := []string{"<< /Type /Catalog /Pages 2 0 R >>"}
+	kids := make([]string, pages)
+	for i := range kids {
+		kids[i] = fmt.Sprintf("%d 0 R", i+3)
+	}
+	objects = append(objects, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), pages))
+	for range pages {
+		objects = append(objects, "<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] >>")
+	}
+
+	var b bytes.Buffer
+	b.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = b.Len()
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+	xref := b.Len()
+	fmt.Fprintf(&b, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&b, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&b, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return b.Bytes()
+}
+
+func TestMergePDFs(t *testing.T) {
+	merged, err := MergePDFs([][]byte{blankPDF(2), blankPDF(1)})
+	if assert.NoError(t, err) {
+		pages, err := api.PageCount(bytes.NewReader(merged), nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, pages)
+	}
+
+	merged, err = MergePDFs([][]byte{blankPDF(1)})
+	if assert.NoError(t, err) {
+		pages, err := api.PageCount(bytes.NewReader(merged), nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, pages)
+	}
+
+	_, err = MergePDFs(nil)
+	assert.ErrorContains(t, err, "at least one PDF")
+	_, err = MergePDFs([][]byte{[]byte("not a PDF")})
+	assert.ErrorContains(t, err, "failed to merge PDFs")
+}
@@ -0,0 +1,143 @@
+package playwright_integration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// ServerTiming holds a single Server-Timing entry reported for a resource.
+type ServerTiming struct {
+	Name        string  `json:"name"`
+	Duration    float64 `json:"duration"`
+	Description string  `json:"description"`
+}
+
+// ResourceTiming holds the Resource Timing API data for a single loaded resource.
+// Durations are in milliseconds and sizes in bytes, as reported by the browser.
+type ResourceTiming struct {
+	Name            string         `json:"name"`
+	InitiatorType   string         `json:"initiatorType"`
+	Duration        float64        `json:"duration"`
+	TransferSize    float64        `json:"transferSize"`
+	EncodedBodySize float64        `json:"encodedBodySize"`
+	DecodedBodySize float64        `json:"decodedBodySize"`
+	ServerTiming    []ServerTiming `json:"serverTiming"`
+}
+
+// resourceTimingsScript collects the resource timing entries of the current page.
+const resourceTimingsScript = `() => performance.getEntriesByType('resource').map(e => ({
+	name: e.name,
+	initiatorType: e.initiatorType,
+	duration: e.duration,
+	transferSize: e.transferSize,
+	encodedBodySize: e.encodedBodySize,
+	decodedBodySize: e.decodedBodySize,
+	serverTiming: (e.serverTiming || []).map(s => ({ name: s.name, duration: s.duration, description: s.description })),
+}))`
+
+// GetResourceTimings returns the page's resource timing entries sorted by duration, slowest first.
+// If maxEntries is greater than zero, at most that many entries are returned.
+func (pi *PlaywrightIntegration) GetResourceTimings(ctx context.Context, page playwright.Page, maxEntries int) ([]ResourceTiming, error) {
+	result, err := pi.ExecuteScript(ctx, page, resourceTimingsScript)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read resource timings: %w", err)
+	}
+
+	var timings []ResourceTiming
+	if err := decodeScriptResult(result, &timings); err != nil {
+		return nil, fmt.Errorf("failed to decode resource timings: %w", err)
+	}
+
+	sort.SliceStable(timings, func(i, j int) bool {
+		return timings[i].Duration > timings[j].Duration
+	})
+	if maxEntries > 0 && len(timings) > maxEntries {
+		timings = timings[:maxEntries]
+	}
+	pi.logger.Debug("Collected resource timings", "count", len(timings))
+	return timings, nil
+}
+
+// ErrNoPerformanceTiming is returned by PerformanceMetrics when the page exposes neither the
+// Navigation Timing entry nor the legacy performance.timing object, e.g. about:blank.
+var ErrNoPerformanceTiming = errors.New("page has no performance timing data")
+
+// PerformanceMetrics are load and paint milestones of a page in milliseconds since the start of
+// the navigation. Milestones the browser does not report, or that the page has not reached yet,
+// are nil: WebKit has no first-paint entry, for instance, and Load is nil while the page loads.
+type PerformanceMetrics struct {
+	TTFB                 *float64 `json:"ttfb_ms,omitempty"`
+	DOMContentLoaded     *float64 `json:"dom_content_loaded_ms,omitempty"`
+	Load                 *float64 `json:"load_ms,omitempty"`
+	FirstPaint           *float64 `json:"first_paint_ms,omitempty"`
+	FirstContentfulPaint *float64 `json:"first_contentful_paint_ms,omitempty"`
+	// Source is the API the load milestones were read from: "navigation_timing" for the
+	// PerformanceNavigationTiming entry, or "performance_timing" for the deprecated
+	// performance.timing object of older browsers.
+	Source string `json:"source"`
+}
+
+// performanceMetricsScript reads the load milestones from the navigation entry, falling back to
+// performance.timing, and the paint milestones from the paint entries. It returns the JSON form
+// of PerformanceMetrics; unreached or unsupported milestones are null.
+const performanceMetricsScript = `() => {
+	const entries = (type) => (performance.getEntriesByType ? performance.getEntriesByType(type) : []);
+	const reached = (v) => (typeof v === 'number' && v > 0 ? v : null);
+	const metrics = {};
+	const nav = entries('navigation')[0];
+	if (nav) {
+		metrics.source = 'navigation_timing';
+		metrics.ttfb_ms = reached(nav.responseStart);
+		metrics.dom_content_loaded_ms = reached(nav.domContentLoadedEventEnd);
+		metrics.load_ms = reached(nav.loadEventEnd);
+	} else if (performance.timing && performance.timing.navigationStart > 0) {
+		const t = performance.timing;
+		const since = (v) => (v > 0 ? v - t.navigationStart : null);
+		metrics.source = 'performance_timing';
+		metrics.ttfb_ms = since(t.responseStart);
+		metrics.dom_content_loaded_ms = since(t.domContentLoadedEventEnd);
+		metrics.load_ms = since(t.loadEventEnd);
+	} else {
+		return null;
+	}
+	for (const entry of entries('paint')) {
+		if (entry.name === 'first-paint') {
+			metrics.first_paint_ms = entry.startTime;
+		} else if (entry.name === 'first-contentful-paint') {
+			metrics.first_contentful_paint_ms = entry.startTime;
+		}
+	}
+	return metrics;
+}`
+
+// PerformanceMetrics reads the load and paint milestones of the loaded page.
+func (pi *PlaywrightIntegration) PerformanceMetrics(ctx context.Context, page playwright.Page) (*PerformanceMetrics, error) {
+	result, err := pi.ExecuteScript(ctx, page, performanceMetricsScript)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read performance metrics: %w", err)
+	}
+	if result == nil {
+		return nil, ErrNoPerformanceTiming
+	}
+
+	metrics := &PerformanceMetrics{}
+	if err := decodeScriptResult(result, metrics); err != nil {
+		return nil, fmt.Errorf("failed to decode performance metrics: %w", err)
+	}
+	pi.logger.Debug("Collected performance metrics", "source", metrics.Source)
+	return metrics, nil
+}
+
+// decodeScriptResult converts the generic value returned by page.Evaluate into target.
+func decodeScriptResult(result interface{}, target interface{}) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
+}
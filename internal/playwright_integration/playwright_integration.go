@@ -2,10 +2,20 @@ package playwright_integration
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/Camelket/mcp-browser-tools/internal/browser"
+	"github.com/Camelket/mcp-browser-tools/internal/metrics"
+	"github.com/Camelket/mcp-browser-tools/internal/safety"
+	"github.com/Camelket/mcp-browser-tools/internal/utils"
 	"github.com/playwright-community/playwright-go"
 )
 
@@ -14,36 +24,244 @@ type PlaywrightRunFunc func(options ...*playwright.RunOptions) (*playwright.Play
 
 // PlaywrightIntegration provides a high-level interface for Playwright interactions.
 type PlaywrightIntegration struct {
-	browserManager      *browser.BrowserInstanceManager
-	logger              *slog.Logger
-	capturedNetworkData []CapturedNetworkActivity
-	pendingRequests     map[string]CapturedRequest // Map to store requests by URL until response is received
+	browserManager     *browser.BrowserInstanceManager
+	logger             *slog.Logger
+	captureMu          sync.Mutex                       // Guards the capture state below up to maxFrameBytes, updated from event handlers
+	captures           map[playwright.Page]*pageCapture // Capture state of each open page
+	maxBodyBytes       int                              // Per-body capture limit, see SetCaptureLimits
+	maxCaptureBytes    int                              // Limit of all bodies captured on a page, see SetCaptureLimits
+	maxFrameBytes      int                              // Per-frame WebSocket payload limit, see SetWebSocketFrameLimit
+	rateLimiter        *originRateLimiter               // Limits navigations per origin; nil if disabled
+	headerRules        []compiledHeaderRule             // Headers injected on every intercepted page, see SetHeaderInjectionRules
+	mocks              []compiledMock                   // Mocks applied to every intercepted page, see SetMockEndpoints
+	urlPolicy          *safety.Policy                   // SSRF protection, see SetURLPolicy
+	defaultTimeout     time.Duration                    // Default Playwright timeout of new pages, see SetDefaultTimeout
+	debugScreenshotDir string                           // Where failures are captured, see SetDebugScreenshotDir
+	ignoreHTTPSErrors  bool                             // Default for pages created without options, see SetIgnoreHTTPSErrors
+}
+
+// PageOptions configures the browser context a new page is created in.
+// Zero values leave Playwright's defaults in place.
+type PageOptions struct {
+	ViewportWidth     int
+	ViewportHeight    int
+	DeviceScaleFactor float64
+	UserAgent         string
+	IsMobile          bool
+	HasTouch          bool
+	// HTTPCredentials answer HTTP basic auth challenges from the pages loaded in the context.
+	HTTPCredentials *HTTPCredentials
+	// Locale sets navigator.language and the Accept-Language header, e.g. "de-DE".
+	Locale string
+	// TimezoneID is an IANA time zone name such as "Europe/Berlin".
+	TimezoneID string
+	// Geolocation is reported by the Geolocation API; the permission is granted automatically.
+	Geolocation *Geolocation
+	// ColorScheme ("light" or "dark"), ReducedMotion ("reduce" or "no-preference") and Media
+	// ("screen" or "print") are emulated with page.EmulateMedia before the page navigates.
+	ColorScheme   string
+	ReducedMotion string
+	Media         string
+	// Proxy overrides the browser-wide proxy for this page's context.
+	Proxy *browser.ProxySettings
+	// IgnoreHTTPSErrors disables certificate validation, e.g. for staging sites with
+	// self-signed certificates. NewPage logs a warning whenever it is set.
+	IgnoreHTTPSErrors bool
+}
+
+// toPlaywright converts PageOptions into the options for browser.NewPage.
+func (o *PageOptions) toPlaywright() playwright.BrowserNewPageOptions {
+	var options playwright.BrowserNewPageOptions
+	if o == nil {
+		return options
+	}
+	if o.ViewportWidth > 0 && o.ViewportHeight > 0 {
+		options.Viewport = &playwright.Size{Width: o.ViewportWidth, Height: o.ViewportHeight}
+	}
+	if o.DeviceScaleFactor > 0 {
+		options.DeviceScaleFactor = playwright.Float(o.DeviceScaleFactor)
+	}
+	if o.UserAgent != "" {
+		options.UserAgent = playwright.String(o.UserAgent)
+	}
+	if o.IsMobile {
+		options.IsMobile = playwright.Bool(true)
+	}
+	if o.HasTouch {
+		options.HasTouch = playwright.Bool(true)
+	}
+	if o.Locale != "" {
+		options.Locale = playwright.String(o.Locale)
+	}
+	if o.TimezoneID != "" {
+		options.TimezoneId = playwright.String(o.TimezoneID)
+	}
+	if o.Geolocation != nil {
+		options.Geolocation = &playwright.Geolocation{
+			Latitude:  o.Geolocation.Latitude,
+			Longitude: o.Geolocation.Longitude,
+		}
+		options.Permissions = []string{"geolocation"}
+	}
+	if o.IgnoreHTTPSErrors {
+		options.IgnoreHttpsErrors = playwright.Bool(true)
+	}
+	if o.Proxy != nil {
+		options.Proxy = o.Proxy.ToPlaywright()
+	}
+	if o.HTTPCredentials != nil {
+		options.HttpCredentials = &playwright.HttpCredentials{
+			Username: o.HTTPCredentials.Username,
+			Password: o.HTTPCredentials.Password,
+		}
+	}
+	return options
 }
 
 // PageScreenshotOptions provides options for capturing a screenshot.
 type PageScreenshotOptions struct {
 	FullPage bool
+	// Clip restricts the screenshot to a rectangle of the page, in CSS pixels from the top-left corner.
+	Clip *Rect
+	// Format is "png" (the default) or "jpeg".
+	Format string
+	// Quality is the JPEG quality from 0 to 100. Zero uses Playwright's default; it is invalid for PNG.
+	Quality int
+	// MaxWidth, if positive, downscales wider screenshots to this many pixels, keeping the aspect ratio.
+	MaxWidth int
+	// MaxHeight is the tallest page, in CSS pixels, a full-page screenshot may capture in one
+	// image. Zero uses DefaultMaxScreenshotHeight. See CaptureScreenshotSegments for taller pages.
+	MaxHeight int
+}
+
+// Rect is a rectangle in CSS pixels.
+type Rect struct {
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
 }
 
 // CapturedRequest holds details of an intercepted network request.
 type CapturedRequest struct {
-	URL     string            `json:"url"`
-	Method  string            `json:"method"`
-	Headers map[string]string `json:"headers"`
-	Body    string            `json:"body,omitempty"`
+	URL          string            `json:"url"`
+	Method       string            `json:"method"`
+	ResourceType string            `json:"resource_type"` // Playwright resource type, e.g. "document", "xhr", "image"
+	Headers      map[string]string `json:"headers"`
+	Body         string            `json:"body,omitempty"`
+	StartedAt    time.Time         `json:"started_at,omitzero"` // When the request was routed
 }
 
 // CapturedResponse holds details of an intercepted network response.
 type CapturedResponse struct {
 	Status  int               `json:"status"`
 	Headers map[string]string `json:"headers"`
-	Body    string            `json:"body,omitempty"`
+	// Body is the response text, a placeholder such as "[binary 1234 bytes]" for binary
+	// content, or base64 if Encoding is "base64".
+	Body          string `json:"body,omitempty"`
+	Encoding      string `json:"encoding,omitempty"`
+	Charset       string `json:"charset,omitempty"`        // Charset of the body as served, lowercased, if known
+	BodySize      int    `json:"body_size,omitempty"`      // Full size of the body in bytes, if known
+	BodyTruncated bool   `json:"body_truncated,omitempty"` // Body was cut to the capture limits
+	Protocol      string `json:"protocol,omitempty"`       // e.g. "h2" or "http/1.1"; Chromium only
+	// EndedAt is when the body had been received, and DurationMs the time from
+	// CapturedRequest.StartedAt until then.
+	EndedAt    time.Time `json:"ended_at,omitzero"`
+	DurationMs float64   `json:"duration_ms,omitempty"`
+	// TransferSize is the number of bytes received for the response: headers and the
+	// encoded body. Zero for mocked responses and if the browser did not report it.
+	TransferSize int `json:"transfer_size,omitempty"`
+}
+
+// finish records the end of the exchange that started with req.
+func (r *CapturedResponse) finish(req CapturedRequest, now time.Time) {
+	r.EndedAt = now
+	if !req.StartedAt.IsZero() && now.After(req.StartedAt) {
+		r.DurationMs = float64(now.Sub(req.StartedAt)) / float64(time.Millisecond)
+	}
 }
 
 // CapturedNetworkActivity holds details of a full request-response cycle.
 type CapturedNetworkActivity struct {
 	Request  CapturedRequest  `json:"request"`
 	Response CapturedResponse `json:"response"`
+	Mocked   bool             `json:"mocked,omitempty"` // True if the response was served from a MockEndpoint
+	// Failed is set for requests that never received a response, e.g. aborted or refused
+	// connections; Response is then empty and Failure holds the browser's error text.
+	Failed  bool   `json:"failed,omitempty"`
+	Failure string `json:"failure,omitempty"`
+}
+
+// pendingRequest is a routed request awaiting its response. Requests are matched to responses
+// by identity, as the same URL may be requested several times, even concurrently.
+type pendingRequest struct {
+	request  playwright.Request
+	captured CapturedRequest
+}
+
+// MockEndpoint describes a canned response served for requests whose URL matches URLPattern.
+// URLPattern uses the same glob syntax as Playwright routes (e.g. "**/api/users*").
+type MockEndpoint struct {
+	URLPattern  string `json:"url_pattern"`
+	Status      int    `json:"status"`
+	ContentType string `json:"content_type"`
+	Body        string `json:"body"`
+	// Headers are added to the response; a Content-Type header here overrides ContentType.
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// InterceptionOptions configures SetupNetworkInterception.
+type InterceptionOptions struct {
+	// Mocks are checked in order and the first matching endpoint wins.
+	Mocks []MockEndpoint
+	// BlockResources lists resource types (e.g. "image", "font"), URL globs, or the
+	// "trackers" preset; matching requests are aborted. Mocks take precedence.
+	BlockResources []string
+	// HeaderRules add headers to matching requests, e.g. credentials for an API only.
+	HeaderRules []HeaderInjectionRule
+	// SkipCapture installs only the routing (mocks and blocking) without recording
+	// requests and responses, avoiding the cost of buffering response bodies.
+	SkipCapture bool
+}
+
+// compiledMock pairs a MockEndpoint with its compiled URL pattern.
+type compiledMock struct {
+	endpoint MockEndpoint
+	pattern  *regexp.Regexp
+}
+
+// compileMocks compiles the URL patterns of mocks.
+func compileMocks(mocks []MockEndpoint) ([]compiledMock, error) {
+	compiled := make([]compiledMock, 0, len(mocks))
+	for i, m := range mocks {
+		if m.URLPattern == "" {
+			return nil, fmt.Errorf("mock endpoint %d is missing url_pattern", i)
+		}
+		pattern, err := compileGlob(m.URLPattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid mock endpoint: %w", err)
+		}
+		compiled = append(compiled, compiledMock{endpoint: m, pattern: pattern})
+	}
+	return compiled, nil
+}
+
+// SetMockEndpoints replaces the mocks served on every page routed through
+// SetupNetworkInterception. They are checked after the per-call InterceptionOptions.Mocks.
+// Passing nil removes all mocks.
+func (pi *PlaywrightIntegration) SetMockEndpoints(mocks []MockEndpoint) error {
+	compiled, err := compileMocks(mocks)
+	if err != nil {
+		return err
+	}
+	pi.mocks = compiled
+	return nil
+}
+
+// HasMockEndpoints reports whether any mocks are set with SetMockEndpoints, in which case
+// requests must be routed through SetupNetworkInterception for them to apply.
+func (pi *PlaywrightIntegration) HasMockEndpoints() bool {
+	return len(pi.mocks) > 0
 }
 
 // NewPlaywrightIntegration creates a new PlaywrightIntegration instance.
@@ -52,74 +270,324 @@ func NewPlaywrightIntegration(browserManager *browser.BrowserInstanceManager, lo
 		return nil, fmt.Errorf("browser instance manager cannot be nil")
 	}
 	return &PlaywrightIntegration{
-		browserManager:      browserManager,
-		logger:              logger,
-		capturedNetworkData: []CapturedNetworkActivity{},
-		pendingRequests:     make(map[string]CapturedRequest),
+		browserManager:  browserManager,
+		logger:          logger,
+		maxBodyBytes:    DefaultMaxBodyBytes,
+		maxCaptureBytes: DefaultMaxCaptureBytes,
+		maxFrameBytes:   DefaultMaxFrameBytes,
 	}, nil
 }
 
+// SetNavigationRateLimit limits navigations to requestsPerSecond per origin. Navigations over
+// the limit wait for their turn instead of failing. Zero or a negative value disables the limit.
+func (pi *PlaywrightIntegration) SetNavigationRateLimit(requestsPerSecond float64) {
+	pi.rateLimiter = newOriginRateLimiter(requestsPerSecond)
+}
+
+// SetDefaultTimeout sets the timeout of navigations and other page operations (clicks, waits,
+// evaluations) on pages created afterwards, wherever no explicit timeout is passed. Zero keeps
+// Playwright's default of 30 seconds.
+func (pi *PlaywrightIntegration) SetDefaultTimeout(d time.Duration) {
+	pi.defaultTimeout = d
+}
+
+// SetIgnoreHTTPSErrors disables TLS certificate validation for pages created afterwards with
+// nil options, e.g. by NavigateToURL. Pages created with options follow their own
+// IgnoreHTTPSErrors field. A warning is logged for every page created without validation.
+func (pi *PlaywrightIntegration) SetIgnoreHTTPSErrors(ignore bool) {
+	pi.ignoreHTTPSErrors = ignore
+}
+
 // Close stops the Playwright instance.
 func (pi *PlaywrightIntegration) Close() {
 	// The browser instance is managed by BrowserInstanceManager, so we don't stop Playwright here.
-	// We just ensure any pending requests are cleared.
-	pi.pendingRequests = make(map[string]CapturedRequest)
+	// We just ensure the capture state of any open pages is dropped.
+	pi.captureMu.Lock()
+	pi.captures = nil
+	pi.captureMu.Unlock()
 }
 
 // NewPage creates a new browser page using the managed browser instance.
-func (pi *PlaywrightIntegration) NewPage(ctx context.Context) (playwright.Page, error) {
+// Each page gets its own browser context configured by opts, which may be nil;
+// the context is closed together with the page.
+func (pi *PlaywrightIntegration) NewPage(ctx context.Context, opts *PageOptions) (playwright.Page, error) {
 	browser, err := pi.browserManager.GetBrowserInstance(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("could not get browser instance: %w", err)
 	}
 
-	page, err := browser.NewPage()
+	if opts == nil && pi.ignoreHTTPSErrors {
+		opts = &PageOptions{IgnoreHTTPSErrors: true}
+	}
+	if opts != nil && opts.IgnoreHTTPSErrors {
+		pi.logger.Warn("Creating page with HTTPS certificate validation disabled")
+	}
+
+	page, err := browser.NewPage(opts.toPlaywright())
 	if err != nil {
 		return nil, fmt.Errorf("could not create page: %w", err)
 	}
+	if pi.defaultTimeout > 0 {
+		timeout := float64(pi.defaultTimeout.Milliseconds())
+		page.Context().SetDefaultNavigationTimeout(timeout)
+		page.Context().SetDefaultTimeout(timeout)
+	}
+	if opts.hasMediaEmulation() {
+		if err := emulateMedia(page, opts); err != nil {
+			page.Close()
+			return nil, err
+		}
+	}
+	// Enforce the URL policy even on pages that never set up interception. SetupNetworkInterception
+	// replaces this handler with its own, which enforces the policy as well.
+	if pi.urlPolicy != nil {
+		if err := page.Route("**/*", func(route playwright.Route) { pi.continueRoute(page, route, nil) }); err != nil {
+			page.Close()
+			return nil, fmt.Errorf("failed to install URL policy: %w", err)
+		}
+	}
 
-	// Use a goroutine to close the page if the parent context is cancelled
+	// Close the page when ctx is cancelled, which aborts any Playwright operation in flight on
+	// it. The watcher exits when the page is closed normally.
+	closed := make(chan struct{})
+	page.OnClose(func(playwright.Page) { close(closed) })
 	go func() {
 		select {
 		case <-ctx.Done():
-			pi.logger.Debug("Context cancelled. Closing page.")
-			if page != nil {
-				page.Close()
-			}
-		default:
-			// Do nothing if context is not done
+			pi.logger.Debug("Context cancelled. Closing page.", "error", ctx.Err())
+			page.Close()
+		case <-closed:
 		}
 	}()
 
 	return page, nil
 }
 
-// NavigateToURL navigates to a given URL with configurable options.
-func (pi *PlaywrightIntegration) NavigateToURL(ctx context.Context, url string, options *playwright.PageGotoOptions, timeoutSeconds float64) (playwright.Page, error) {
-	pi.logger.Info("Navigating to URL", "url", url, "timeout", timeoutSeconds)
+// NavigationOptions configures how NavigateToURL and NavigateToURLOnPage load a page.
+type NavigationOptions struct {
+	// WaitUntil is the load state page.Goto waits for. Nil uses Playwright's default ("load").
+	WaitUntil *playwright.WaitUntilState
+	// Timeout bounds each navigation attempt. It is passed to page.Goto, clamped to the
+	// caller's context deadline, and together with the retries and SettleDelay it also bounds
+	// the context of the whole navigation. Zero uses Playwright's default timeout.
+	Timeout time.Duration
+	// Referer is sent with the main document request; it overrides a Referer in ExtraHeaders.
+	Referer string
+	// MaxRetries is the number of additional attempts made after a network failure. Zero
+	// disables retries. The MCP tools leave it unset; it is meant for library callers.
+	MaxRetries int
+	// RetryDelay is the delay before the first retry; it doubles on each subsequent retry.
+	RetryDelay time.Duration
+	// NetworkConditions, if set, are emulated on the page before navigating (Chromium only).
+	NetworkConditions *NetworkConditions
+	// ExtraHeaders are sent with every request the page makes, including subresources.
+	ExtraHeaders map[string]string
+	// SettleDelay is an extra wait after the WaitUntil condition is met, for pages that keep
+	// rendering after load.
+	SettleDelay time.Duration
+	// FailOnUnauthorized makes a 401 response to the main document an ErrAuthenticationFailed
+	// error instead of a successful navigation to the error page. Set it when credentials are supplied.
+	FailOnUnauthorized bool
+	// FailOnHTTPError makes a 4xx or 5xx response to the main document an *HTTPStatusError.
+	FailOnHTTPError bool
+	// Progress, if set, is told when the navigation starts, when the document has loaded and
+	// when the page has settled.
+	Progress ProgressFunc
+}
 
-	page, err := pi.NewPage(ctx)
+// NavigateToURL navigates to a given URL with configurable options.
+func (pi *PlaywrightIntegration) NavigateToURL(ctx context.Context, url string, opts *NavigationOptions) (playwright.Page, error) {
+	page, err := pi.NewPage(ctx, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create new page: %w", err)
 	}
+	if err := ctx.Err(); err != nil {
+		page.Close()
+		return nil, err
+	}
 
-	// Set default timeout if not provided or if options is nil
-	if options == nil {
-		options = &playwright.PageGotoOptions{}
+	if _, err := pi.NavigateToURLOnPage(ctx, page, url, opts); err != nil {
+		page.Close() // Close page if navigation fails
+		return nil, err
 	}
-	if timeoutSeconds > 0 {
-		options.Timeout = playwright.Float(timeoutSeconds * 1000) // Convert seconds to milliseconds
+	return page, nil
+}
+
+// NavigateToURLOnPage navigates an existing page to a given URL with configurable options.
+// Use this instead of NavigateToURL when the page needs to be prepared (e.g. with network
+// interception) before navigation. The caller remains responsible for closing the page.
+// It returns the HTTP status of the main document.
+func (pi *PlaywrightIntegration) NavigateToURLOnPage(ctx context.Context, page playwright.Page, url string, opts *NavigationOptions) (*DocumentStatus, error) {
+	if page == nil {
+		return nil, fmt.Errorf("playwright.Page cannot be nil")
 	}
-	// If timeoutSeconds is 0, Playwright's default timeout will be used.
+	if opts == nil {
+		opts = &NavigationOptions{}
+	}
+	pi.logger.Info("Navigating to URL", "url", url, "timeout", opts.Timeout)
+	defer metrics.NavigationDuration.ObserveDuration(time.Now())
 
-	pi.logger.Debug("Calling page.Goto", "url", url, "options", options)
-	if _, err = page.Goto(url, *options); err != nil {
-		page.Close() // Close page if navigation fails
-		return nil, fmt.Errorf("failed to navigate to %s: %w", url, err)
+	if budget := opts.Budget(); budget > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, budget)
+		defer cancel()
 	}
 
-	pi.logger.Info("Successfully navigated to URL", "url", url)
-	return page, nil
+	if err := pi.checkURL(ctx, url); err != nil {
+		return nil, err
+	}
+	pi.takePolicyViolation(page) // Forget a violation of an earlier navigation
+
+	if err := pi.rateLimiter.wait(ctx, url); err != nil {
+		return nil, wrapTimeout(PhaseNavigation, fmt.Errorf("rate limit wait for %s: %w", url, err))
+	}
+
+	if err := pi.ApplyNetworkConditions(ctx, page, opts.NetworkConditions); err != nil {
+		return nil, err
+	}
+	offline := opts.NetworkConditions != nil && opts.NetworkConditions.Offline
+
+	if len(opts.ExtraHeaders) > 0 {
+		if err := ValidateExtraHeaders(opts.ExtraHeaders); err != nil {
+			return nil, err
+		}
+		if err := page.SetExtraHTTPHeaders(opts.ExtraHeaders); err != nil {
+			return nil, fmt.Errorf("failed to set extra HTTP headers: %w", err)
+		}
+	}
+
+	opts.Progress.Report(0, "Navigating to "+url)
+	gotoOptions := playwright.PageGotoOptions{WaitUntil: opts.WaitUntil}
+	if opts.Referer != "" {
+		gotoOptions.Referer = playwright.String(opts.Referer)
+	}
+
+	status := &DocumentStatus{}
+	attempt := 0
+	navigate := func() error {
+		attempt++
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if attempt > 1 {
+			pi.logger.Warn("Retrying navigation", "url", url, "retry", attempt-1, "max_retries", opts.MaxRetries)
+		}
+
+		// Without a timeout or deadline, Playwright's default timeout is used.
+		if timeout, ok := remainingTimeout(ctx, opts.Timeout); ok {
+			if timeout <= 0 {
+				return context.DeadlineExceeded
+			}
+			gotoOptions.Timeout = playwright.Float(float64(timeout.Milliseconds()))
+		}
+		pi.logger.Debug("Calling page.Goto", "url", url, "options", gotoOptions)
+		response, err := page.Goto(url, gotoOptions)
+		if err != nil {
+			if ctx.Err() != nil {
+				return withContextErr(ctx, err)
+			}
+			// A redirect to a blocked address surfaces as a generic net::ERR_BLOCKED_BY_CLIENT.
+			if violation := pi.takePolicyViolation(page); violation != nil {
+				return violation
+			}
+			if offline {
+				return fmt.Errorf("%w: %v", ErrOfflineEmulation, err)
+			}
+			if isProxyAuthError(err) {
+				return fmt.Errorf("%w: %v", ErrProxyAuthenticationFailed, err)
+			}
+			if opts.MaxRetries > 0 && isRetryableNavigationError(err) {
+				return &utils.RetryableError{Err: err}
+			}
+			return err
+		}
+		// Goto returns no response for same-document navigations such as anchor changes.
+		if response != nil {
+			status.StatusCode = response.Status()
+			status.StatusText = response.StatusText()
+			status.Certificate = certificateDetails(response)
+		}
+		status.FinalURL = page.URL()
+
+		if status.StatusCode == http.StatusProxyAuthRequired {
+			return fmt.Errorf("%w: proxy answered 407 Proxy Authentication Required", ErrProxyAuthenticationFailed)
+		}
+		if opts.FailOnUnauthorized && status.StatusCode == http.StatusUnauthorized {
+			return fmt.Errorf("%w: server answered 401 Unauthorized despite the supplied credentials", ErrAuthenticationFailed)
+		}
+		if opts.FailOnHTTPError && status.StatusCode >= 400 {
+			return &HTTPStatusError{URL: status.FinalURL, StatusCode: status.StatusCode, StatusText: status.StatusText}
+		}
+		return nil
+	}
+
+	var err error
+	if opts.MaxRetries > 0 {
+		err = utils.WithRetry(ctx, pi.logger, navigate, opts.MaxRetries+1, opts.RetryDelay)
+	} else {
+		err = navigate()
+	}
+	if err != nil {
+		return nil, pi.debugScreenshot(page, wrapTimeout(PhaseNavigation, fmt.Errorf("failed to navigate to %s: %w", url, err)))
+	}
+
+	if opts.SettleDelay > 0 {
+		opts.Progress.Report(0.8, "Document loaded, waiting for the page to settle")
+		timer := time.NewTimer(opts.SettleDelay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return nil, wrapTimeout(PhaseNavigation, fmt.Errorf("failed to navigate to %s: %w", url, ctx.Err()))
+		}
+	}
+
+	opts.Progress.Report(1, "Navigation complete")
+	pi.logger.Info("Successfully navigated to URL", "url", url, "status", status.StatusCode)
+	return status, nil
+}
+
+// Budget returns the longest a navigation with o may take: every attempt's timeout, the
+// backoff between attempts and the settle delay. Callers bounding a whole operation should
+// derive its deadline from it rather than from Timeout. It returns zero if Timeout is not
+// set, leaving the navigation bounded only by the caller's context.
+func (o *NavigationOptions) Budget() time.Duration {
+	if o.Timeout <= 0 {
+		return 0
+	}
+	budget := o.Timeout*time.Duration(o.MaxRetries+1) + o.SettleDelay
+	for i, delay := 0, o.RetryDelay; i < o.MaxRetries; i, delay = i+1, delay*2 {
+		budget += delay
+	}
+	return budget
+}
+
+// remainingTimeout returns the smaller of limit and the time left until ctx's deadline,
+// ignoring whichever is unset. It reports false if neither is set.
+func remainingTimeout(ctx context.Context, limit time.Duration) (time.Duration, bool) {
+	deadline, hasDeadline := ctx.Deadline()
+	switch {
+	case !hasDeadline && limit <= 0:
+		return 0, false
+	case !hasDeadline:
+		return limit, true
+	case limit <= 0:
+		return time.Until(deadline), true
+	default:
+		return min(limit, time.Until(deadline)), true
+	}
+}
+
+// isRetryableNavigationError reports whether a page.Goto error looks like a transient
+// network failure (a net:: error or a navigation timeout) worth retrying.
+func isRetryableNavigationError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, playwright.ErrTimeout) {
+		return true
+	}
+	return strings.Contains(err.Error(), "net::ERR_")
 }
 
 // ExecuteScript executes JavaScript code on a given playwright.Page and returns the result.
@@ -127,11 +595,14 @@ func (pi *PlaywrightIntegration) ExecuteScript(ctx context.Context, page playwri
 	if page == nil {
 		return nil, fmt.Errorf("playwright.Page cannot be nil")
 	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	pi.logger.Debug("Executing script on page.")
 
 	result, err := page.Evaluate(script, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute script: %w", err)
+		return nil, fmt.Errorf("failed to execute script: %w", withContextErr(ctx, err))
 	}
 	pi.logger.Debug("Script executed successfully.")
 	return result, nil
@@ -144,28 +615,146 @@ func (pi *PlaywrightIntegration) CaptureScreenshot(ctx context.Context, page pla
 	}
 	pi.logger.Debug("Capturing screenshot.")
 
-	screenshot, err := page.Screenshot(playwright.PageScreenshotOptions{FullPage: playwright.Bool(options.FullPage)})
+	if err := options.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid screenshot options: %w", err)
+	}
+	screenshotOptions := playwright.PageScreenshotOptions{FullPage: playwright.Bool(options.FullPage)}
+	options.apply(&screenshotOptions)
+	if options.FullPage && options.Clip == nil {
+		width, height, err := pi.pageSize(ctx, page)
+		if err != nil {
+			return nil, err
+		}
+		if height > float64(options.maxHeight()) {
+			return nil, &ScreenshotTooTallError{Width: int(width), Height: int(height), MaxHeight: options.maxHeight()}
+		}
+	}
+	if options.Clip != nil {
+		if err := pi.validateClip(ctx, page, *options.Clip); err != nil {
+			return nil, err
+		}
+		screenshotOptions.Clip = &playwright.Rect{
+			X:      options.Clip.X,
+			Y:      options.Clip.Y,
+			Width:  options.Clip.Width,
+			Height: options.Clip.Height,
+		}
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		// Bound the screenshot by the caller's deadline rather than Playwright's default timeout.
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return nil, wrapTimeout(PhaseScreenshot, fmt.Errorf("failed to capture screenshot: %w", context.DeadlineExceeded))
+		}
+		screenshotOptions.Timeout = playwright.Float(float64(remaining.Milliseconds()))
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, wrapTimeout(PhaseScreenshot, fmt.Errorf("failed to capture screenshot: %w", err))
+	}
+
+	screenshot, err := page.Screenshot(screenshotOptions)
 	if err != nil {
-		return nil, fmt.Errorf("failed to capture screenshot: %w", err)
+		return nil, wrapTimeout(PhaseScreenshot, fmt.Errorf("failed to capture screenshot: %w", withContextErr(ctx, err)))
+	}
+	if options.MaxWidth > 0 {
+		if screenshot, err = downscale(screenshot, options.MaxWidth, options); err != nil {
+			return nil, err
+		}
 	}
 	pi.logger.Debug("Screenshot captured successfully.")
 	return screenshot, nil
 }
 
+// pageSizeScript returns the scrollable size of the document in CSS pixels.
+const pageSizeScript = `() => ({
+	width: Math.max(document.documentElement.scrollWidth, document.body ? document.body.scrollWidth : 0),
+	height: Math.max(document.documentElement.scrollHeight, document.body ? document.body.scrollHeight : 0),
+})`
+
+// validateClip returns an error if clip is empty or extends beyond the page.
+func (pi *PlaywrightIntegration) validateClip(ctx context.Context, page playwright.Page, clip Rect) error {
+	if clip.Width <= 0 || clip.Height <= 0 {
+		return fmt.Errorf("clip width and height must be positive, got %gx%g", clip.Width, clip.Height)
+	}
+	if clip.X < 0 || clip.Y < 0 {
+		return fmt.Errorf("clip origin must not be negative, got (%g, %g)", clip.X, clip.Y)
+	}
+
+	width, height, err := pi.pageSize(ctx, page)
+	if err != nil {
+		return err
+	}
+	if clip.X+clip.Width > width || clip.Y+clip.Height > height {
+		return fmt.Errorf("clip region (%g, %g, %gx%g) extends beyond the page bounds (%gx%g)",
+			clip.X, clip.Y, clip.Width, clip.Height, width, height)
+	}
+	return nil
+}
+
+// pageSize returns the scrollable width and height of the page in CSS pixels.
+func (pi *PlaywrightIntegration) pageSize(ctx context.Context, page playwright.Page) (width, height float64, err error) {
+	result, err := pi.ExecuteScript(ctx, page, pageSizeScript)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read page size: %w", err)
+	}
+	var size struct {
+		Width  float64 `json:"width"`
+		Height float64 `json:"height"`
+	}
+	if err := decodeScriptResult(result, &size); err != nil {
+		return 0, 0, fmt.Errorf("failed to decode page size: %w", err)
+	}
+	return size.Width, size.Height, nil
+}
+
 // SetupNetworkInterception sets up network interception on a given playwright.Page.
-func (pi *PlaywrightIntegration) SetupNetworkInterception(ctx context.Context, page playwright.Page) error {
+// Requests matching a header injection rule (see SetHeaderInjectionRules and
+// opts.HeaderRules) get the rule's headers added. If opts contains mocks, matching requests
+// are fulfilled with the canned response instead of reaching the network; the first
+// matching mock wins. Requests matching a block rule are aborted and counted (see
+// GetBlockedRequestCount).
+func (pi *PlaywrightIntegration) SetupNetworkInterception(ctx context.Context, page playwright.Page, opts *InterceptionOptions) error {
 	if page == nil {
 		return fmt.Errorf("playwright.Page cannot be nil")
 	}
+	if opts == nil {
+		opts = &InterceptionOptions{}
+	}
+
+	mocks, err := compileMocks(opts.Mocks)
+	if err != nil {
+		return err
+	}
+	mocks = append(mocks, pi.mocks...)
+	blocker, err := newResourceBlocker(opts.BlockResources)
+	if err != nil {
+		return fmt.Errorf("invalid block_resources rule: %w", err)
+	}
+	headerRules, err := compileHeaderRules(opts.HeaderRules)
+	if err != nil {
+		return err
+	}
+	headerRules = slices.Concat(pi.headerRules, headerRules)
+
+	router := &requestRouter{mocks: mocks, blocker: blocker}
 
-	pi.logger.Debug("Setting up network interception.")
+	pi.logger.Debug("Setting up network interception.", "capture", !opts.SkipCapture)
 
-	// Clear previous network data for a new navigation
-	pi.capturedNetworkData = []CapturedNetworkActivity{}
-	pi.pendingRequests = make(map[string]CapturedRequest) // Clear pending requests for a new navigation
+	// Start from empty network data for a new navigation
+	state := pi.resetCapture(page)
+	if !opts.SkipCapture {
+		pi.watchProtocols(page, state)
+	}
+
+	// Replace any earlier handler, such as the URL policy route installed by NewPage or the
+	// handler of a previous interception setup, so every request is handled exactly once.
+	if err := page.UnrouteAll(); err != nil {
+		return fmt.Errorf("failed to remove previous request interception: %w", err)
+	}
 
 	// Set up request interception
-	err := page.Route("**/*", func(route playwright.Route) {
+	err = page.Route("**/*", func(route playwright.Route) {
 		request := route.Request()
 
 		// Capture request details
@@ -179,38 +768,72 @@ func (pi *PlaywrightIntegration) SetupNetworkInterception(ctx context.Context, p
 			}
 		}
 		capturedReq := CapturedRequest{
-			URL:     request.URL(),
-			Method:  request.Method(),
-			Headers: reqHeaders,
-		}
-
-		// Capture request body for POST requests
-		if request.Method() == "POST" {
-			postData, err := request.PostData()
-			if err != nil {
-				pi.logger.Warn("Failed to get request post data", "error", err)
-			} else if postData != "" {
-				capturedReq.Body = postData
+			URL:          request.URL(),
+			Method:       request.Method(),
+			ResourceType: request.ResourceType(),
+			Headers:      redactHeaders(reqHeaders),
+			StartedAt:    time.Now(),
+		}
+
+		// Capture the request body for any method that carries one (POST, PUT, PATCH, DELETE, ...)
+		postData, err := request.PostData()
+		if err != nil {
+			pi.logger.Warn("Failed to get request post data", "error", err)
+		} else if postData != "" {
+			capturedReq.Body = postData
+		}
+
+		action, mock := router.decide(request.URL(), request.ResourceType())
+		switch action {
+		case routeMock:
+			// Serve a canned response
+			if opts.SkipCapture {
+				pi.fulfillMock(route, capturedReq, *mock, nil)
+			} else {
+				pi.fulfillMock(route, capturedReq, *mock, state)
 			}
+			return
+		case routeBlock:
+			pi.captureMu.Lock()
+			state.blockedRequests++
+			pi.captureMu.Unlock()
+			pi.logger.Debug("Blocking request", "url", request.URL(), "resource_type", request.ResourceType())
+			if err := route.Abort(); err != nil {
+				pi.logger.Warn("Failed to abort blocked request", "url", request.URL(), "error", err)
+			}
+			return
 		}
 
-		// Store the request in pendingRequests map
-		pi.pendingRequests[request.URL()] = capturedReq
+		// Add the headers of matching injection rules
+		injected := injectHeaders(headerRules, request.URL(), reqHeaders)
+		if injected != nil {
+			capturedReq.Headers = redactHeaders(injected)
+		}
+
+		// Keep the request until its response or failure arrives
+		if !opts.SkipCapture {
+			pi.captureMu.Lock()
+			state.pending = append(state.pending, pendingRequest{request: request, captured: capturedReq})
+			pi.captureMu.Unlock()
+		}
 
 		// Continue the request
-		route.Continue()
+		pi.continueRoute(page, route, injected)
 	})
 	if err != nil {
 		return fmt.Errorf("failed to set up request interception: %w", err)
 	}
 
+	if opts.SkipCapture {
+		pi.logger.Debug("Network routing set up without capture.")
+		return nil
+	}
+
 	// Set up response interception
 	page.On("response", func(response playwright.Response) {
-		// Retrieve the corresponding request from pendingRequests
-		reqURL := response.Request().URL()
-		capturedReq, ok := pi.pendingRequests[reqURL]
+		capturedReq, ok := pi.takePendingRequest(state, response.Request())
 		if !ok {
-			pi.logger.Debug("No matching pending request found for response", "url", reqURL)
+			pi.logger.Debug("No matching pending request found for response", "url", response.Request().URL())
 			return
 		}
 
@@ -224,34 +847,227 @@ func (pi *PlaywrightIntegration) SetupNetworkInterception(ctx context.Context, p
 				respHeaders[header.Name] = header.Value
 			}
 		}
+		// The protocol is filled in from the CDP events when the capture is read
 		capturedResp := CapturedResponse{
 			Status:  response.Status(),
 			Headers: respHeaders,
 		}
-
-		// Capture response body
-		body, err := response.Body()
-		if err != nil {
-			pi.logger.Warn("Failed to get response body", "error", err)
+		// Redirect and binary bodies are replaced by a placeholder
+		pi.captureResponseBody(response, &capturedResp, state)
+		capturedResp.finish(capturedReq, time.Now())
+		if sizes, err := response.Request().Sizes(); err != nil {
+			pi.logger.Debug("Failed to get response sizes", "url", response.URL(), "error", err)
 		} else {
-			capturedResp.Body = string(body)
+			capturedResp.TransferSize = sizes.ResponseHeadersSize + sizes.ResponseBodySize
 		}
 
-		// Store the captured activity
-		pi.capturedNetworkData = append(pi.capturedNetworkData, CapturedNetworkActivity{
+		pi.recordActivity(state, CapturedNetworkActivity{
 			Request:  capturedReq,
 			Response: capturedResp,
 		})
+	})
 
-		// Remove from pending requests
-		delete(pi.pendingRequests, reqURL)
+	// Record requests that end without a response, e.g. refused connections or aborted fetches
+	page.OnRequestFailed(func(request playwright.Request) {
+		capturedReq, ok := pi.takePendingRequest(state, request)
+		if !ok {
+			return
+		}
+		activity := CapturedNetworkActivity{Request: capturedReq, Failed: true}
+		if failure := request.Failure(); failure != nil {
+			activity.Failure = failure.Error()
+		}
+		pi.logger.Debug("Request failed", "url", request.URL(), "failure", activity.Failure)
+		pi.recordActivity(state, activity)
 	})
 
 	pi.logger.Debug("Network interception set up successfully.")
 	return nil
 }
 
-// GetCapturedNetworkData returns the captured network activity.
-func (pi *PlaywrightIntegration) GetCapturedNetworkData() []CapturedNetworkActivity {
-	return pi.capturedNetworkData
+// fulfillMock answers a routed request with a mock endpoint's canned response and, if state
+// is not nil, records the exchange in it as mocked network activity.
+func (pi *PlaywrightIntegration) fulfillMock(route playwright.Route, capturedReq CapturedRequest, m MockEndpoint, state *pageCapture) {
+	status := m.Status
+	if status == 0 {
+		status = 200
+	}
+	contentType := m.ContentType
+	if contentType == "" {
+		contentType = "text/plain"
+	}
+
+	headers := map[string]string{"content-type": contentType}
+	for name, value := range m.Headers {
+		if strings.EqualFold(name, "content-type") {
+			name = "content-type"
+		}
+		headers[name] = value
+	}
+
+	pi.logger.Debug("Serving mock response", "url", capturedReq.URL, "pattern", m.URLPattern, "status", status)
+	if err := route.Fulfill(playwright.RouteFulfillOptions{
+		Status:  playwright.Int(status),
+		Headers: headers,
+		Body:    m.Body,
+	}); err != nil {
+		pi.logger.Warn("Failed to fulfill mock response", "url", capturedReq.URL, "error", err)
+		return
+	}
+	if state == nil {
+		return
+	}
+
+	response := CapturedResponse{
+		Status:   status,
+		Headers:  headers,
+		Body:     m.Body,
+		BodySize: len(m.Body),
+	}
+	response.finish(capturedReq, time.Now())
+	pi.recordActivity(state, CapturedNetworkActivity{
+		Request:  capturedReq,
+		Response: response,
+		Mocked:   true,
+	})
+}
+
+// takePendingRequest removes request from the pending requests of state and returns its
+// captured details, or false if it was not routed with capture enabled.
+func (pi *PlaywrightIntegration) takePendingRequest(state *pageCapture, request playwright.Request) (CapturedRequest, bool) {
+	pi.captureMu.Lock()
+	defer pi.captureMu.Unlock()
+	for i, pending := range state.pending {
+		if pending.request == request {
+			state.pending = slices.Delete(state.pending, i, i+1)
+			return pending.captured, true
+		}
+	}
+	return CapturedRequest{}, false
+}
+
+// recordActivity appends a finished exchange to the captured network data of state.
+func (pi *PlaywrightIntegration) recordActivity(state *pageCapture, activity CapturedNetworkActivity) {
+	pi.captureMu.Lock()
+	defer pi.captureMu.Unlock()
+	state.networkData = append(state.networkData, activity)
+}
+
+// GetCapturedNetworkData returns the network activity captured on page since its last
+// SetupNetworkInterception, in the order the exchanges finished.
+func (pi *PlaywrightIntegration) GetCapturedNetworkData(page playwright.Page) []CapturedNetworkActivity {
+	pi.captureMu.Lock()
+	defer pi.captureMu.Unlock()
+	state := pi.recordedCapture(page)
+	state.fillProtocols()
+	return slices.Clone(state.networkData)
+}
+
+// DocumentResponse returns the captured response of the document request for url on page,
+// usually the final URL of a navigation, or false if no such response was captured.
+func (pi *PlaywrightIntegration) DocumentResponse(page playwright.Page, url string) (CapturedResponse, bool) {
+	pi.captureMu.Lock()
+	defer pi.captureMu.Unlock()
+	networkData := pi.recordedCapture(page).networkData
+	for i := len(networkData) - 1; i >= 0; i-- {
+		activity := networkData[i]
+		if activity.Request.URL == url && activity.Request.ResourceType == "document" {
+			return activity.Response, true
+		}
+	}
+	return CapturedResponse{}, false
+}
+
+// DocumentSource returns the HTML the server sent for the document at url, before any script
+// ran, from the network capture of page, which must have been set up with capture enabled. It
+// fails if the body was cut to the capture limits or is not text that could be decoded to UTF-8.
+func (pi *PlaywrightIntegration) DocumentSource(page playwright.Page, url string) (string, error) {
+	response, ok := pi.DocumentResponse(page, url)
+	if !ok {
+		return "", fmt.Errorf("no document response was captured for %s", url)
+	}
+	if !isTextContentType(headerValue(response.Headers, "Content-Type")) {
+		return "", fmt.Errorf("the document at %s is not text: %s", url, headerValue(response.Headers, "Content-Type"))
+	}
+	if response.BodyTruncated {
+		return "", fmt.Errorf("the source of %s (%d bytes) was cut to the network capture limits", url, response.BodySize)
+	}
+	if response.Encoding != "" {
+		if response.Charset != "" {
+			return "", fmt.Errorf("the source of %s is in an unsupported charset: %s", url, response.Charset)
+		}
+		return "", fmt.Errorf("the source of %s is not UTF-8 text", url)
+	}
+	return response.Body, nil
+}
+
+// NetworkActivityFilter selects captured network activity. Zero values match everything.
+type NetworkActivityFilter struct {
+	// StatusMin and StatusMax bound the response status inclusively; 0 means "no bound".
+	StatusMin, StatusMax int
+	// ContentType matches responses whose Content-Type contains it, ignoring case (e.g. "json").
+	ContentType string
+	// ResourceType matches requests of this Playwright resource type (e.g. "xhr", "fetch", "image").
+	ResourceType string
+}
+
+// matches reports whether an entry passes the filter.
+func (f NetworkActivityFilter) matches(activity CapturedNetworkActivity) bool {
+	status := activity.Response.Status
+	if (f.StatusMin != 0 && status < f.StatusMin) || (f.StatusMax != 0 && status > f.StatusMax) {
+		return false
+	}
+	if f.ContentType != "" && !strings.Contains(strings.ToLower(headerValue(activity.Response.Headers, "Content-Type")), strings.ToLower(f.ContentType)) {
+		return false
+	}
+	if f.ResourceType != "" && !strings.EqualFold(activity.Request.ResourceType, f.ResourceType) {
+		return false
+	}
+	return true
+}
+
+// FilterNetworkActivity returns the network activity captured on page matching all criteria of the filter.
+func (pi *PlaywrightIntegration) FilterNetworkActivity(page playwright.Page, filter NetworkActivityFilter) []CapturedNetworkActivity {
+	pi.captureMu.Lock()
+	defer pi.captureMu.Unlock()
+	state := pi.recordedCapture(page)
+	state.fillProtocols()
+	filtered := []CapturedNetworkActivity{}
+	for _, activity := range state.networkData {
+		if filter.matches(activity) {
+			filtered = append(filtered, activity)
+		}
+	}
+	return filtered
+}
+
+// FilterByStatusRange returns the network activity captured on page whose response status
+// lies between min and max inclusive. A bound of 0 means "no bound", so
+// FilterByStatusRange(page, 400, 0) returns all responses with status 400 and above.
+func (pi *PlaywrightIntegration) FilterByStatusRange(page playwright.Page, min, max int) []CapturedNetworkActivity {
+	return pi.FilterNetworkActivity(page, NetworkActivityFilter{StatusMin: min, StatusMax: max})
+}
+
+// FilterByStatus returns the network activity captured on page with the given response status.
+func (pi *PlaywrightIntegration) FilterByStatus(page playwright.Page, status int) []CapturedNetworkActivity {
+	return pi.FilterByStatusRange(page, status, status)
+}
+
+// FilterByContentType returns the network activity captured on page whose response
+// Content-Type contains contentType, so "json" matches "application/json; charset=utf-8".
+func (pi *PlaywrightIntegration) FilterByContentType(page playwright.Page, contentType string) []CapturedNetworkActivity {
+	return pi.FilterNetworkActivity(page, NetworkActivityFilter{ContentType: contentType})
+}
+
+// FilterByResourceType returns the network activity captured on page of the given resource type.
+func (pi *PlaywrightIntegration) FilterByResourceType(page playwright.Page, resourceType string) []CapturedNetworkActivity {
+	return pi.FilterNetworkActivity(page, NetworkActivityFilter{ResourceType: resourceType})
+}
+
+// GetBlockedRequestCount returns the number of requests on page aborted by block rules
+// since its last SetupNetworkInterception.
+func (pi *PlaywrightIntegration) GetBlockedRequestCount(page playwright.Page) int {
+	pi.captureMu.Lock()
+	defer pi.captureMu.Unlock()
+	return pi.recordedCapture(page).blockedRequests
 }
@@ -0,0 +1,255 @@
+package playwright_integration
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Camelket/mcp-browser-tools/internal/safety"
+	"github.com/playwright-community/playwright-go"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakePage is a distinct playwright.Page; only its identity matters.
+type fakePage struct {
+	playwright.Page
+	id int
+}
+
+// capturedOn returns an integration that captured data on page.
+func capturedOn(page playwright.Page, data []CapturedNetworkActivity) *PlaywrightIntegration {
+	return &PlaywrightIntegration{captures: map[playwright.Page]*pageCapture{page: {networkData: data}}}
+}
+
+func TestFilterNetworkActivity(t *testing.T) {
+	page := &fakePage{id: 1}
+	pi := capturedOn(page, []CapturedNetworkActivity{
+		{
+			Request:  CapturedRequest{URL: "https://example.com/", ResourceType: "document"},
+			Response: CapturedResponse{Status: 200, Headers: map[string]string{"content-type": "text/html"}},
+		},
+		{
+			Request:  CapturedRequest{URL: "https://example.com/api", ResourceType: "fetch"},
+			Response: CapturedResponse{Status: 500, Headers: map[string]string{"Content-Type": "application/json; charset=utf-8"}},
+		},
+		{
+			Request:  CapturedRequest{URL: "https://example.com/logo.png", ResourceType: "image"},
+			Response: CapturedResponse{Status: 404, Headers: map[string]string{"content-type": "image/png"}},
+		},
+	})
+
+	urls := func(entries []CapturedNetworkActivity) []string {
+		var result []string
+		for _, e := range entries {
+			result = append(result, e.Request.URL)
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"https://example.com/api", "https://example.com/logo.png"}, urls(pi.FilterByStatusRange(page, 400, 0)))
+	assert.Equal(t, []string{"https://example.com/"}, urls(pi.FilterByStatusRange(page, 0, 399)))
+	assert.Equal(t, []string{"https://example.com/logo.png"}, urls(pi.FilterByStatus(page, 404)))
+	assert.Equal(t, []string{"https://example.com/api"}, urls(pi.FilterByContentType(page, "json")))
+	assert.Equal(t, []string{"https://example.com/logo.png"}, urls(pi.FilterByResourceType(page, "image")))
+	assert.Empty(t, pi.FilterNetworkActivity(page, NetworkActivityFilter{StatusMin: 400, ContentType: "html"}))
+	assert.Empty(t, pi.FilterByStatus(&fakePage{id: 2}, 404), "each page has its own capture")
+}
+
+func TestSetMockEndpoints(t *testing.T) {
+	pi := &PlaywrightIntegration{}
+	assert.NoError(t, pi.SetMockEndpoints([]MockEndpoint{{URLPattern: "**/api/*", Status: 204}}))
+	assert.True(t, pi.HasMockEndpoints())
+	assert.True(t, pi.mocks[0].pattern.MatchString("https://example.com/api/users"))
+
+	assert.Error(t, pi.SetMockEndpoints([]MockEndpoint{{Status: 200}}))
+	assert.True(t, pi.HasMockEndpoints(), "a failed call must keep the previous mocks")
+
+	assert.NoError(t, pi.SetMockEndpoints(nil))
+	assert.False(t, pi.HasMockEndpoints())
+}
+
+func TestGetCapturedNetworkDataFillsProtocols(t *testing.T) {
+	page := &fakePage{id: 1}
+	pi := capturedOn(page, []CapturedNetworkActivity{
+		{Request: CapturedRequest{URL: "https://example.com/"}},
+		{Request: CapturedRequest{URL: "https://cdn.example.com/app.js"}, Response: CapturedResponse{Protocol: "http/1.1"}},
+		{Request: CapturedRequest{URL: "https://example.com/unknown.css"}},
+	})
+	pi.captures[page].protocols = map[string]string{
+		"https://example.com/":           "h2",
+		"https://cdn.example.com/app.js": "h3",
+	}
+
+	data := pi.GetCapturedNetworkData(page)
+	assert.Equal(t, "h2", data[0].Response.Protocol)
+	assert.Equal(t, "http/1.1", data[1].Response.Protocol, "a protocol already captured is kept")
+	assert.Empty(t, data[2].Response.Protocol)
+	assert.Equal(t, "h2", pi.DocumentProtocol(page, "https://example.com/"))
+	assert.Empty(t, pi.DocumentProtocol(&fakePage{id: 2}, "https://example.com/"), "each page has its own protocols")
+}
+
+func TestCapturedNetworkActivityJSON(t *testing.T) {
+	started := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	activity := CapturedNetworkActivity{
+		Request: CapturedRequest{
+			URL:          "https://example.com/app.js",
+			Method:       "GET",
+			ResourceType: "script",
+			Headers:      map[string]string{"accept": "*/*"},
+			StartedAt:    started,
+		},
+		Response: CapturedResponse{
+			Status:       200,
+			Headers:      map[string]string{"content-type": "text/javascript"},
+			Body:         "run()",
+			BodySize:     5,
+			TransferSize: 180,
+		},
+	}
+	activity.Response.finish(activity.Request, started.Add(1500*time.Microsecond))
+
+	data, err := json.Marshal(activity)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"request": {
+			"url": "https://example.com/app.js",
+			"method": "GET",
+			"resource_type": "script",
+			"headers": {"accept": "*/*"},
+			"started_at": "2024-05-01T12:00:00Z"
+		},
+		"response": {
+			"status": 200,
+			"headers": {"content-type": "text/javascript"},
+			"body": "run()",
+			"body_size": 5,
+			"ended_at": "2024-05-01T12:00:00.0015Z",
+			"duration_ms": 1.5,
+			"transfer_size": 180
+		}
+	}`, string(data))
+
+	// Unset times and sizes are left out, e.g. for failed requests.
+	data, err = json.Marshal(CapturedNetworkActivity{
+		Request: CapturedRequest{URL: "https://example.com/", Method: "GET", ResourceType: "document"},
+		Failed:  true,
+		Failure: "net::ERR_CONNECTION_REFUSED",
+	})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"request": {"url": "https://example.com/", "method": "GET", "resource_type": "document", "headers": null},
+		"response": {"status": 0, "headers": null},
+		"failed": true,
+		"failure": "net::ERR_CONNECTION_REFUSED"
+	}`, string(data))
+}
+
+func TestCapturedResponseFinishWithoutStart(t *testing.T) {
+	var response CapturedResponse
+	now := time.Now()
+	response.finish(CapturedRequest{}, now)
+	assert.Equal(t, now, response.EndedAt)
+	assert.Zero(t, response.DurationMs, "the duration is unknown without a start time")
+}
+
+func TestDocumentSource(t *testing.T) {
+	html := map[string]string{"content-type": "text/html; charset=utf-8"}
+	page := &fakePage{id: 1}
+	pi := capturedOn(page, []CapturedNetworkActivity{
+		{
+			Request:  CapturedRequest{URL: "https://example.com/", ResourceType: "document"},
+			Response: CapturedResponse{Status: 200, Headers: html, Body: `<div id="app"></div>`},
+		},
+		{
+			Request:  CapturedRequest{URL: "https://example.com/app.js", ResourceType: "script"},
+			Response: CapturedResponse{Status: 200, Headers: map[string]string{"content-type": "text/javascript"}, Body: "render()"},
+		},
+		{
+			Request:  CapturedRequest{URL: "https://example.com/big", ResourceType: "document"},
+			Response: CapturedResponse{Status: 200, Headers: html, Body: "<html>", BodySize: 1 << 20, BodyTruncated: true},
+		},
+		{
+			Request:  CapturedRequest{URL: "https://example.com/report.pdf", ResourceType: "document"},
+			Response: CapturedResponse{Status: 200, Headers: map[string]string{"content-type": "application/pdf"}, Body: "[binary 10 bytes]"},
+		},
+	})
+
+	source, err := pi.DocumentSource(page, "https://example.com/")
+	assert.NoError(t, err)
+	assert.Equal(t, `<div id="app"></div>`, source)
+
+	_, err = pi.DocumentSource(page, "https://example.com/app.js")
+	assert.ErrorContains(t, err, "no document response")
+	_, err = pi.DocumentSource(page, "https://example.com/big")
+	assert.ErrorContains(t, err, "capture limits")
+	_, err = pi.DocumentSource(page, "https://example.com/report.pdf")
+	assert.ErrorContains(t, err, "not text")
+}
+
+// fakeRequest is a distinct playwright.Request; only its identity matters.
+type fakeRequest struct {
+	playwright.Request
+	id int
+}
+
+func TestTakePendingRequestMatchesByIdentity(t *testing.T) {
+	pi := &PlaywrightIntegration{}
+	first, second := &fakeRequest{id: 1}, &fakeRequest{id: 2}
+	state := &pageCapture{}
+	state.pending = []pendingRequest{
+		{request: first, captured: CapturedRequest{URL: "https://example.com/poll", Method: "GET"}},
+		{request: second, captured: CapturedRequest{URL: "https://example.com/poll", Method: "POST"}},
+	}
+
+	captured, ok := pi.takePendingRequest(state, second)
+	assert.True(t, ok)
+	assert.Equal(t, "POST", captured.Method)
+
+	captured, ok = pi.takePendingRequest(state, first)
+	assert.True(t, ok)
+	assert.Equal(t, "GET", captured.Method)
+
+	_, ok = pi.takePendingRequest(state, first)
+	assert.False(t, ok, "a request is only matched once")
+	assert.Empty(t, state.pending)
+}
+
+func TestTakePolicyViolationIsPerPage(t *testing.T) {
+	first, second := &fakePage{id: 1}, &fakePage{id: 2}
+	blocked := &safety.BlockedError{URL: "http://127.0.0.1/", Reason: "private address"}
+	pi := &PlaywrightIntegration{captures: map[playwright.Page]*pageCapture{first: {policyViolation: blocked}}}
+
+	assert.Nil(t, pi.takePolicyViolation(second), "another page's violation is not reported")
+	assert.Same(t, blocked, pi.takePolicyViolation(first))
+	assert.Nil(t, pi.takePolicyViolation(first), "a violation is only reported once")
+}
+
+func TestNavigationOptionsBudget(t *testing.T) {
+	assert.Zero(t, (&NavigationOptions{}).Budget())
+	assert.Equal(t, 10*time.Second, (&NavigationOptions{Timeout: 10 * time.Second}).Budget())
+	// Three attempts, backoff of 1s and 2s, then the settle delay.
+	assert.Equal(t, 33500*time.Millisecond, (&NavigationOptions{
+		Timeout:     10 * time.Second,
+		MaxRetries:  2,
+		RetryDelay:  time.Second,
+		SettleDelay: 500 * time.Millisecond,
+	}).Budget())
+}
+
+func TestRemainingTimeout(t *testing.T) {
+	_, ok := remainingTimeout(context.Background(), 0)
+	assert.False(t, ok)
+
+	timeout, ok := remainingTimeout(context.Background(), 5*time.Second)
+	assert.True(t, ok)
+	assert.Equal(t, 5*time.Second, timeout)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	timeout, ok = remainingTimeout(ctx, 5*time.Second)
+	assert.True(t, ok)
+	assert.LessOrEqual(t, timeout, time.Second, "the context deadline wins when it is sooner")
+	timeout, _ = remainingTimeout(ctx, 0)
+	assert.LessOrEqual(t, timeout, time.Second)
+}
@@ -0,0 +1,83 @@
+package playwright_integration
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Camelket/mcp-browser-tools/internal/safety"
+	"github.com/playwright-community/playwright-go"
+)
+
+// SetURLPolicy makes every page created afterwards check the URLs it requests, including
+// subresources and the redirects of navigations, against policy. Nil disables the checks.
+func (pi *PlaywrightIntegration) SetURLPolicy(policy *safety.Policy) {
+	pi.urlPolicy = policy
+}
+
+// URLPolicy returns the policy set with SetURLPolicy, or nil.
+func (pi *PlaywrightIntegration) URLPolicy() *safety.Policy {
+	return pi.urlPolicy
+}
+
+// checkURL returns a *safety.BlockedError if the URL policy rejects url.
+func (pi *PlaywrightIntegration) checkURL(ctx context.Context, url string) error {
+	if pi.urlPolicy == nil {
+		return nil
+	}
+	return pi.urlPolicy.CheckURL(ctx, url)
+}
+
+// continueRoute sends a request routed on page on to the network with the given extra headers.
+// Under a URL policy the request is checked first. Navigation requests are then fetched without
+// following redirects, so each redirect target comes back through the route handler and is
+// checked as well; Playwright does not call route handlers for redirects followed by the browser
+// itself. Other requests are continued as they are, so streaming responses keep working.
+func (pi *PlaywrightIntegration) continueRoute(page playwright.Page, route playwright.Route, headers map[string]string) {
+	request := route.Request()
+	if pi.urlPolicy == nil {
+		route.Continue(playwright.RouteContinueOptions{Headers: headers})
+		return
+	}
+
+	if err := pi.checkURL(context.Background(), request.URL()); err != nil {
+		pi.logger.Warn("Blocked request by URL policy", "url", request.URL(), "error", err)
+		var blocked *safety.BlockedError
+		if errors.As(err, &blocked) && request.IsNavigationRequest() {
+			pi.captureMu.Lock()
+			pi.captureState(page).policyViolation = blocked
+			pi.captureMu.Unlock()
+		}
+		if err := route.Abort("blockedbyclient"); err != nil {
+			pi.logger.Warn("Failed to abort blocked request", "url", request.URL(), "error", err)
+		}
+		return
+	}
+	if !request.IsNavigationRequest() {
+		route.Continue(playwright.RouteContinueOptions{Headers: headers})
+		return
+	}
+
+	response, err := route.Fetch(playwright.RouteFetchOptions{
+		Headers:      headers,
+		MaxRedirects: playwright.Int(0),
+	})
+	if err != nil {
+		pi.logger.Debug("Failed to fetch routed request", "url", request.URL(), "error", err)
+		route.Abort("failed")
+		return
+	}
+	if err := route.Fulfill(playwright.RouteFulfillOptions{Response: response}); err != nil {
+		pi.logger.Warn("Failed to fulfill routed request", "url", request.URL(), "error", err)
+	}
+}
+
+// takePolicyViolation returns and clears the last navigation request of page blocked by the
+// URL policy, or nil if there was none.
+func (pi *PlaywrightIntegration) takePolicyViolation(page playwright.Page) *safety.BlockedError {
+	pi.captureMu.Lock()
+	defer pi.captureMu.Unlock()
+	state := pi.recordedCapture(page)
+	violation := state.policyViolation
+	state.policyViolation = nil
+	return violation
+}
@@ -0,0 +1,24 @@
+package playwright_integration
+
+// ProgressFunc receives progress reports of a long-running operation: the fraction of the work
+// done, from 0 to 1, and a short description of the stage just reached. A nil ProgressFunc
+// discards reports.
+type ProgressFunc func(done float64, message string)
+
+// Report calls f if it is set.
+func (f ProgressFunc) Report(done float64, message string) {
+	if f != nil {
+		f(done, message)
+	}
+}
+
+// Scale returns a ProgressFunc that maps the progress of a sub-operation onto the range
+// [from, to] of f, e.g. so navigation reports cover 10% to 50% of a page summary.
+func (f ProgressFunc) Scale(from, to float64) ProgressFunc {
+	if f == nil {
+		return nil
+	}
+	return func(done float64, message string) {
+		f(from+done*(to-from), message)
+	}
+}
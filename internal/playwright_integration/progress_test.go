@@ -0,0 +1,22 @@
+package playwright_integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgressFuncScale(t *testing.T) {
+	var reports []float64
+	f := ProgressFunc(func(done float64, message string) { reports = append(reports, done) })
+
+	scaled := f.Scale(0.2, 0.6)
+	scaled.Report(0, "start")
+	scaled.Report(0.5, "half")
+	scaled.Report(1, "done")
+	assert.InDeltaSlice(t, []float64{0.2, 0.4, 0.6}, reports, 1e-9)
+
+	var none ProgressFunc
+	assert.Nil(t, none.Scale(0, 1))
+	assert.NotPanics(t, func() { none.Report(1, "ignored") })
+}
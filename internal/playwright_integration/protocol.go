@@ -0,0 +1,55 @@
+package playwright_integration
+
+import (
+	"github.com/playwright-community/playwright-go"
+)
+
+// watchProtocols records the network protocol ("h2", "http/1.1", "h3", ...) of every response
+// the page receives. Playwright does not expose the protocol, so it is read from the
+// Network.responseReceived events of a Chrome DevTools Protocol session. On other browsers
+// the protocols are simply left empty. They are recorded into state, the page's capture.
+func (pi *PlaywrightIntegration) watchProtocols(page playwright.Page, state *pageCapture) {
+	session, err := page.Context().NewCDPSession(page)
+	if err != nil {
+		pi.logger.Debug("Protocol detection unavailable", "error", err)
+		return
+	}
+	session.On("Network.responseReceived", func(params map[string]interface{}) {
+		response, ok := params["response"].(map[string]interface{})
+		if !ok {
+			return
+		}
+		url, _ := response["url"].(string)
+		protocol, _ := response["protocol"].(string)
+		if url == "" || protocol == "" {
+			return
+		}
+		pi.captureMu.Lock()
+		defer pi.captureMu.Unlock()
+		if state.protocols == nil {
+			state.protocols = make(map[string]string)
+		}
+		state.protocols[url] = protocol
+	})
+	if _, err := session.Send("Network.enable", nil); err != nil {
+		pi.logger.Debug("Protocol detection unavailable", "error", err)
+	}
+}
+
+// DocumentProtocol returns the network protocol the document at url was loaded over on page,
+// or "" if it is unknown. It is only available for pages set up with network interception.
+func (pi *PlaywrightIntegration) DocumentProtocol(page playwright.Page, url string) string {
+	pi.captureMu.Lock()
+	defer pi.captureMu.Unlock()
+	return pi.recordedCapture(page).protocols[url]
+}
+
+// fillProtocols sets the protocol of captured responses from the CDP events recorded so far,
+// which may arrive after the Playwright response event. captureMu must be held.
+func (state *pageCapture) fillProtocols() {
+	for i := range state.networkData {
+		if state.networkData[i].Response.Protocol == "" {
+			state.networkData[i].Response.Protocol = state.protocols[state.networkData[i].Request.URL]
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package playwright_integration
+
+import (
+	"context"
+	"math"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// originRateLimiter is a token-bucket rate limiter keyed by URL origin (scheme and host).
+type originRateLimiter struct {
+	mu      sync.Mutex
+	rate    float64 // Tokens added per second
+	burst   float64 // Bucket capacity
+	buckets map[string]*tokenBucket
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// newOriginRateLimiter returns a limiter allowing requestsPerSecond navigations per origin,
+// with bursts of up to one second's worth of requests. It returns nil if requestsPerSecond
+// is not positive, which disables rate limiting.
+func newOriginRateLimiter(requestsPerSecond float64) *originRateLimiter {
+	if requestsPerSecond <= 0 {
+		return nil
+	}
+	return &originRateLimiter{
+		rate:    requestsPerSecond,
+		burst:   math.Max(1, math.Floor(requestsPerSecond)),
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+// wait blocks until a navigation to rawURL is allowed or ctx is done. A nil limiter never blocks.
+func (l *originRateLimiter) wait(ctx context.Context, rawURL string) error {
+	if l == nil {
+		return nil
+	}
+	origin := urlOrigin(rawURL)
+
+	for {
+		delay := l.reserve(origin, time.Now())
+		if delay <= 0 {
+			return nil
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// reserve takes a token for origin if one is available and returns zero, or returns how long
+// to wait until the next token is added.
+func (l *originRateLimiter) reserve(origin string, now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.buckets[origin]
+	if !ok {
+		b = &tokenBucket{tokens: l.burst, last: now}
+		l.buckets[origin] = b
+	}
+	b.tokens = math.Min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+}
+
+// urlOrigin returns the scheme and host of rawURL, or rawURL itself if it cannot be parsed.
+func urlOrigin(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return rawURL
+	}
+	return u.Scheme + "://" + u.Host
+}
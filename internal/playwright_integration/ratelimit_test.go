@@ -0,0 +1,35 @@
+package playwright_integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOriginRateLimiter_Reserve(t *testing.T) {
+	l := newOriginRateLimiter(2)
+	now := time.Now()
+
+	// A burst of two is allowed, the third navigation waits half a second.
+	assert.Zero(t, l.reserve("https://a.example", now))
+	assert.Zero(t, l.reserve("https://a.example", now))
+	assert.Equal(t, 500*time.Millisecond, l.reserve("https://a.example", now))
+
+	// Other origins have their own bucket, and tokens refill over time.
+	assert.Zero(t, l.reserve("https://b.example", now))
+	assert.Zero(t, l.reserve("https://a.example", now.Add(time.Second)))
+}
+
+func TestOriginRateLimiter_WaitRespectsContext(t *testing.T) {
+	l := newOriginRateLimiter(0.1)
+	assert.NoError(t, l.wait(context.Background(), "https://example.com/a"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, l.wait(ctx, "https://example.com/b"), context.DeadlineExceeded)
+
+	var disabled *originRateLimiter
+	assert.NoError(t, disabled.wait(context.Background(), "https://example.com"))
+}
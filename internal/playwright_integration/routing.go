@@ -0,0 +1,32 @@
+package playwright_integration
+
+// routeAction is what the interception route does with a request.
+type routeAction int
+
+const (
+	routeContinue routeAction = iota // Send the request, with injected headers and the URL policy applied
+	routeMock                        // Fulfill the request with a mock endpoint's canned response
+	routeBlock                       // Abort the request because it matches a block rule
+)
+
+// requestRouter decides the fate of every request of a page. SetupNetworkInterception installs
+// it as the page's only route callback, so mocks, block rules, header injection and the URL
+// policy never race to handle the same request ("Route is already handled").
+type requestRouter struct {
+	mocks   []compiledMock
+	blocker *resourceBlocker
+}
+
+// decide returns the action for a request and, for routeMock, the endpoint to serve. Mocks take
+// precedence over block rules, so a mocked image is served even while images are blocked.
+func (r *requestRouter) decide(requestURL, resourceType string) (routeAction, *MockEndpoint) {
+	for i := range r.mocks {
+		if r.mocks[i].pattern.MatchString(requestURL) {
+			return routeMock, &r.mocks[i].endpoint
+		}
+	}
+	if r.blocker.shouldBlock(requestURL, resourceType) {
+		return routeBlock, nil
+	}
+	return routeContinue, nil
+}
@@ -0,0 +1,31 @@
+package playwright_integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestRouterDecide(t *testing.T) {
+	mocks, err := compileMocks([]MockEndpoint{{URLPattern: "**/hero.png", Status: 200}})
+	assert.NoError(t, err)
+	blocker, err := newResourceBlocker([]string{"image"})
+	assert.NoError(t, err)
+	router := &requestRouter{mocks: mocks, blocker: blocker}
+
+	action, mock := router.decide("https://example.com/hero.png", "image")
+	assert.Equal(t, routeMock, action, "mocks take precedence over block rules")
+	if assert.NotNil(t, mock) {
+		assert.Equal(t, 200, mock.Status)
+	}
+
+	action, mock = router.decide("https://example.com/logo.png", "image")
+	assert.Equal(t, routeBlock, action)
+	assert.Nil(t, mock)
+
+	action, _ = router.decide("https://example.com/api/data", "xhr")
+	assert.Equal(t, routeContinue, action)
+
+	action, _ = (&requestRouter{}).decide("https://example.com/logo.png", "image")
+	assert.Equal(t, routeContinue, action, "an empty router continues everything")
+}
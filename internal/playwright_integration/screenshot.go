@@ -0,0 +1,232 @@
+package playwright_integration
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// Screenshot formats accepted by PageScreenshotOptions.Format.
+const (
+	ScreenshotFormatPNG  = "png"
+	ScreenshotFormatJPEG = "jpeg"
+)
+
+// DefaultMaxScreenshotHeight is the default PageScreenshotOptions.MaxHeight. Chromium cannot
+// render a single capture taller than 16384 device pixels.
+const DefaultMaxScreenshotHeight = 16384
+
+// MaxScreenshotSegments bounds the number of images CaptureScreenshotSegments takes of one page.
+const MaxScreenshotSegments = 20
+
+// ScreenshotTooTallError is returned by CaptureScreenshot for a full-page screenshot of a page
+// taller than PageScreenshotOptions.MaxHeight.
+type ScreenshotTooTallError struct {
+	Width     int // Page size in CSS pixels
+	Height    int
+	MaxHeight int
+}
+
+func (e *ScreenshotTooTallError) Error() string {
+	return fmt.Sprintf("page is %dx%d pixels, taller than the %d pixel limit for a full-page screenshot; capture it in segments with max_height, take a viewport screenshot with full_page=false, or select a region with clip",
+		e.Width, e.Height, e.MaxHeight)
+}
+
+// ScreenshotSegment is one horizontal band of a full-page screenshot.
+type ScreenshotSegment struct {
+	Y      int    // Offset of the band from the top of the page in CSS pixels
+	Height int    // Height of the band in CSS pixels
+	Data   []byte // Encoded image
+}
+
+// defaultJPEGQuality is used when re-encoding a downscaled JPEG without an explicit quality.
+const defaultJPEGQuality = 80
+
+// MIMEType returns the media type of screenshots taken with these options.
+func (o PageScreenshotOptions) MIMEType() string {
+	if o.Format == ScreenshotFormatJPEG {
+		return "image/jpeg"
+	}
+	return "image/png"
+}
+
+// Thumbnail shrinks a screenshot taken with these options to at most maxWidth pixels wide,
+// keeping its format, for use as a small preview.
+func (o PageScreenshotOptions) Thumbnail(data []byte, maxWidth int) ([]byte, error) {
+	return downscale(data, maxWidth, o)
+}
+
+// Validate checks the format, quality and size options.
+func (o PageScreenshotOptions) Validate() error {
+	switch o.Format {
+	case "", ScreenshotFormatPNG:
+		if o.Quality != 0 {
+			return fmt.Errorf("quality is only supported for jpeg screenshots")
+		}
+	case ScreenshotFormatJPEG:
+		if o.Quality < 0 || o.Quality > 100 {
+			return fmt.Errorf("quality must be between 0 and 100, got %d", o.Quality)
+		}
+	default:
+		return fmt.Errorf("unsupported screenshot format %q: expected png or jpeg", o.Format)
+	}
+	if o.MaxWidth < 0 {
+		return fmt.Errorf("max width must not be negative, got %d", o.MaxWidth)
+	}
+	if o.MaxHeight < 0 {
+		return fmt.Errorf("max height must not be negative, got %d", o.MaxHeight)
+	}
+	return nil
+}
+
+// maxHeight returns MaxHeight, or DefaultMaxScreenshotHeight if it is not set.
+func (o PageScreenshotOptions) maxHeight() int {
+	if o.MaxHeight > 0 {
+		return o.MaxHeight
+	}
+	return DefaultMaxScreenshotHeight
+}
+
+// apply sets the format and quality on Playwright's screenshot options.
+func (o PageScreenshotOptions) apply(options *playwright.PageScreenshotOptions) {
+	if o.Format == ScreenshotFormatJPEG {
+		options.Type = playwright.ScreenshotTypeJpeg
+		if o.Quality > 0 {
+			options.Quality = playwright.Int(o.Quality)
+		}
+	}
+}
+
+// reflowScript resolves after two animation frames, by which time the layout and paint
+// triggered by a viewport change have happened.
+const reflowScript = `() => new Promise(resolve => requestAnimationFrame(() => requestAnimationFrame(resolve)))`
+
+// ResizeViewport changes the viewport of an open page and waits for the page to reflow, so a
+// screenshot taken afterwards shows the layout for the new size.
+func (pi *PlaywrightIntegration) ResizeViewport(ctx context.Context, page playwright.Page, width, height int) error {
+	if page == nil {
+		return fmt.Errorf("playwright.Page cannot be nil")
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := page.SetViewportSize(width, height); err != nil {
+		return fmt.Errorf("failed to resize viewport to %dx%d: %w", width, height, err)
+	}
+	if _, err := page.Evaluate(reflowScript); err != nil {
+		return fmt.Errorf("failed to wait for reflow at %dx%d: %w", width, height, err)
+	}
+	return nil
+}
+
+// downscale shrinks an encoded screenshot to at most maxWidth pixels wide, keeping the aspect
+// ratio and the encoding format. Images that are already narrow enough are returned unchanged.
+func downscale(data []byte, maxWidth int, options PageScreenshotOptions) ([]byte, error) {
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode screenshot: %w", err)
+	}
+	bounds := src.Bounds()
+	if bounds.Dx() <= maxWidth {
+		return data, nil
+	}
+
+	height := max(1, bounds.Dy()*maxWidth/bounds.Dx())
+	dst := resizeBox(src, maxWidth, height)
+
+	var buf bytes.Buffer
+	if options.Format == ScreenshotFormatJPEG {
+		quality := options.Quality
+		if quality == 0 {
+			quality = defaultJPEGQuality
+		}
+		err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: quality})
+	} else {
+		err = png.Encode(&buf, dst)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode downscaled screenshot: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// resizeBox downscales src to width x height by averaging the source pixels covered by each
+// destination pixel, which avoids the aliasing of nearest-neighbour sampling.
+func resizeBox(src image.Image, width, height int) *image.RGBA {
+	bounds := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0 := bounds.Min.Y + y*bounds.Dy()/height
+		y1 := max(y0+1, bounds.Min.Y+(y+1)*bounds.Dy()/height)
+		for x := 0; x < width; x++ {
+			x0 := bounds.Min.X + x*bounds.Dx()/width
+			x1 := max(x0+1, bounds.Min.X+(x+1)*bounds.Dx()/width)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(cr), g+uint64(cg), b+uint64(cb), a+uint64(ca)
+					n++
+				}
+			}
+			dst.Set(x, y, color.RGBA64{
+				R: uint16(r / n),
+				G: uint16(g / n),
+				B: uint16(b / n),
+				A: uint16(a / n),
+			})
+		}
+	}
+	return dst
+}
+
+// CaptureScreenshotSegments takes a full-page screenshot as a series of bands, each at most
+// options.MaxHeight pixels tall, so pages too tall for a single image can still be captured.
+// A page that fits yields a single segment. options.FullPage and options.Clip are ignored.
+func (pi *PlaywrightIntegration) CaptureScreenshotSegments(ctx context.Context, page playwright.Page, options PageScreenshotOptions) ([]ScreenshotSegment, error) {
+	if page == nil {
+		return nil, fmt.Errorf("playwright.Page cannot be nil")
+	}
+	if err := options.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid screenshot options: %w", err)
+	}
+	width, height, err := pi.pageSize(ctx, page)
+	if err != nil {
+		return nil, err
+	}
+	offsets := segmentOffsets(int(height), options.maxHeight())
+	if len(offsets) > MaxScreenshotSegments {
+		return nil, fmt.Errorf("page is %d pixels tall and would need %d segments of at most %d pixels; the limit is %d, so raise max_height or capture a region with clip",
+			int(height), len(offsets), options.maxHeight(), MaxScreenshotSegments)
+	}
+
+	segments := make([]ScreenshotSegment, 0, len(offsets))
+	for _, y := range offsets {
+		band := min(options.maxHeight(), int(height)-y)
+		segmentOptions := options
+		segmentOptions.FullPage = true
+		segmentOptions.Clip = &Rect{X: 0, Y: float64(y), Width: width, Height: float64(band)}
+		data, err := pi.CaptureScreenshot(ctx, page, segmentOptions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to capture segment at y=%d: %w", y, err)
+		}
+		segments = append(segments, ScreenshotSegment{Y: y, Height: band, Data: data})
+	}
+	return segments, nil
+}
+
+// segmentOffsets returns the top offsets of the bands a page of the given height is split into.
+func segmentOffsets(height, maxHeight int) []int {
+	offsets := []int{0}
+	for y := maxHeight; y < height; y += maxHeight {
+		offsets = append(offsets, y)
+	}
+	return offsets
+}
@@ -0,0 +1,62 @@
+package playwright_integration
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPageScreenshotOptions_Validate(t *testing.T) {
+	assert.NoError(t, PageScreenshotOptions{Format: "jpeg", Quality: 70}.Validate())
+	assert.ErrorContains(t, PageScreenshotOptions{Quality: 70}.Validate(), "only supported for jpeg")
+	assert.Error(t, PageScreenshotOptions{Format: "jpeg", Quality: 101}.Validate())
+	assert.Error(t, PageScreenshotOptions{Format: "webp"}.Validate())
+}
+
+func TestPageScreenshotOptions_MIMEType(t *testing.T) {
+	assert.Equal(t, "image/png", PageScreenshotOptions{}.MIMEType())
+	assert.Equal(t, "image/png", PageScreenshotOptions{Format: "png"}.MIMEType())
+	assert.Equal(t, "image/jpeg", PageScreenshotOptions{Format: "jpeg"}.MIMEType())
+}
+
+func TestDownscale(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 1600, 900))
+	for y := 0; y < 900; y++ {
+		for x := 0; x < 1600; x++ {
+			src.Set(x, y, color.RGBA{R: 200, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, src))
+
+	data, err := downscale(buf.Bytes(), 800, PageScreenshotOptions{})
+	assert.NoError(t, err)
+
+	img, format, err := image.Decode(bytes.NewReader(data))
+	assert.NoError(t, err)
+	assert.Equal(t, "png", format)
+	assert.Equal(t, image.Rect(0, 0, 800, 450), img.Bounds())
+	r, _, _, _ := img.At(400, 200).RGBA()
+	assert.Equal(t, uint32(200), r>>8)
+
+	// Narrow images are left untouched.
+	unchanged, err := downscale(buf.Bytes(), 2000, PageScreenshotOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, buf.Bytes(), unchanged)
+}
+
+func TestSegmentOffsets(t *testing.T) {
+	assert.Equal(t, []int{0}, segmentOffsets(720, DefaultMaxScreenshotHeight))
+	assert.Equal(t, []int{0}, segmentOffsets(16384, 16384))
+	assert.Equal(t, []int{0, 16384, 32768, 49152}, segmentOffsets(50000, 16384))
+}
+
+func TestScreenshotTooTallError(t *testing.T) {
+	err := &ScreenshotTooTallError{Width: 1280, Height: 50000, MaxHeight: DefaultMaxScreenshotHeight}
+	assert.Contains(t, err.Error(), "1280x50000")
+	assert.Contains(t, err.Error(), "max_height")
+}
@@ -0,0 +1,88 @@
+package playwright_integration
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// maxTextMatches caps the matches returned by SearchText; TotalMatches still counts all of them.
+const maxTextMatches = 100
+
+// TextMatch is a single occurrence of a search query in the page's text.
+type TextMatch struct {
+	Text        string `json:"text"` // The matched text as it appears on the page
+	ParentTag   string `json:"parent_tag"`
+	ParentID    string `json:"parent_id"`
+	ParentClass string `json:"parent_class"`
+	Context     string `json:"context"` // About 100 characters of text surrounding the match
+}
+
+// TextSearchResult holds the matches found by SearchText.
+type TextSearchResult struct {
+	Matches      []TextMatch `json:"matches"`
+	TotalMatches int         `json:"total_matches"`
+}
+
+// searchTextScript walks all text nodes outside <script>, <style> and <noscript> and records
+// each occurrence of the query together with its parent element and surrounding text.
+const searchTextScript = `({ query, caseSensitive, maxMatches, contextChars }) => {
+	const needle = caseSensitive ? query : query.toLowerCase();
+	const skip = new Set(['SCRIPT', 'STYLE', 'NOSCRIPT', 'TEMPLATE']);
+	const walker = document.createTreeWalker(document.body || document.documentElement, NodeFilter.SHOW_TEXT, {
+		acceptNode: (node) => node.parentElement && !skip.has(node.parentElement.tagName) ? NodeFilter.FILTER_ACCEPT : NodeFilter.FILTER_REJECT,
+	});
+	const matches = [];
+	let total = 0;
+	while (walker.nextNode()) {
+		const text = walker.currentNode.nodeValue;
+		const haystack = caseSensitive ? text : text.toLowerCase();
+		let index = haystack.indexOf(needle);
+		while (index !== -1) {
+			total++;
+			if (matches.length < maxMatches) {
+				const parent = walker.currentNode.parentElement;
+				const half = Math.floor((contextChars - needle.length) / 2);
+				const start = Math.max(0, index - Math.max(half, 0));
+				matches.push({
+					text: text.substr(index, needle.length),
+					parent_tag: parent.tagName.toLowerCase(),
+					parent_id: parent.id || '',
+					parent_class: typeof parent.className === 'string' ? parent.className : '',
+					context: text.substr(start, Math.max(contextChars, needle.length)).replace(/\s+/g, ' ').trim(),
+				});
+			}
+			index = haystack.indexOf(needle, index + needle.length);
+		}
+	}
+	return { matches, total_matches: total };
+}`
+
+// SearchText finds all occurrences of query in the visible text of the page.
+// At most maxTextMatches matches are returned; TotalMatches reports the full count.
+func (pi *PlaywrightIntegration) SearchText(ctx context.Context, page playwright.Page, query string, caseSensitive bool) (*TextSearchResult, error) {
+	if query == "" {
+		return nil, fmt.Errorf("search query cannot be empty")
+	}
+
+	result, err := pi.ExecuteScript(ctx, page, searchTextScript, map[string]interface{}{
+		"query":         query,
+		"caseSensitive": caseSensitive,
+		"maxMatches":    maxTextMatches,
+		"contextChars":  100,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search page text: %w", err)
+	}
+
+	search := &TextSearchResult{}
+	if err := decodeScriptResult(result, search); err != nil {
+		return nil, fmt.Errorf("failed to decode text matches: %w", err)
+	}
+	if search.Matches == nil {
+		search.Matches = []TextMatch{}
+	}
+	pi.logger.Debug("Searched page text", "query", query, "total_matches", search.TotalMatches)
+	return search, nil
+}
@@ -0,0 +1,64 @@
+package playwright_integration
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// DocumentStatus describes the response to the main document of a navigation.
+type DocumentStatus struct {
+	StatusCode int    `json:"status_code"` // Zero if the navigation produced no response
+	StatusText string `json:"status_text"`
+	FinalURL   string `json:"final_url"` // URL of the page after redirects
+	// Certificate describes the TLS certificate of an HTTPS main document.
+	Certificate *CertificateDetails `json:"certificate,omitempty"`
+}
+
+// CertificateDetails is the subset of a TLS certificate reported by the browser.
+type CertificateDetails struct {
+	Subject   string    `json:"subject,omitempty"`
+	Issuer    string    `json:"issuer,omitempty"`
+	Protocol  string    `json:"protocol,omitempty"` // e.g. "TLS 1.3"
+	ValidFrom time.Time `json:"valid_from,omitzero"`
+	ValidTo   time.Time `json:"valid_to,omitzero"`
+}
+
+// certificateDetails returns the certificate of response, or nil for plain HTTP responses
+// and browsers that do not report security details.
+func certificateDetails(response playwright.Response) *CertificateDetails {
+	details, err := response.SecurityDetails()
+	if err != nil || details == nil {
+		return nil
+	}
+	cert := &CertificateDetails{}
+	if details.SubjectName != nil {
+		cert.Subject = *details.SubjectName
+	}
+	if details.Issuer != nil {
+		cert.Issuer = *details.Issuer
+	}
+	if details.Protocol != nil {
+		cert.Protocol = *details.Protocol
+	}
+	if details.ValidFrom != nil {
+		cert.ValidFrom = time.Unix(int64(*details.ValidFrom), 0).UTC()
+	}
+	if details.ValidTo != nil {
+		cert.ValidTo = time.Unix(int64(*details.ValidTo), 0).UTC()
+	}
+	return cert
+}
+
+// HTTPStatusError is returned when NavigationOptions.FailOnHTTPError is set and the main
+// document answers with a 4xx or 5xx status.
+type HTTPStatusError struct {
+	URL        string
+	StatusCode int
+	StatusText string
+}
+
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("%s answered HTTP %d %s", e.URL, e.StatusCode, e.StatusText)
+}
@@ -0,0 +1,117 @@
+package playwright_integration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// Storage holds the Web Storage contents of one origin.
+type Storage struct {
+	Origin         string            `json:"origin"`
+	LocalStorage   map[string]string `json:"local_storage"`
+	SessionStorage map[string]string `json:"session_storage"`
+}
+
+// getStorageScript dumps both storage areas of the current document. Accessing storage throws
+// on opaque origins such as about:blank or sandboxed documents.
+const getStorageScript = `() => {
+	const dump = (storage) => {
+		const items = {};
+		for (let i = 0; i < storage.length; i++) {
+			const key = storage.key(i);
+			items[key] = storage.getItem(key);
+		}
+		return items;
+	};
+	return {
+		origin: location.origin,
+		local_storage: dump(window.localStorage),
+		session_storage: dump(window.sessionStorage),
+	};
+}`
+
+// seedStorageScript is an init script template: it is called with a Storage and sets its items
+// in top-level documents of the matching origin before any page script runs.
+const seedStorageScript = `((seed) => {
+	if (window !== window.top || location.origin !== seed.origin) {
+		return;
+	}
+	for (const [key, value] of Object.entries(seed.local_storage || {})) {
+		window.localStorage.setItem(key, value);
+	}
+	for (const [key, value] of Object.entries(seed.session_storage || {})) {
+		window.sessionStorage.setItem(key, value);
+	}
+})(%s);`
+
+// GetStorage returns the localStorage and sessionStorage items of the page's main frame.
+func (pi *PlaywrightIntegration) GetStorage(ctx context.Context, page playwright.Page) (*Storage, error) {
+	if page == nil {
+		return nil, fmt.Errorf("playwright.Page cannot be nil")
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	result, err := page.Evaluate(getStorageScript)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read storage: %w", withContextErr(ctx, err))
+	}
+	// Round-trip through JSON to convert the evaluated object into a Storage.
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode storage: %w", err)
+	}
+	var storage Storage
+	if err := json.Unmarshal(data, &storage); err != nil {
+		return nil, fmt.Errorf("failed to decode storage: %w", err)
+	}
+	return &storage, nil
+}
+
+// SeedStorage makes the page set the given items whenever it loads a top-level document of
+// seed.Origin, before the document's own scripts run, e.g. to restore a saved login token.
+// Items are written again on every such load, so reloads start from the seeded values.
+// It must be called before navigation.
+func (pi *PlaywrightIntegration) SeedStorage(page playwright.Page, seed Storage) error {
+	if page == nil {
+		return fmt.Errorf("playwright.Page cannot be nil")
+	}
+	origin, err := StorageOrigin(seed.Origin)
+	if err != nil {
+		return err
+	}
+	seed.Origin = origin
+	data, err := json.Marshal(seed)
+	if err != nil {
+		return fmt.Errorf("failed to encode storage: %w", err)
+	}
+	if err := page.AddInitScript(playwright.Script{Content: playwright.String(fmt.Sprintf(seedStorageScript, data))}); err != nil {
+		return fmt.Errorf("failed to seed storage: %w", err)
+	}
+	return nil
+}
+
+// StorageOrigin returns the origin ("scheme://host[:port]") of rawURL, the scope of Web Storage.
+func StorageOrigin(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return "", fmt.Errorf("invalid storage origin %q: expected an http or https URL", rawURL)
+	}
+	// Match location.origin, which lowercases the host and omits default ports.
+	host, port := strings.ToLower(u.Hostname()), u.Port()
+	if (u.Scheme == "http" && port == "80") || (u.Scheme == "https" && port == "443") {
+		port = ""
+	}
+	if port != "" {
+		host = net.JoinHostPort(host, port)
+	} else if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+	return u.Scheme + "://" + host, nil
+}
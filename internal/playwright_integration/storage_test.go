@@ -0,0 +1,27 @@
+package playwright_integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorageOrigin(t *testing.T) {
+	tests := map[string]string{
+		"https://Example.com/login?next=/": "https://example.com",
+		"https://example.com:443/":         "https://example.com",
+		"http://localhost:8080/app":        "http://localhost:8080",
+		"http://[::1]:80/":                 "http://[::1]",
+		"http://[::1]:3000/":               "http://[::1]:3000",
+	}
+	for rawURL, want := range tests {
+		origin, err := StorageOrigin(rawURL)
+		assert.NoError(t, err, rawURL)
+		assert.Equal(t, want, origin, rawURL)
+	}
+
+	for _, rawURL := range []string{"about:blank", "file:///tmp/index.html", "example.com"} {
+		_, err := StorageOrigin(rawURL)
+		assert.Error(t, err, rawURL)
+	}
+}
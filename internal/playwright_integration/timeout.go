@@ -0,0 +1,68 @@
+package playwright_integration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// Phases of a tool call reported by PhaseTimeoutError.
+const (
+	PhaseNavigation = "navigation"
+	PhaseContent    = "content"
+	PhaseScreenshot = "screenshot"
+)
+
+// PhaseTimeoutError reports which phase of a tool call ran out of time, so callers can
+// tell a slow page load from a slow screenshot and tune timeout_ms accordingly.
+type PhaseTimeoutError struct {
+	Phase string
+	Err   error
+}
+
+func (e *PhaseTimeoutError) Error() string {
+	return fmt.Sprintf("%s timed out: %v", e.Phase, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *PhaseTimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// wrapTimeout returns a *PhaseTimeoutError for the given phase if err is a context deadline
+// or a Playwright timeout, and err unchanged otherwise.
+func wrapTimeout(phase string, err error) error {
+	var phaseErr *PhaseTimeoutError
+	if err == nil || errors.As(err, &phaseErr) {
+		return err
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, playwright.ErrTimeout) {
+		return &PhaseTimeoutError{Phase: phase, Err: err}
+	}
+	return err
+}
+
+// GetContent returns the page's HTML. If ctx has expired, the error is reported as a
+// timeout of the content phase.
+func (pi *PlaywrightIntegration) GetContent(ctx context.Context, page playwright.Page) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", wrapTimeout(PhaseContent, err)
+	}
+	content, err := page.Content()
+	if err != nil {
+		return "", wrapTimeout(PhaseContent, fmt.Errorf("failed to get HTML content: %w", withContextErr(ctx, err)))
+	}
+	return content, nil
+}
+
+// withContextErr attributes a failed Playwright call to ctx if ctx is done: the page is closed
+// when its context is cancelled, so the call fails with a "target closed" error that would
+// otherwise hide the cancellation. It returns err unchanged if ctx is still live.
+func withContextErr(ctx context.Context, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil && err != nil && !errors.Is(err, ctxErr) {
+		return fmt.Errorf("%w: %v", ctxErr, err)
+	}
+	return err
+}
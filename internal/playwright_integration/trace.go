@@ -0,0 +1,63 @@
+package playwright_integration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// RecordTrace records a Playwright trace of the page's context while run executes, typically
+// the navigation, and returns the trace archive: a zip with the action log, DOM snapshots,
+// screenshots and network activity, viewable with "playwright show-trace" or
+// trace.playwright.dev. Snapshots and screenshots make a trace of a single page load typically
+// 1-5 MB. The page must have its own context, as pages created by NewPage do.
+func (pi *PlaywrightIntegration) RecordTrace(ctx context.Context, page playwright.Page, run func() error) ([]byte, error) {
+	if page == nil {
+		return nil, fmt.Errorf("playwright.Page cannot be nil")
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	file, err := os.CreateTemp("", "trace-*.zip")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace file: %w", err)
+	}
+	path := file.Name()
+	file.Close()
+	defer os.Remove(path)
+
+	tracing := page.Context().Tracing()
+	if err := tracing.Start(playwright.TracingStartOptions{
+		Screenshots: playwright.Bool(true),
+		Snapshots:   playwright.Bool(true),
+		Sources:     playwright.Bool(false),
+	}); err != nil {
+		return nil, fmt.Errorf("failed to start tracing: %w", err)
+	}
+	pi.logger.Debug("Tracing started", "path", path)
+
+	if runErr := run(); runErr != nil {
+		// Discard the trace; without a path Playwright does not write an archive.
+		if err := tracing.Stop(); err != nil && ctx.Err() == nil {
+			pi.logger.Warn("Failed to stop tracing", "error", err)
+		}
+		return nil, runErr
+	}
+	if err := tracing.Stop(path); err != nil {
+		return nil, fmt.Errorf("failed to stop tracing: %w", withContextErr(ctx, err))
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trace file: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, errors.New("tracing produced an empty trace")
+	}
+	pi.logger.Debug("Tracing stopped", "bytes", len(data))
+	return data, nil
+}
@@ -0,0 +1,78 @@
+package playwright_integration
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// WaitForResponse waits for a response whose URL matches urlPattern and returns its details.
+// urlPattern is a Playwright glob, or a regular expression when wrapped in slashes.
+// If trigger is not nil it is run after the wait has started, so responses caused by it
+// (e.g. a navigation) are not missed. timeout is in seconds; zero uses Playwright's default.
+func (pi *PlaywrightIntegration) WaitForResponse(ctx context.Context, page playwright.Page, urlPattern string, timeout float64, trigger func() error) (*CapturedNetworkActivity, error) {
+	if page == nil {
+		return nil, fmt.Errorf("playwright.Page cannot be nil")
+	}
+	matcher, err := urlMatcher(urlPattern)
+	if err != nil {
+		return nil, err
+	}
+
+	options := playwright.PageExpectResponseOptions{}
+	if timeout > 0 {
+		options.Timeout = playwright.Float(timeout * 1000) // Convert seconds to milliseconds
+	}
+
+	pi.logger.Debug("Waiting for response", "pattern", urlPattern, "timeout", timeout)
+	response, err := page.ExpectResponse(matcher, trigger, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed waiting for response matching %s: %w", urlPattern, err)
+	}
+
+	activity := &CapturedNetworkActivity{
+		Request: CapturedRequest{
+			URL:          response.Request().URL(),
+			Method:       response.Request().Method(),
+			ResourceType: response.Request().ResourceType(),
+			Headers:      redactHeaders(response.Request().Headers()),
+		},
+		Response: CapturedResponse{
+			Status:  response.Status(),
+			Headers: response.Headers(),
+		},
+	}
+	pi.captureResponseBody(response, &activity.Response, nil)
+
+	pi.logger.Debug("Matched response", "url", response.URL(), "status", response.Status())
+	return activity, nil
+}
+
+// WaitForURL waits until the page URL matches urlPattern, e.g. after a click that triggers
+// client-side routing, and returns the URL it reached. urlPattern is a Playwright glob, or a
+// regular expression when wrapped in slashes. A URL that already matches returns immediately.
+// timeout is in seconds; zero uses Playwright's default.
+func (pi *PlaywrightIntegration) WaitForURL(ctx context.Context, page playwright.Page, urlPattern string, timeout float64) (string, error) {
+	if page == nil {
+		return "", fmt.Errorf("playwright.Page cannot be nil")
+	}
+	matcher, err := urlMatcher(urlPattern)
+	if err != nil {
+		return "", err
+	}
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	options := playwright.PageWaitForURLOptions{}
+	if timeout > 0 {
+		options.Timeout = playwright.Float(timeout * 1000) // Convert seconds to milliseconds
+	}
+
+	pi.logger.Debug("Waiting for URL", "pattern", urlPattern, "timeout", timeout)
+	if err := page.WaitForURL(matcher, options); err != nil {
+		return "", pi.debugScreenshot(page, fmt.Errorf("failed waiting for URL matching %s (current URL %s): %w", urlPattern, page.URL(), err))
+	}
+	return page.URL(), nil
+}
@@ -0,0 +1,144 @@
+package playwright_integration
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// DefaultMaxFrameBytes is the default per-frame payload limit of SetWebSocketFrameLimit.
+const DefaultMaxFrameBytes = 4 * 1024
+
+// MaxWebSocketFrames is the number of frames recorded per WebSocket; later frames are only counted.
+const MaxWebSocketFrames = 1000
+
+// WebSocket frame directions.
+const (
+	FrameSent     = "sent"
+	FrameReceived = "received"
+)
+
+// WebSocketFrame is a message sent or received over a WebSocket.
+type WebSocketFrame struct {
+	Direction string    `json:"direction"` // "sent" or "received"
+	Time      time.Time `json:"time"`
+	// Payload is the message text, or base64 if Encoding is "base64" because the payload is
+	// not valid UTF-8. It is cut to the frame limit and flagged with Truncated.
+	Payload   string `json:"payload"`
+	Encoding  string `json:"encoding,omitempty"`
+	Size      int    `json:"size"` // Full payload size in bytes
+	Truncated bool   `json:"truncated,omitempty"`
+}
+
+// WebSocketActivity is a WebSocket the page opened, with the frames it carried.
+type WebSocketActivity struct {
+	URL           string           `json:"url"`
+	OpenedAt      time.Time        `json:"opened_at"`
+	Closed        bool             `json:"closed,omitempty"`
+	Error         string           `json:"error,omitempty"`
+	Frames        []WebSocketFrame `json:"frames"`
+	DroppedFrames int              `json:"dropped_frames,omitempty"` // Frames past MaxWebSocketFrames
+}
+
+// SetWebSocketFrameLimit bounds the payload recorded per WebSocket frame. Zero disables the limit.
+func (pi *PlaywrightIntegration) SetWebSocketFrameLimit(maxFrameBytes int) {
+	pi.captureMu.Lock()
+	defer pi.captureMu.Unlock()
+	pi.maxFrameBytes = maxFrameBytes
+}
+
+// CaptureWebSockets records the WebSockets the page opens from now on and the frames they send
+// and receive, replacing the WebSockets captured on it before. Read them with
+// GetCapturedWebSockets.
+func (pi *PlaywrightIntegration) CaptureWebSockets(ctx context.Context, page playwright.Page) error {
+	if page == nil {
+		return fmt.Errorf("playwright.Page cannot be nil")
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	pi.captureMu.Lock()
+	pi.captureState(page).webSockets = nil
+	pi.captureMu.Unlock()
+
+	page.OnWebSocket(func(ws playwright.WebSocket) {
+		activity := &WebSocketActivity{URL: ws.URL(), OpenedAt: time.Now(), Frames: []WebSocketFrame{}}
+		pi.captureMu.Lock()
+		state := pi.captureState(page)
+		state.webSockets = append(state.webSockets, activity)
+		pi.captureMu.Unlock()
+		pi.logger.Debug("WebSocket opened", "url", activity.URL)
+
+		ws.OnFrameSent(func(payload []byte) {
+			pi.recordFrame(activity, FrameSent, payload)
+		})
+		ws.OnFrameReceived(func(payload []byte) {
+			pi.recordFrame(activity, FrameReceived, payload)
+		})
+		ws.OnSocketError(func(message string) {
+			pi.captureMu.Lock()
+			defer pi.captureMu.Unlock()
+			activity.Error = message
+		})
+		ws.OnClose(func(playwright.WebSocket) {
+			pi.captureMu.Lock()
+			defer pi.captureMu.Unlock()
+			activity.Closed = true
+		})
+	})
+	return nil
+}
+
+// recordFrame appends a frame to activity, cutting its payload to the frame limit.
+func (pi *PlaywrightIntegration) recordFrame(activity *WebSocketActivity, direction string, payload []byte) {
+	pi.captureMu.Lock()
+	defer pi.captureMu.Unlock()
+	if len(activity.Frames) >= MaxWebSocketFrames {
+		activity.DroppedFrames++
+		return
+	}
+	activity.Frames = append(activity.Frames, newWebSocketFrame(direction, payload, pi.maxFrameBytes, time.Now()))
+}
+
+// newWebSocketFrame describes a frame whose payload is kept up to maxBytes, or whole if
+// maxBytes is zero.
+func newWebSocketFrame(direction string, payload []byte, maxBytes int, now time.Time) WebSocketFrame {
+	frame := WebSocketFrame{Direction: direction, Time: now, Size: len(payload)}
+	binary := !utf8.Valid(payload)
+	if maxBytes > 0 && len(payload) > maxBytes {
+		payload = payload[:maxBytes]
+		if !binary {
+			// Do not split a multi-byte character.
+			for len(payload) > 0 && !utf8.Valid(payload) {
+				payload = payload[:len(payload)-1]
+			}
+		}
+		frame.Truncated = true
+	}
+	if binary {
+		frame.Payload = base64.StdEncoding.EncodeToString(payload)
+		frame.Encoding = "base64"
+	} else {
+		frame.Payload = string(payload)
+	}
+	return frame
+}
+
+// GetCapturedWebSockets returns a copy of the WebSockets recorded on page since its last
+// CaptureWebSockets, in the order they were opened.
+func (pi *PlaywrightIntegration) GetCapturedWebSockets(page playwright.Page) []WebSocketActivity {
+	pi.captureMu.Lock()
+	defer pi.captureMu.Unlock()
+	webSockets := pi.recordedCapture(page).webSockets
+	result := make([]WebSocketActivity, len(webSockets))
+	for i, activity := range webSockets {
+		result[i] = *activity
+		result[i].Frames = append([]WebSocketFrame{}, activity.Frames...)
+	}
+	return result
+}
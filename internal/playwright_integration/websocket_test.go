@@ -0,0 +1,39 @@
+package playwright_integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewWebSocketFrame(t *testing.T) {
+	now := time.Now()
+
+	frame := newWebSocketFrame(FrameSent, []byte(`{"type":"ping"}`), 1024, now)
+	assert.Equal(t, WebSocketFrame{Direction: FrameSent, Time: now, Payload: `{"type":"ping"}`, Size: 15}, frame)
+
+	frame = newWebSocketFrame(FrameReceived, []byte("héllo"), 2, now)
+	assert.Equal(t, "h", frame.Payload, "a multi-byte character is not split")
+	assert.Equal(t, 6, frame.Size)
+	assert.True(t, frame.Truncated)
+
+	frame = newWebSocketFrame(FrameReceived, []byte{0xff, 0x00, 0x01}, 2, now)
+	assert.Equal(t, "/wA=", frame.Payload)
+	assert.Equal(t, "base64", frame.Encoding)
+	assert.True(t, frame.Truncated)
+
+	frame = newWebSocketFrame(FrameReceived, make([]byte, 10), 0, now)
+	assert.Len(t, frame.Payload, 10)
+	assert.False(t, frame.Truncated)
+}
+
+func TestRecordFrameDropsFramesOverTheLimit(t *testing.T) {
+	pi := &PlaywrightIntegration{maxFrameBytes: DefaultMaxFrameBytes}
+	activity := &WebSocketActivity{}
+	for i := 0; i < MaxWebSocketFrames+2; i++ {
+		pi.recordFrame(activity, FrameReceived, []byte("tick"))
+	}
+	assert.Len(t, activity.Frames, MaxWebSocketFrames)
+	assert.Equal(t, 2, activity.DroppedFrames)
+}
@@ -0,0 +1,150 @@
+// Package resources keeps large tool outputs such as screenshots and HTML in memory so they can
+// be served as MCP resources instead of being inlined in tool results. Entries are addressed by
+// URIs like "capture://3f2a9c0d1e4b5a67/screenshot.png", expire after a TTL, and are evicted
+// least recently used first once the store exceeds its size bound.
+package resources
+
+import (
+	"container/list"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Scheme is the URI scheme of stored resources.
+const Scheme = "capture"
+
+// URITemplate matches the URIs of stored resources, for registering a resource template.
+const URITemplate = Scheme + "://{id}/{name}"
+
+// ErrTooLarge is returned by Put for data larger than the whole store.
+var ErrTooLarge = errors.New("resource is larger than the resource store")
+
+// Resource is a stored tool output.
+type Resource struct {
+	URI      string
+	MIMEType string
+	Data     []byte
+	Created  time.Time
+}
+
+// Store is a size-bounded, expiring, in-memory LRU store of resources. It is safe for
+// concurrent use.
+type Store struct {
+	mu       sync.Mutex
+	maxBytes int
+	ttl      time.Duration
+	size     int
+	entries  map[string]*list.Element // Values are *Resource
+	order    *list.List               // Front is the most recently used
+	now      func() time.Time
+}
+
+// NewStore returns a store holding at most maxBytes of data, each entry for at most ttl.
+// A ttl of zero or less keeps entries until they are evicted.
+func NewStore(maxBytes int, ttl time.Duration) *Store {
+	return &Store{
+		maxBytes: maxBytes,
+		ttl:      ttl,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+		now:      time.Now,
+	}
+}
+
+// Put stores data under a new URI ending in name, e.g. "screenshot.png", evicting the least
+// recently used entries if needed, and returns the URI.
+func (s *Store) Put(name, mimeType string, data []byte) (string, error) {
+	if len(data) > s.maxBytes {
+		return "", fmt.Errorf("%w: %d bytes, limit %d", ErrTooLarge, len(data), s.maxBytes)
+	}
+	id, err := newID()
+	if err != nil {
+		return "", err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expire()
+	for s.size+len(data) > s.maxBytes {
+		s.remove(s.order.Back())
+	}
+	r := &Resource{
+		URI:      fmt.Sprintf("%s://%s/%s", Scheme, id, name),
+		MIMEType: mimeType,
+		Data:     data,
+		Created:  s.now(),
+	}
+	s.entries[r.URI] = s.order.PushFront(r)
+	s.size += len(data)
+	return r.URI, nil
+}
+
+// Get returns the resource stored under uri, or false if it does not exist or has expired.
+func (s *Store) Get(uri string) (*Resource, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expire()
+	e, ok := s.entries[uri]
+	if !ok {
+		return nil, false
+	}
+	s.order.MoveToFront(e)
+	return e.Value.(*Resource), true
+}
+
+// Clear removes all resources, e.g. because the browser they were captured from restarted.
+func (s *Store) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = make(map[string]*list.Element)
+	s.order.Init()
+	s.size = 0
+}
+
+// Len returns the number of stored resources.
+func (s *Store) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.order.Len()
+}
+
+// expire removes the entries older than the TTL. Callers must hold s.mu.
+func (s *Store) expire() {
+	if s.ttl <= 0 {
+		return
+	}
+	cutoff := s.now().Add(-s.ttl)
+	for e := s.order.Back(); e != nil; {
+		prev := e.Prev()
+		if e.Value.(*Resource).Created.Before(cutoff) {
+			s.remove(e)
+		}
+		e = prev
+	}
+}
+
+// remove deletes an entry. Callers must hold s.mu.
+func (s *Store) remove(e *list.Element) {
+	r := s.order.Remove(e).(*Resource)
+	delete(s.entries, r.URI)
+	s.size -= len(r.Data)
+}
+
+// IsText reports whether a MIME type is served as text rather than base64 encoded data.
+func IsText(mimeType string) bool {
+	return strings.HasPrefix(mimeType, "text/") || strings.HasPrefix(mimeType, "application/json")
+}
+
+// newID returns a random resource ID.
+func newID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate resource ID: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,64 @@
+package resources
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorePutGet(t *testing.T) {
+	s := NewStore(1024, time.Minute)
+	uri, err := s.Put("screenshot.png", "image/png", []byte("png"))
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(uri, "capture://"))
+	assert.True(t, strings.HasSuffix(uri, "/screenshot.png"))
+
+	r, ok := s.Get(uri)
+	if assert.True(t, ok) {
+		assert.Equal(t, "image/png", r.MIMEType)
+		assert.Equal(t, []byte("png"), r.Data)
+	}
+	_, ok = s.Get("capture://unknown/screenshot.png")
+	assert.False(t, ok)
+}
+
+func TestStoreEvictsLeastRecentlyUsed(t *testing.T) {
+	s := NewStore(10, 0)
+	first, _ := s.Put("a.html", "text/html", []byte("aaaa"))
+	second, _ := s.Put("b.html", "text/html", []byte("bbbb"))
+	s.Get(first) // first is now more recently used than second
+
+	third, err := s.Put("c.html", "text/html", []byte("cccc"))
+	assert.NoError(t, err)
+
+	_, ok := s.Get(second)
+	assert.False(t, ok, "the least recently used entry is evicted")
+	_, ok = s.Get(first)
+	assert.True(t, ok)
+	_, ok = s.Get(third)
+	assert.True(t, ok)
+
+	_, err = s.Put("huge.html", "text/html", make([]byte, 11))
+	assert.ErrorIs(t, err, ErrTooLarge)
+}
+
+func TestStoreExpiresAndClears(t *testing.T) {
+	now := time.Now()
+	s := NewStore(1024, time.Minute)
+	s.now = func() time.Time { return now }
+
+	old, _ := s.Put("old.html", "text/html", []byte("old"))
+	now = now.Add(45 * time.Second)
+	recent, _ := s.Put("recent.html", "text/html", []byte("recent"))
+	now = now.Add(30 * time.Second)
+
+	_, ok := s.Get(old)
+	assert.False(t, ok, "entries expire after the TTL")
+	_, ok = s.Get(recent)
+	assert.True(t, ok)
+
+	s.Clear()
+	assert.Zero(t, s.Len())
+}
@@ -0,0 +1,84 @@
+package safety
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// HostPattern matches host names. It is either a glob where "*" matches any run of characters
+// (e.g. "*.example.com"), or a regular expression wrapped in slashes (e.g. "/^docs\.[a-z]+\.org$/").
+type HostPattern struct {
+	source string
+	re     *regexp.Regexp
+}
+
+// ParseHostPattern compiles a host glob or slash-wrapped regular expression. Globs are
+// matched case-insensitively against the whole host name.
+func ParseHostPattern(pattern string) (*HostPattern, error) {
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" {
+		return nil, fmt.Errorf("empty host pattern")
+	}
+
+	var expr string
+	if len(pattern) > 1 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+		expr = pattern[1 : len(pattern)-1]
+	} else {
+		expr = "(?i)^" + strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*") + "$"
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid host pattern %q: %w", pattern, err)
+	}
+	return &HostPattern{source: pattern, re: re}, nil
+}
+
+// ParseHostPatterns compiles a list of host patterns.
+func ParseHostPatterns(patterns []string) ([]*HostPattern, error) {
+	compiled := make([]*HostPattern, 0, len(patterns))
+	for _, pattern := range patterns {
+		p, err := ParseHostPattern(pattern)
+		if err != nil {
+			return nil, err
+		}
+		compiled = append(compiled, p)
+	}
+	return compiled, nil
+}
+
+// Match reports whether host matches the pattern.
+func (p *HostPattern) Match(host string) bool {
+	return p.re.MatchString(host)
+}
+
+// String returns the pattern as it was written.
+func (p *HostPattern) String() string {
+	return p.source
+}
+
+// MarshalJSON encodes the pattern as it was written.
+func (p *HostPattern) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.source)
+}
+
+// PolicyFile is the JSON file format for host lists, e.g. {"allow": ["*.example.com"], "deny": ["ads.example.com"]}.
+type PolicyFile struct {
+	Allow []string `json:"allow"`
+	Deny  []string `json:"deny"`
+}
+
+// LoadPolicyFile reads host lists from a JSON file.
+func LoadPolicyFile(path string) (*PolicyFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var file PolicyFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &file, nil
+}
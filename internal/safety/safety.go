@@ -0,0 +1,264 @@
+// Package safety guards the server against server-side request forgery (SSRF): it rejects URLs
+// that resolve to loopback, private, link-local or cloud metadata addresses, so a tool call
+// cannot be used to reach services on the server's own network. Operators can further restrict
+// the reachable sites with host allow and deny lists.
+package safety
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ErrBlocked is matched by errors.Is for every *BlockedError.
+var ErrBlocked = errors.New("blocked by URL safety policy")
+
+// BlockedError is returned when a URL is rejected by a Policy.
+type BlockedError struct {
+	URL     string
+	Host    string
+	IP      string // The offending address, empty if the URL was rejected before resolution
+	Pattern string // The deny list pattern that matched, if any
+	Reason  string
+}
+
+func (e *BlockedError) Error() string {
+	target := e.URL
+	if target == "" {
+		target = e.Host
+	}
+	if e.Pattern != "" {
+		return fmt.Sprintf("blocked by policy: %s (%s)", e.Pattern, target)
+	}
+	if e.IP != "" {
+		return fmt.Sprintf("%s: %s resolves to %s (%s); set MCP_BROWSER_ALLOW_PRIVATE=true or add the host to MCP_BROWSER_ALLOWED_HOSTS to allow it", ErrBlocked, e.Host, e.IP, e.Reason)
+	}
+	return fmt.Sprintf("%s: %s (%s)", ErrBlocked, target, e.Reason)
+}
+
+func (e *BlockedError) Is(target error) bool {
+	return target == ErrBlocked
+}
+
+// blockedPrefixes are special-purpose ranges not covered by the netip.Addr predicates.
+var blockedPrefixes = []struct {
+	prefix netip.Prefix
+	reason string
+}{
+	{netip.MustParsePrefix("100.64.0.0/10"), "shared address space"}, // Carrier-grade NAT, also Alibaba Cloud's metadata service
+	{netip.MustParsePrefix("192.0.0.0/24"), "IETF protocol assignments"},
+	{netip.MustParsePrefix("198.18.0.0/15"), "benchmarking network"},
+	{netip.MustParsePrefix("fd00:ec2::254/128"), "metadata service"},
+}
+
+// BlockReason returns why ip must not be contacted, or "" if it is a public address.
+func BlockReason(ip netip.Addr) string {
+	ip = ip.Unmap()
+	switch {
+	case ip == netip.MustParseAddr("169.254.169.254"):
+		return "metadata service"
+	case ip.IsLoopback():
+		return "loopback"
+	case ip.IsPrivate():
+		return "private network"
+	case ip.IsLinkLocalUnicast(), ip.IsLinkLocalMulticast():
+		return "link-local"
+	case ip.IsUnspecified():
+		return "unspecified address"
+	case ip.IsMulticast():
+		return "multicast"
+	}
+	for _, b := range blockedPrefixes {
+		if b.prefix.Contains(ip) {
+			return b.reason
+		}
+	}
+	return ""
+}
+
+// Policy decides which URLs the server may fetch. The zero value blocks all non-public
+// addresses and has no host lists.
+type Policy struct {
+	// AllowPrivate disables the address checks, leaving only the host lists.
+	AllowPrivate bool
+	// AllowedHosts are host names exempt from the address checks, e.g. "intranet.corp".
+	// An entry starting with "." also matches all subdomains.
+	AllowedHosts []string
+	// HostAllowlist, if not empty, restricts requests to hosts matching one of its patterns.
+	HostAllowlist []*HostPattern
+	// HostDenylist rejects requests to hosts matching any of its patterns. It takes precedence
+	// over HostAllowlist.
+	HostDenylist []*HostPattern
+	// Resolver looks up host names; nil uses net.DefaultResolver.
+	Resolver *net.Resolver
+}
+
+// NewPolicy returns a policy that blocks non-public addresses except for allowedHosts.
+func NewPolicy(allowedHosts []string) *Policy {
+	return &Policy{AllowedHosts: allowedHosts}
+}
+
+// Restrictive reports whether the policy rejects anything at all. A policy that allows private
+// addresses and has no host lists need not be enforced.
+func (p *Policy) Restrictive() bool {
+	return !p.AllowPrivate || len(p.HostAllowlist) > 0 || len(p.HostDenylist) > 0
+}
+
+// PolicySummary describes the restrictions of a Policy, so an agent can discover them
+// instead of failing blind.
+type PolicySummary struct {
+	BlockPrivateNetworks     bool           `json:"block_private_networks"`
+	PrivateNetworkExceptions []string       `json:"private_network_exceptions"`
+	AllowedHosts             []*HostPattern `json:"allowed_hosts"` // Empty allows all hosts not denied
+	DeniedHosts              []*HostPattern `json:"denied_hosts"`
+}
+
+// Summary describes the policy. A nil policy allows everything.
+func (p *Policy) Summary() PolicySummary {
+	summary := PolicySummary{
+		PrivateNetworkExceptions: []string{},
+		AllowedHosts:             []*HostPattern{},
+		DeniedHosts:              []*HostPattern{},
+	}
+	if p == nil {
+		return summary
+	}
+	summary.BlockPrivateNetworks = !p.AllowPrivate
+	if !p.AllowPrivate {
+		summary.PrivateNetworkExceptions = append(summary.PrivateNetworkExceptions, p.AllowedHosts...)
+	}
+	summary.AllowedHosts = append(summary.AllowedHosts, p.HostAllowlist...)
+	summary.DeniedHosts = append(summary.DeniedHosts, p.HostDenylist...)
+	return summary
+}
+
+// checkHost applies the host allow and deny lists.
+func (p *Policy) checkHost(host string) error {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	for _, pattern := range p.HostDenylist {
+		if pattern.Match(host) {
+			return &BlockedError{Host: host, Pattern: pattern.String(), Reason: "host is on the deny list"}
+		}
+	}
+	if len(p.HostAllowlist) == 0 {
+		return nil
+	}
+	for _, pattern := range p.HostAllowlist {
+		if pattern.Match(host) {
+			return nil
+		}
+	}
+	return &BlockedError{Host: host, Reason: "host matches no allow list pattern"}
+}
+
+// hostAllowed reports whether host is on the allowlist.
+func (p *Policy) hostAllowed(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	for _, allowed := range p.AllowedHosts {
+		allowed = strings.ToLower(allowed)
+		if host == strings.TrimPrefix(allowed, ".") || (strings.HasPrefix(allowed, ".") && strings.HasSuffix(host, allowed)) {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckURL resolves the host of rawURL and returns a *BlockedError if it is not allowed.
+// Only http, https, ws and wss URLs reach the network; data:, blob: and about: URLs are
+// allowed, and all other schemes (such as file:) are blocked.
+func (p *Policy) CheckURL(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return &BlockedError{URL: rawURL, Reason: "unparseable URL"}
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https", "ws", "wss":
+	case "data", "blob", "about":
+		return nil
+	default:
+		return &BlockedError{URL: rawURL, Reason: fmt.Sprintf("scheme %q is not allowed", u.Scheme)}
+	}
+
+	if err = p.checkHost(u.Hostname()); err == nil {
+		_, err = p.resolve(ctx, u.Hostname())
+	}
+	var blocked *BlockedError
+	if errors.As(err, &blocked) {
+		blocked.URL = rawURL
+	}
+	return err
+}
+
+// resolve returns the addresses of host, or a *BlockedError if any of them is blocked. Hosts
+// exempt from the address checks are not resolved and yield no addresses.
+func (p *Policy) resolve(ctx context.Context, host string) ([]netip.Addr, error) {
+	if p.AllowPrivate || p.hostAllowed(host) {
+		return nil, nil
+	}
+
+	var addrs []netip.Addr
+	if ip, err := netip.ParseAddr(strings.Trim(host, "[]")); err == nil {
+		addrs = []netip.Addr{ip}
+	} else {
+		resolver := p.Resolver
+		if resolver == nil {
+			resolver = net.DefaultResolver
+		}
+		if addrs, err = resolver.LookupNetIP(ctx, "ip", host); err != nil {
+			return nil, fmt.Errorf("failed to resolve %s: %w", host, err)
+		}
+	}
+
+	for _, ip := range addrs {
+		if reason := BlockReason(ip); reason != "" {
+			return nil, &BlockedError{Host: host, IP: ip.Unmap().String(), Reason: reason}
+		}
+	}
+	return addrs, nil
+}
+
+// DialContext connects like net.Dialer.DialContext, but only to addresses the policy allows.
+// It dials the address it checked, so a DNS answer that changes between the check and the
+// connection (DNS rebinding) cannot slip through.
+func (p *Policy) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.checkHost(host); err != nil {
+		return nil, err
+	}
+	addrs, err := p.resolve(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	if len(addrs) == 0 {
+		// Host exempt from the address checks.
+		return dialer.DialContext(ctx, network, address)
+	}
+	var lastErr error
+	for _, ip := range addrs {
+		conn, err := dialer.DialContext(ctx, network, net.JoinHostPort(ip.String(), port))
+		if err == nil {
+			return conn, nil
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+// Transport returns an http.Transport that refuses connections the policy does not allow,
+// including those made while following redirects.
+func (p *Policy) Transport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = p.DialContext
+	return transport
+}
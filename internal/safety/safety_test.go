@@ -0,0 +1,77 @@
+package safety
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockReason(t *testing.T) {
+	for ip, reason := range map[string]string{
+		"127.0.0.1":        "loopback",
+		"::1":              "loopback",
+		"10.1.2.3":         "private network",
+		"172.16.0.1":       "private network",
+		"192.168.1.1":      "private network",
+		"fc00::1":          "private network",
+		"169.254.169.254":  "metadata service",
+		"169.254.1.1":      "link-local",
+		"fe80::1":          "link-local",
+		"0.0.0.0":          "unspecified address",
+		"100.100.100.200":  "shared address space",
+		"::ffff:127.0.0.1": "loopback",
+		"93.184.216.34":    "",
+		"2606:4700::1111":  "",
+	} {
+		assert.Equal(t, reason, BlockReason(netip.MustParseAddr(ip)), ip)
+	}
+}
+
+func TestPolicy_CheckURL(t *testing.T) {
+	ctx := context.Background()
+	policy := NewPolicy([]string{"10.0.0.5", ".corp.example"})
+
+	err := policy.CheckURL(ctx, "http://169.254.169.254/latest/meta-data")
+	var blocked *BlockedError
+	if assert.ErrorAs(t, err, &blocked) {
+		assert.Equal(t, "metadata service", blocked.Reason)
+		assert.Equal(t, "http://169.254.169.254/latest/meta-data", blocked.URL)
+	}
+	assert.ErrorIs(t, policy.CheckURL(ctx, "http://[::1]:8080/admin"), ErrBlocked)
+	assert.ErrorIs(t, policy.CheckURL(ctx, "file:///etc/passwd"), ErrBlocked)
+
+	assert.NoError(t, policy.CheckURL(ctx, "http://93.184.216.34/"))
+	assert.NoError(t, policy.CheckURL(ctx, "http://10.0.0.5:8080/"))
+	assert.NoError(t, policy.CheckURL(ctx, "https://wiki.corp.example/page"))
+	assert.NoError(t, policy.CheckURL(ctx, "data:text/html,hello"))
+	assert.NoError(t, policy.CheckURL(ctx, "about:blank"))
+}
+
+func TestPolicy_HostLists(t *testing.T) {
+	ctx := context.Background()
+	allow, err := ParseHostPatterns([]string{"*.example.com", `/^docs\.[a-z]+\.org$/`})
+	assert.NoError(t, err)
+	deny, err := ParseHostPatterns([]string{"ads.example.com"})
+	assert.NoError(t, err)
+	policy := &Policy{AllowPrivate: true, HostAllowlist: allow, HostDenylist: deny}
+
+	assert.NoError(t, policy.CheckURL(ctx, "https://www.EXAMPLE.com/"))
+	assert.NoError(t, policy.CheckURL(ctx, "https://docs.golang.org/"))
+	assert.NoError(t, policy.CheckURL(ctx, "http://127.0.0.1.example.com/"), "private addresses are allowed")
+
+	err = policy.CheckURL(ctx, "https://ads.example.com/pixel.gif")
+	if assert.ErrorIs(t, err, ErrBlocked) {
+		assert.Equal(t, "blocked by policy: ads.example.com (https://ads.example.com/pixel.gif)", err.Error())
+	}
+	assert.ErrorIs(t, policy.CheckURL(ctx, "https://evil.test/"), ErrBlocked)
+
+	_, err = ParseHostPattern("/[/")
+	assert.Error(t, err)
+
+	summary := policy.Summary()
+	assert.False(t, summary.BlockPrivateNetworks)
+	assert.Len(t, summary.AllowedHosts, 2)
+	assert.Equal(t, PolicySummary{PrivateNetworkExceptions: []string{}, AllowedHosts: []*HostPattern{}, DeniedHosts: []*HostPattern{}}, (*Policy)(nil).Summary())
+}
@@ -0,0 +1,159 @@
+// Package sanitize strips the parts of an HTML page that matter to a browser but not to a
+// reader: scripts, styles, inline SVG, preload hints, comments, event handler attributes and
+// inlined data: URIs. What remains is the visible text and the document structure, often a
+// fraction of the original size.
+package sanitize
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// removedElements are dropped together with their content.
+var removedElements = map[atom.Atom]bool{
+	atom.Script:   true,
+	atom.Style:    true,
+	atom.Noscript: true,
+	atom.Svg:      true,
+}
+
+// HTML returns src with scripts, styles, noscript and svg elements, preload links, comments,
+// on* event handler attributes and attributes holding data: URIs removed. src may be a full
+// document or a fragment such as the outer HTML of a few elements; a fragment stays a fragment.
+func HTML(src string) (string, error) {
+	nodes, err := parse(src)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	var sb strings.Builder
+	for _, n := range nodes {
+		if removable(n) {
+			continue
+		}
+		clean(n)
+		if err := html.Render(&sb, n); err != nil {
+			return "", fmt.Errorf("failed to render HTML: %w", err)
+		}
+	}
+	return sb.String(), nil
+}
+
+// parse parses src as a document if it starts like one, and as a body fragment otherwise.
+// A lone <head> or <body> element is parsed as part of a document and returned on its own,
+// since the fragment parser would drop the tag itself.
+func parse(src string) ([]*html.Node, error) {
+	switch firstTag(src) {
+	case "html":
+		doc, err := html.Parse(strings.NewReader(src))
+		if err != nil {
+			return nil, err
+		}
+		return []*html.Node{doc}, nil
+	case "head", "body":
+		doc, err := html.Parse(strings.NewReader(src))
+		if err != nil {
+			return nil, err
+		}
+		if n := findElement(doc, atom.Lookup([]byte(firstTag(src)))); n != nil {
+			return []*html.Node{n}, nil
+		}
+		return []*html.Node{doc}, nil
+	default:
+		return html.ParseFragment(strings.NewReader(src), &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body})
+	}
+}
+
+// firstTag returns the lower-case name of the first start tag in src, "html" if src starts
+// with a doctype, or "" if it has no tags at all.
+func firstTag(src string) string {
+	z := html.NewTokenizer(strings.NewReader(src))
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return ""
+		case html.DoctypeToken:
+			return "html"
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, _ := z.TagName()
+			return string(name)
+		}
+	}
+}
+
+// findElement returns the first element of type a in the tree rooted at n.
+func findElement(n *html.Node, a atom.Atom) *html.Node {
+	if n.Type == html.ElementNode && n.DataAtom == a {
+		return n
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if found := findElement(c, a); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// clean removes the unwanted descendants and attributes of n in place.
+func clean(n *html.Node) {
+	if n.Type == html.ElementNode {
+		n.Attr = cleanAttrs(n.Attr)
+	}
+	for c := n.FirstChild; c != nil; {
+		next := c.NextSibling
+		if removable(c) {
+			n.RemoveChild(c)
+		} else {
+			clean(c)
+		}
+		c = next
+	}
+}
+
+// removable reports whether n is dropped entirely.
+func removable(n *html.Node) bool {
+	switch n.Type {
+	case html.CommentNode:
+		return true
+	case html.ElementNode:
+		if removedElements[n.DataAtom] || (n.Namespace == "svg" && n.Data == "svg") {
+			return true
+		}
+		if n.DataAtom == atom.Link {
+			for _, a := range n.Attr {
+				if a.Key == "rel" && isPreload(a.Val) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// isPreload reports whether a link rel value contains a preload hint.
+func isPreload(rel string) bool {
+	for _, token := range strings.Fields(strings.ToLower(rel)) {
+		if token == "preload" || token == "modulepreload" {
+			return true
+		}
+	}
+	return false
+}
+
+// cleanAttrs drops event handler attributes and attributes whose value is a data: URI.
+func cleanAttrs(attrs []html.Attribute) []html.Attribute {
+	kept := attrs[:0]
+	for _, a := range attrs {
+		if strings.HasPrefix(strings.ToLower(a.Key), "on") {
+			continue
+		}
+		if strings.HasPrefix(strings.ToLower(strings.TrimSpace(a.Val)), "data:") {
+			continue
+		}
+		kept = append(kept, a)
+	}
+	return kept
+}
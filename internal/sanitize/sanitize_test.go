@@ -0,0 +1,88 @@
+package sanitize
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fixturePage mimics a typical modern page: a little content buried in bundled scripts,
+// inline styles, an SVG sprite and tracking markup.
+var fixturePage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Quarterly Report</title>
+<link rel="preload" href="/app.js" as="script">
+<link rel="stylesheet" href="/site.css">
+<style>` + strings.Repeat(".btn{color:#fff;background:#036;padding:4px 8px}\n", 200) + `</style>
+<script>` + strings.Repeat("window.__STATE__.push({id:1,kind:'tracking',payload:'xxxxxxxxxxxxxxxx'});\n", 300) + `</script>
+</head>
+<body onload="init()">
+<!-- build 2024-06-01 -->
+<svg style="display:none"><symbol id="icon"><path d="` + strings.Repeat("M0 0L10 10", 200) + `"/></symbol></svg>
+<noscript><img src="/pixel.gif"></noscript>
+<main>
+<h1>Quarterly Report</h1>
+<p>Revenue grew by <strong>12%</strong> compared to last quarter.</p>
+<img src="data:image/png;base64,` + strings.Repeat("iVBORw0KGgo", 300) + `" alt="Chart">
+<a href="/details" onclick="track()">Details</a>
+<table><tr><th>Region</th><th>Revenue</th></tr><tr><td>EMEA</td><td>4.2M</td></tr></table>
+</main>
+</body>
+</html>`
+
+func TestHTMLShrinksFixturePage(t *testing.T) {
+	sanitized, err := HTML(fixturePage)
+	require.NoError(t, err)
+
+	assert.Less(t, len(sanitized)*10, len(fixturePage), "sanitized page should be under 10%% of the original")
+
+	for _, gone := range []string{"<script", "<style", "<svg", "<noscript", "<!--", "preload", "onload", "onclick", "data:image"} {
+		assert.NotContains(t, sanitized, gone)
+	}
+	for _, kept := range []string{
+		"<title>Quarterly Report</title>",
+		`<link rel="stylesheet" href="/site.css"/>`,
+		"<h1>Quarterly Report</h1>",
+		"<p>Revenue grew by <strong>12%</strong> compared to last quarter.</p>",
+		`<img alt="Chart"/>`,
+		`<a href="/details">Details</a>`,
+		"<td>EMEA</td><td>4.2M</td>",
+	} {
+		assert.Contains(t, sanitized, kept)
+	}
+}
+
+func TestHTMLFragments(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "elements stay a fragment",
+			in:   `<div onclick="x()"><script>x()</script><p>Hi</p></div><span>there</span>`,
+			want: `<div><p>Hi</p></div><span>there</span>`,
+		},
+		{
+			name: "head keeps its tag",
+			in:   `<head><title>T</title><script src="/a.js"></script></head>`,
+			want: `<head><title>T</title></head>`,
+		},
+		{
+			name: "text only",
+			in:   `plain <!-- note --> text`,
+			want: `plain  text`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := HTML(tt.in)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
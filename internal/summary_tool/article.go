@@ -0,0 +1,78 @@
+package summary_tool
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// readabilityJS defines the Readability class used by ExtractArticle.
+//
+//go:embed readability.js
+var readabilityJS string
+
+// parseArticleScript runs Readability on a copy of the document, leaving the live page intact.
+const parseArticleScript = `() => {
+	if (typeof Readability === "undefined") {
+		throw new Error("Readability was not injected");
+	}
+	return new Readability(document.cloneNode(true)).parse();
+}`
+
+// ErrNotArticle is returned by ExtractArticle when the page has no article-like main content,
+// e.g. a search form or an app shell.
+var ErrNotArticle = errors.New("page is not parseable as an article")
+
+// Article is the main content of a page with navigation, ads and sidebars removed.
+type Article struct {
+	Title       string `json:"title"`
+	Byline      string `json:"byline"`
+	Content     string `json:"content"`      // Cleaned HTML of the article body
+	TextContent string `json:"text_content"` // Content as plain text
+	Excerpt     string `json:"excerpt"`      // Meta description, or the first paragraph
+	Length      int    `json:"length"`       // Length of TextContent in characters
+}
+
+// ExtractArticle injects Readability into the page and returns its main content. It returns
+// ErrNotArticle if Readability finds none.
+func (st *SummaryTool) ExtractArticle(ctx context.Context, page playwright.Page) (*Article, error) {
+	if page == nil {
+		return nil, fmt.Errorf("playwright.Page cannot be nil")
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if _, err := page.AddScriptTag(playwright.PageAddScriptTagOptions{Content: playwright.String(readabilityJS)}); err != nil {
+		return nil, fmt.Errorf("failed to inject Readability: %w", err)
+	}
+	result, err := page.Evaluate(parseArticleScript)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse article: %w", err)
+	}
+	if result == nil {
+		return nil, ErrNotArticle
+	}
+
+	// Round-trip through JSON to map Readability's camelCase fields onto Article.
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode article: %w", err)
+	}
+	var parsed struct {
+		Title       string `json:"title"`
+		Byline      string `json:"byline"`
+		Content     string `json:"content"`
+		TextContent string `json:"textContent"`
+		Excerpt     string `json:"excerpt"`
+		Length      int    `json:"length"`
+	}
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to decode article: %w", err)
+	}
+	article := Article(parsed)
+	return &article, nil
+}
@@ -0,0 +1,86 @@
+package summary_tool
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// wordsPerMinute is the reading speed assumed by ContentStats.ReadingTimeMinutes.
+const wordsPerMinute = 200
+
+// ContentStats are basic text statistics of a page's visible text.
+type ContentStats struct {
+	WordCount          int `json:"word_count"`
+	ParagraphCount     int `json:"paragraph_count"` // Blocks of text separated by blank lines
+	SentenceCount      int `json:"sentence_count"`  // Approximated by runs of ".", "!" and "?"
+	CharCount          int `json:"char_count"`      // Characters including whitespace
+	CharCountNoSpaces  int `json:"char_count_no_spaces"`
+	ReadingTimeMinutes int `json:"reading_time_minutes"` // At 200 words per minute, rounded up
+}
+
+// ExtractContentStats computes ContentStats for the rendered text of the page's body.
+func (st *SummaryTool) ExtractContentStats(ctx context.Context, page playwright.Page) (*ContentStats, error) {
+	if page == nil {
+		return nil, fmt.Errorf("playwright.Page cannot be nil")
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	text, err := page.InnerText("body")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read page text: %w", err)
+	}
+	stats := ComputeContentStats(text)
+	return &stats, nil
+}
+
+// ComputeContentStats counts the words, paragraphs, sentences and characters of text, as
+// returned by innerText, and estimates its reading time.
+func ComputeContentStats(text string) ContentStats {
+	text = strings.TrimSpace(strings.ReplaceAll(text, "\r\n", "\n"))
+	stats := ContentStats{
+		WordCount: len(strings.FieldsFunc(text, unicode.IsSpace)),
+		CharCount: utf8.RuneCountInString(text),
+	}
+	for _, r := range text {
+		if !unicode.IsSpace(r) {
+			stats.CharCountNoSpaces++
+		}
+	}
+	for _, paragraph := range strings.Split(text, "\n\n") {
+		if strings.TrimSpace(paragraph) != "" {
+			stats.ParagraphCount++
+		}
+	}
+	stats.SentenceCount = countSentences(text)
+	stats.ReadingTimeMinutes = int(math.Ceil(float64(stats.WordCount) / wordsPerMinute))
+	return stats
+}
+
+// countSentences counts runs of sentence terminators that end some text, plus a final
+// sentence without a terminator, so "Wait... what?!" is two sentences and "Hello" is one.
+func countSentences(text string) int {
+	count := 0
+	pending := false // Whether text has been seen since the last terminator
+	for _, r := range text {
+		switch {
+		case r == '.' || r == '!' || r == '?':
+			if pending {
+				count++
+				pending = false
+			}
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			pending = true
+		}
+	}
+	if pending {
+		count++
+	}
+	return count
+}
@@ -0,0 +1,23 @@
+package summary_tool
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeContentStats(t *testing.T) {
+	stats := ComputeContentStats("Hello world. How are you?\n\nWait... what?!\nFine\n\n\n")
+	assert.Equal(t, ContentStats{
+		WordCount:          8,
+		ParagraphCount:     2,
+		SentenceCount:      5,
+		CharCount:          46,
+		CharCountNoSpaces:  38,
+		ReadingTimeMinutes: 1,
+	}, stats)
+
+	assert.Equal(t, ContentStats{}, ComputeContentStats("  \n "))
+	assert.Equal(t, 2, ComputeContentStats(strings.Repeat("word ", 201)).ReadingTimeMinutes)
+}
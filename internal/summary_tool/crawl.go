@@ -0,0 +1,213 @@
+package summary_tool
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/Camelket/mcp-browser-tools/internal/analysis"
+	"github.com/Camelket/mcp-browser-tools/internal/playwright_integration"
+)
+
+// CrawlOptions configures a shallow same-origin crawl.
+type CrawlOptions struct {
+	MaxDepth    int // Link levels to follow from the start URL; 0 fetches only the start page
+	MaxPages    int // Upper bound on the number of pages fetched
+	Concurrency int // Number of pages fetched in parallel
+	// Page configures the browser context of each crawled page, e.g. for device emulation.
+	Page *playwright_integration.PageOptions
+	// Interception, if set, is passed to SetupNetworkInterception of each page, e.g. to block
+	// resources or inject headers.
+	Interception *playwright_integration.InterceptionOptions
+	// Navigation is passed to NavigateToURLOnPage for each page.
+	Navigation *playwright_integration.NavigationOptions
+	// RespectRobots checks every page against robots.txt before loading it and honors its
+	// Crawl-delay.
+	RespectRobots bool
+}
+
+// Crawl starts at startURL and follows same-origin links breadth-first up to opts.MaxDepth
+// levels, fetching at most opts.MaxPages pages. It returns a map of each fetched URL to the
+// links extracted from it. Pages that fail to load are logged and omitted from the result.
+// With opts.RespectRobots, a start URL disallowed by robots.txt fails the crawl with an
+// *analysis.RobotsDisallowedError, other disallowed pages are skipped like failed ones, and a
+// Crawl-delay makes the crawl load one page at a time, that far apart.
+func (st *SummaryTool) Crawl(ctx context.Context, startURL string, opts CrawlOptions) (map[string][]string, error) {
+	start, err := url.Parse(startURL)
+	if err != nil || start.Host == "" {
+		return nil, fmt.Errorf("invalid start URL %q", startURL)
+	}
+	if opts.MaxPages <= 0 {
+		opts.MaxPages = 1
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 1
+	}
+	var throttle *crawlThrottle
+	if opts.RespectRobots {
+		robots, err := st.robotsChecker().Check(ctx, startURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check robots.txt: %w", err)
+		}
+		if !robots.Allowed {
+			return nil, &analysis.RobotsDisallowedError{URL: startURL, Result: robots}
+		}
+		if robots.CrawlDelay > 0 {
+			opts.Concurrency = 1
+			throttle = &crawlThrottle{delay: robots.CrawlDelay}
+		}
+	}
+	st.logger.Info("Starting crawl", "url", startURL, "max_depth", opts.MaxDepth, "max_pages", opts.MaxPages)
+
+	results := make(map[string][]string)
+	visited := map[string]bool{normalizeCrawlURL(start): true}
+	level := []string{startURL}
+
+	for depth := 0; depth <= opts.MaxDepth && len(level) > 0; depth++ {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+
+		// Never fetch more pages than the remaining budget allows.
+		if remaining := opts.MaxPages - len(results); len(level) > remaining {
+			level = level[:remaining]
+		}
+
+		var (
+			mu  sync.Mutex
+			wg  sync.WaitGroup
+			sem = make(chan struct{}, opts.Concurrency)
+		)
+		for _, pageURL := range level {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(pageURL string) {
+				defer wg.Done()
+				defer func() { <-sem }()
+
+				// The start URL was checked before the crawl began.
+				if opts.RespectRobots && depth > 0 {
+					robots, err := st.robotsChecker().Check(ctx, pageURL)
+					if err != nil || !robots.Allowed {
+						st.logger.Info("Skipping page disallowed by robots.txt", "url", pageURL, "reason", robots.Reason, "error", err)
+						return
+					}
+				}
+				if err := throttle.wait(ctx); err != nil {
+					return
+				}
+				links, err := st.fetchLinks(ctx, pageURL, opts)
+				if err != nil {
+					st.logger.Warn("Failed to crawl page", "url", pageURL, "error", err)
+					return
+				}
+				mu.Lock()
+				results[pageURL] = links
+				mu.Unlock()
+			}(pageURL)
+		}
+		wg.Wait()
+
+		if len(results) >= opts.MaxPages {
+			break
+		}
+
+		// Queue unvisited same-origin links for the next level, in a stable order.
+		var next []string
+		for _, pageURL := range level {
+			for _, link := range results[pageURL] {
+				parsed, err := url.Parse(link)
+				if err != nil || parsed.Scheme != start.Scheme || parsed.Host != start.Host {
+					continue
+				}
+				key := normalizeCrawlURL(parsed)
+				if visited[key] {
+					continue
+				}
+				visited[key] = true
+				next = append(next, link)
+			}
+		}
+		level = next
+	}
+
+	st.logger.Info("Crawl finished", "url", startURL, "pages", len(results))
+	return results, nil
+}
+
+// robotsChecker returns a robots.txt checker that fetches through the URL policy, if one is set,
+// like the pages themselves.
+func (st *SummaryTool) robotsChecker() *analysis.RobotsChecker {
+	checker := &analysis.RobotsChecker{}
+	if policy := st.playwright.URLPolicy(); policy != nil {
+		checker.Transport = policy.Transport()
+	}
+	return checker
+}
+
+// crawlThrottle spaces page loads delay apart. A nil throttle does not wait.
+type crawlThrottle struct {
+	mu    sync.Mutex
+	delay time.Duration
+	next  time.Time // Earliest time of the next page load
+}
+
+// wait blocks until the next page may be loaded, or until ctx is done.
+func (t *crawlThrottle) wait(ctx context.Context) error {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if wait := time.Until(t.next); wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	t.next = time.Now().Add(t.delay)
+	return nil
+}
+
+// fetchLinks loads a single page as configured by opts and returns the links found in its HTML.
+func (st *SummaryTool) fetchLinks(ctx context.Context, pageURL string, opts CrawlOptions) ([]string, error) {
+	page, err := st.playwright.NewPage(ctx, opts.Page)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new page: %w", err)
+	}
+	defer page.Close()
+
+	if opts.Interception != nil {
+		if err := st.playwright.SetupNetworkInterception(ctx, page, opts.Interception); err != nil {
+			return nil, fmt.Errorf("failed to set up network interception: %w", err)
+		}
+	}
+	if _, err := st.playwright.NavigateToURLOnPage(ctx, page, pageURL, opts.Navigation); err != nil {
+		return nil, err
+	}
+
+	htmlContent, err := st.playwright.GetContent(ctx, page)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HTML content for %s: %w", pageURL, err)
+	}
+	links, err := st.extractLinks(htmlContent, pageURL)
+	if err != nil {
+		return nil, err
+	}
+	return linkHrefs(links), nil
+}
+
+// normalizeCrawlURL returns a dedupe key for a URL, ignoring fragments and treating an empty path as "/".
+func normalizeCrawlURL(u *url.URL) string {
+	normalized := *u
+	normalized.Fragment = ""
+	if normalized.Path == "" {
+		normalized.Path = "/"
+	}
+	return normalized.String()
+}
@@ -0,0 +1,24 @@
+package summary_tool
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCrawlThrottle(t *testing.T) {
+	var unthrottled *crawlThrottle
+	assert.NoError(t, unthrottled.wait(context.Background()))
+
+	throttle := &crawlThrottle{delay: 50 * time.Millisecond}
+	start := time.Now()
+	assert.NoError(t, throttle.wait(context.Background()), "the first page loads at once")
+	assert.NoError(t, throttle.wait(context.Background()))
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, throttle.wait(ctx), context.Canceled)
+}
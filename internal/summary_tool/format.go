@@ -0,0 +1,96 @@
+package summary_tool
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// ToJSON marshals the summary to JSON. The screenshot is encoded as a base64 string.
+func (ps *PageSummary) ToJSON() ([]byte, error) {
+	return json.Marshal(ps)
+}
+
+// ToMarkdown renders the summary as a Markdown document with the page's links,
+// headings, and an inline screenshot.
+func (ps *PageSummary) ToMarkdown() string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "# %s\n\n", ps.URL)
+	if ps.FinalURL != "" && ps.FinalURL != ps.URL {
+		fmt.Fprintf(&sb, "Redirected to %s\n\n", ps.FinalURL)
+	}
+
+	sb.WriteString("## Links\n\n")
+	if len(ps.Links) == 0 {
+		sb.WriteString("_No links found._\n")
+	}
+	for _, link := range ps.Links {
+		if link.Text != "" {
+			fmt.Fprintf(&sb, "- [%s](%s)\n", link.Text, link.Href)
+		} else {
+			fmt.Fprintf(&sb, "- %s\n", link.Href)
+		}
+	}
+
+	sb.WriteString("\n## Headings\n\n")
+	if len(ps.Headings) == 0 {
+		sb.WriteString("_No headings found._\n")
+	}
+	for _, heading := range ps.Headings {
+		// Nest headings by level so the outline of the page is preserved.
+		fmt.Fprintf(&sb, "%s- %s\n", strings.Repeat("  ", heading.Level-1), heading.Text)
+	}
+
+	if len(ps.Screenshot) > 0 {
+		fmt.Fprintf(&sb, "\n## Screenshot\n\n![screenshot](data:image/png;base64,%s)\n", base64.StdEncoding.EncodeToString(ps.Screenshot))
+	}
+
+	return sb.String()
+}
+
+// Heading is a single <h1>-<h6> element of a page.
+type Heading struct {
+	Level int    `json:"level"`
+	Text  string `json:"text"`
+}
+
+// extractHeadings returns all non-empty <h1>-<h6> elements in document order.
+func extractHeadings(htmlContent string) ([]Heading, error) {
+	doc, err := html.Parse(strings.NewReader(htmlContent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	var headings []Heading
+	var f func(*html.Node)
+	f = func(n *html.Node) {
+		if n.Type == html.ElementNode && len(n.Data) == 2 && n.Data[0] == 'h' && n.Data[1] >= '1' && n.Data[1] <= '6' {
+			if text := strings.Join(strings.Fields(nodeText(n)), " "); text != "" {
+				headings = append(headings, Heading{Level: int(n.Data[1] - '0'), Text: text})
+			}
+			return
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			f(c)
+		}
+	}
+	f(doc)
+
+	return headings, nil
+}
+
+// nodeText returns the concatenated text content of a node and its descendants.
+func nodeText(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	var sb strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		sb.WriteString(nodeText(c))
+	}
+	return sb.String()
+}
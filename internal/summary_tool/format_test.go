@@ -0,0 +1,44 @@
+package summary_tool
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPageSummary_ToJSON(t *testing.T) {
+	ps := &PageSummary{
+		URL:        "https://example.com",
+		Screenshot: []byte{0x89, 'P', 'N', 'G'},
+		Links:      []Link{{Href: "https://example.com/a"}},
+	}
+
+	data, err := ps.ToJSON()
+	assert.NoError(t, err)
+
+	var decoded map[string]any
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, "https://example.com", decoded["url"])
+	assert.Equal(t, base64.StdEncoding.EncodeToString(ps.Screenshot), decoded["screenshot"])
+}
+
+func TestPageSummary_ToMarkdown(t *testing.T) {
+	headings, err := extractHeadings(`<h1>Title</h1><p>text</p><h2> Sub <em>section</em> </h2>`)
+	assert.NoError(t, err)
+
+	ps := &PageSummary{
+		URL:        "https://example.com",
+		Screenshot: []byte("png"),
+		Links:      []Link{{Href: "https://example.com/a"}},
+		Headings:   headings,
+	}
+	md := ps.ToMarkdown()
+
+	assert.True(t, strings.HasPrefix(md, "# https://example.com\n"))
+	assert.Contains(t, md, "## Links\n\n- https://example.com/a\n")
+	assert.Contains(t, md, "## Headings\n\n- Title\n  - Sub section\n")
+	assert.Contains(t, md, "![screenshot](data:image/png;base64,cG5n)")
+}
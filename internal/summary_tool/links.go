@@ -0,0 +1,202 @@
+package summary_tool
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/Camelket/mcp-browser-tools/internal/analysis"
+)
+
+// LinkStatus holds the result of checking a single link.
+type LinkStatus struct {
+	URL         string `json:"url"`
+	StatusCode  int    `json:"status_code"`
+	Error       string `json:"error,omitempty"`
+	RedirectURL string `json:"redirect_url,omitempty"` // Final URL if the link redirected
+}
+
+// linkCheckTimeout bounds each link check request.
+const linkCheckTimeout = 10 * time.Second
+
+// perHostInterval is the minimum delay between two requests to the same host.
+const perHostInterval = 200 * time.Millisecond
+
+// CheckLinks extracts the links from htmlContent and checks each one with a HEAD request,
+// falling back to GET when the server answers 405 Method Not Allowed. Requests run with the
+// given concurrency, while requests to the same host are spaced out to avoid hammering it.
+// Links disallowed by their origin's robots.txt, fetched once per origin, are not requested
+// and are reported with an Error instead. Results are returned in the order the links appear
+// on the page.
+func (st *SummaryTool) CheckLinks(ctx context.Context, htmlContent string, baseURL string, concurrency int) ([]LinkStatus, error) {
+	found, err := st.extractLinks(htmlContent, baseURL)
+	if err != nil {
+		return nil, err
+	}
+	links := linkHrefs(found)
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	st.logger.Info("Checking links", "url", baseURL, "count", len(links), "concurrency", concurrency)
+
+	client := &http.Client{Timeout: linkCheckTimeout}
+	if policy := st.playwright.URLPolicy(); policy != nil {
+		client.Transport = policy.Transport()
+	}
+	limiter := newHostLimiter(perHostInterval)
+	robots := newRobotsCache(st.robotsChecker(), limiter)
+	results := make([]LinkStatus, len(links))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for i, link := range links {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, link string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = checkLink(ctx, client, limiter, robots, link)
+		}(i, link)
+	}
+	wg.Wait()
+
+	return results, ctx.Err()
+}
+
+// checkLink issues a HEAD request for link, retrying with GET on 405, unless robots.txt
+// disallows it.
+func checkLink(ctx context.Context, client *http.Client, limiter *hostLimiter, robots *robotsCache, link string) LinkStatus {
+	status := LinkStatus{URL: link}
+
+	u, err := url.Parse(link)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		status.Error = fmt.Sprintf("unsupported scheme %q", u.Scheme)
+		return status
+	}
+	verdict, err := robots.check(ctx, u)
+	if err != nil {
+		status.Error = fmt.Sprintf("failed to check robots.txt: %v", err)
+		return status
+	}
+	if !verdict.Allowed {
+		status.Error = "robots.txt disallows this link: " + verdict.Reason
+		return status
+	}
+
+	resp, err := doLinkRequest(ctx, client, limiter, http.MethodHead, u)
+	if err == nil && resp.StatusCode == http.StatusMethodNotAllowed {
+		resp.Body.Close()
+		resp, err = doLinkRequest(ctx, client, limiter, http.MethodGet, u)
+	}
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	defer resp.Body.Close()
+
+	status.StatusCode = resp.StatusCode
+	if final := resp.Request.URL.String(); final != link {
+		status.RedirectURL = final
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		status.Error = resp.Status
+	}
+	return status
+}
+
+// doLinkRequest waits for the host's rate limit and then sends a request.
+func doLinkRequest(ctx context.Context, client *http.Client, limiter *hostLimiter, method string, u *url.URL) (*http.Response, error) {
+	if err := limiter.wait(ctx, u.Host); err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return client.Do(req)
+}
+
+// robotsCache fetches the robots.txt of each origin once for CheckLinks.
+type robotsCache struct {
+	checker *analysis.RobotsChecker
+	limiter *hostLimiter
+	mu      sync.Mutex
+	origins map[string]*originRobots
+}
+
+// originRobots is the robots.txt of one origin, fetched by the first link that needs it.
+type originRobots struct {
+	once  sync.Once
+	rules *analysis.RobotsRules
+	err   error
+}
+
+func newRobotsCache(checker *analysis.RobotsChecker, limiter *hostLimiter) *robotsCache {
+	return &robotsCache{checker: checker, limiter: limiter, origins: make(map[string]*originRobots)}
+}
+
+// check applies the robots.txt of u's origin to u. The robots.txt request counts against the
+// host's rate limit like the link checks themselves.
+func (c *robotsCache) check(ctx context.Context, u *url.URL) (analysis.RobotsResult, error) {
+	origin := u.Scheme + "://" + u.Host
+	c.mu.Lock()
+	entry, ok := c.origins[origin]
+	if !ok {
+		entry = &originRobots{}
+		c.origins[origin] = entry
+	}
+	c.mu.Unlock()
+
+	entry.once.Do(func() {
+		if entry.err = c.limiter.wait(ctx, u.Host); entry.err == nil {
+			entry.rules, entry.err = c.checker.Fetch(ctx, u.String())
+		}
+	})
+	if entry.err != nil {
+		return analysis.RobotsResult{}, entry.err
+	}
+	return entry.rules.Check(u), nil
+}
+
+// hostLimiter spaces out requests to the same host by a minimum interval.
+type hostLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     map[string]time.Time
+}
+
+func newHostLimiter(interval time.Duration) *hostLimiter {
+	return &hostLimiter{interval: interval, next: make(map[string]time.Time)}
+}
+
+// wait blocks until a request to host may be sent or ctx is done.
+func (l *hostLimiter) wait(ctx context.Context, host string) error {
+	l.mu.Lock()
+	now := time.Now()
+	slot := l.next[host]
+	if slot.Before(now) {
+		slot = now
+	}
+	l.next[host] = slot.Add(l.interval)
+	l.mu.Unlock()
+
+	delay := time.Until(slot)
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
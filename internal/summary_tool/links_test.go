@@ -0,0 +1,46 @@
+package summary_tool
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Camelket/mcp-browser-tools/internal/analysis"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckLinkRespectsRobotsTxt(t *testing.T) {
+	var robotsRequests, privateRequests atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/robots.txt", func(w http.ResponseWriter, r *http.Request) {
+		robotsRequests.Add(1)
+		fmt.Fprint(w, "User-agent: *\nDisallow: /private\n")
+	})
+	mux.HandleFunc("/private", func(w http.ResponseWriter, r *http.Request) {
+		privateRequests.Add(1)
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {})
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+
+	limiter := newHostLimiter(0)
+	robots := newRobotsCache(&analysis.RobotsChecker{}, limiter)
+	check := func(path string) LinkStatus {
+		return checkLink(context.Background(), ts.Client(), limiter, robots, ts.URL+path)
+	}
+
+	status := check("/public")
+	assert.Equal(t, http.StatusOK, status.StatusCode)
+	assert.Empty(t, status.Error)
+
+	status = check("/private")
+	assert.Zero(t, status.StatusCode)
+	assert.Contains(t, status.Error, "robots.txt disallows this link")
+	assert.Equal(t, int32(0), privateRequests.Load(), "a disallowed link should not be requested")
+
+	check("/other")
+	assert.Equal(t, int32(1), robotsRequests.Load(), "robots.txt is fetched once per origin")
+}
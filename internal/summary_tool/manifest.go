@@ -0,0 +1,121 @@
+package summary_tool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// manifestTimeout bounds the request for a web app manifest.
+const manifestTimeout = 10 * time.Second
+
+// maxManifestBytes is the largest manifest ExtractManifest reads.
+const maxManifestBytes = 1 << 20
+
+// WebAppManifest holds the fields of a web app manifest used for PWA analysis. StartURL and
+// icon sources are resolved against the manifest URL.
+type WebAppManifest struct {
+	URL             string         `json:"url"` // URL the manifest was fetched from
+	Name            string         `json:"name,omitempty"`
+	ShortName       string         `json:"short_name,omitempty"`
+	StartURL        string         `json:"start_url,omitempty"`
+	Display         string         `json:"display,omitempty"` // e.g. "standalone" or "browser"
+	BackgroundColor string         `json:"background_color,omitempty"`
+	ThemeColor      string         `json:"theme_color,omitempty"`
+	Icons           []ManifestIcon `json:"icons"`
+}
+
+// ManifestIcon is an icon listed in a web app manifest.
+type ManifestIcon struct {
+	Src     string `json:"src"`
+	Sizes   string `json:"sizes,omitempty"`   // e.g. "192x192" or "any"
+	Type    string `json:"type,omitempty"`    // e.g. "image/png"
+	Purpose string `json:"purpose,omitempty"` // e.g. "maskable"
+}
+
+// ExtractManifest fetches and parses the web app manifest linked from the page with
+// <link rel="manifest">. It returns nil, not an error, if the page links no manifest.
+// The manifest is fetched without the page's cookies, through the URL policy if one is set.
+func (st *SummaryTool) ExtractManifest(ctx context.Context, page playwright.Page) (*WebAppManifest, error) {
+	if page == nil {
+		return nil, fmt.Errorf("playwright.Page cannot be nil")
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	result, err := page.Evaluate(`() => document.querySelector('link[rel="manifest"]')?.href || ''`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find manifest link: %w", err)
+	}
+	manifestURL, _ := result.(string)
+	if manifestURL == "" {
+		return nil, nil
+	}
+
+	client := &http.Client{Timeout: manifestTimeout}
+	if policy := st.playwright.URLPolicy(); policy != nil {
+		client.Transport = policy.Transport()
+	}
+	return fetchManifest(ctx, client, manifestURL)
+}
+
+// fetchManifest requests and parses the manifest at manifestURL.
+func fetchManifest(ctx context.Context, client *http.Client, manifestURL string) (*WebAppManifest, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, manifestURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid manifest URL %q: %w", manifestURL, err)
+	}
+	req.Header.Set("Accept", "application/manifest+json, application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch manifest %s: %w", manifestURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch manifest %s: status %d", manifestURL, resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxManifestBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest %s: %w", manifestURL, err)
+	}
+	if len(data) > maxManifestBytes {
+		return nil, fmt.Errorf("manifest %s is larger than %d bytes", manifestURL, maxManifestBytes)
+	}
+	return parseManifest(data, resp.Request.URL)
+}
+
+// parseManifest decodes a manifest fetched from base and resolves its URLs against it.
+func parseManifest(data []byte, base *url.URL) (*WebAppManifest, error) {
+	var manifest WebAppManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest %s: %w", base, err)
+	}
+	manifest.URL = base.String()
+	resolve := func(ref string) string {
+		if ref == "" {
+			return ""
+		}
+		u, err := base.Parse(ref)
+		if err != nil {
+			return ref
+		}
+		return u.String()
+	}
+	manifest.StartURL = resolve(manifest.StartURL)
+	icons := []ManifestIcon{}
+	for _, icon := range manifest.Icons {
+		if icon.Src == "" {
+			continue
+		}
+		icon.Src = resolve(icon.Src)
+		icons = append(icons, icon)
+	}
+	manifest.Icons = icons
+	return &manifest, nil
+}
@@ -0,0 +1,58 @@
+package summary_tool
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFetchManifest(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/app/manifest.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/manifest+json")
+		fmt.Fprint(w, `{
+			"name": "Example App",
+			"short_name": "Example",
+			"start_url": "./?source=pwa",
+			"display": "standalone",
+			"background_color": "#ffffff",
+			"theme_color": "#3367d6",
+			"icons": [
+				{"src": "/icons/192.png", "sizes": "192x192", "type": "image/png"},
+				{"src": "icons/512.png", "sizes": "512x512", "type": "image/png", "purpose": "maskable"},
+				{"sizes": "any"}
+			]
+		}`)
+	})
+	mux.HandleFunc("/broken.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name":`)
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	manifest, err := fetchManifest(context.Background(), ts.Client(), ts.URL+"/app/manifest.json")
+	if assert.NoError(t, err) {
+		assert.Equal(t, &WebAppManifest{
+			URL:             ts.URL + "/app/manifest.json",
+			Name:            "Example App",
+			ShortName:       "Example",
+			StartURL:        ts.URL + "/app/?source=pwa",
+			Display:         "standalone",
+			BackgroundColor: "#ffffff",
+			ThemeColor:      "#3367d6",
+			Icons: []ManifestIcon{
+				{Src: ts.URL + "/icons/192.png", Sizes: "192x192", Type: "image/png"},
+				{Src: ts.URL + "/app/icons/512.png", Sizes: "512x512", Type: "image/png", Purpose: "maskable"},
+			},
+		}, manifest)
+	}
+
+	_, err = fetchManifest(context.Background(), ts.Client(), ts.URL+"/missing.json")
+	assert.ErrorContains(t, err, "status 404")
+	_, err = fetchManifest(context.Background(), ts.Client(), ts.URL+"/broken.json")
+	assert.ErrorContains(t, err, "failed to parse manifest")
+}
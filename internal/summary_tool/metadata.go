@@ -0,0 +1,84 @@
+package summary_tool
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// PageMetadata holds the preview metadata of a page, as found in <title>, <meta> and <link> tags.
+type PageMetadata struct {
+	Title        string            `json:"title"`
+	Description  string            `json:"description"`
+	CanonicalURL string            `json:"canonical_url"`
+	OpenGraph    map[string]string `json:"open_graph"` // og:* properties keyed without the "og:" prefix
+	Twitter      map[string]string `json:"twitter"`    // twitter:* card fields keyed without the "twitter:" prefix
+}
+
+// ExtractMetadata scans the HTML for the page title, description, canonical URL,
+// and OpenGraph and Twitter card fields. The first occurrence of each field wins.
+func (st *SummaryTool) ExtractMetadata(htmlContent string) (*PageMetadata, error) {
+	doc, err := html.Parse(strings.NewReader(htmlContent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	metadata := &PageMetadata{
+		OpenGraph: make(map[string]string),
+		Twitter:   make(map[string]string),
+	}
+
+	var f func(*html.Node)
+	f = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			switch n.Data {
+			case "title":
+				if metadata.Title == "" {
+					metadata.Title = strings.TrimSpace(nodeText(n))
+				}
+			case "meta":
+				key := strings.ToLower(attr(n, "property"))
+				if key == "" {
+					key = strings.ToLower(attr(n, "name"))
+				}
+				content := strings.TrimSpace(attr(n, "content"))
+				switch {
+				case key == "description" && metadata.Description == "":
+					metadata.Description = content
+				case strings.HasPrefix(key, "og:"):
+					setFirst(metadata.OpenGraph, strings.TrimPrefix(key, "og:"), content)
+				case strings.HasPrefix(key, "twitter:"):
+					setFirst(metadata.Twitter, strings.TrimPrefix(key, "twitter:"), content)
+				}
+			case "link":
+				if metadata.CanonicalURL == "" && strings.EqualFold(attr(n, "rel"), "canonical") {
+					metadata.CanonicalURL = attr(n, "href")
+				}
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			f(c)
+		}
+	}
+	f(doc)
+
+	return metadata, nil
+}
+
+// attr returns the value of the named attribute of n, or "" if it is not set.
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+// setFirst stores value under key unless the key is already set.
+func setFirst(m map[string]string, key, value string) {
+	if _, ok := m[key]; !ok {
+		m[key] = value
+	}
+}
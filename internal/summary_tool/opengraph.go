@@ -0,0 +1,67 @@
+package summary_tool
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// OpenGraphData holds the Open Graph protocol fields used for social sharing previews.
+type OpenGraphData struct {
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Image       string `json:"image,omitempty"`
+	URL         string `json:"url,omitempty"`
+	Type        string `json:"type,omitempty"`
+	SiteName    string `json:"site_name,omitempty"`
+	Locale      string `json:"locale,omitempty"`
+}
+
+// extractOpenGraph collects the <meta property="og:*"> tags of a page. The first occurrence of
+// each field wins. It returns nil, not an error, if the page has no Open Graph tags.
+func extractOpenGraph(htmlContent string) (*OpenGraphData, error) {
+	doc, err := html.Parse(strings.NewReader(htmlContent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	og := &OpenGraphData{}
+	fields := map[string]*string{
+		"og:title":       &og.Title,
+		"og:description": &og.Description,
+		"og:image":       &og.Image,
+		"og:url":         &og.URL,
+		"og:type":        &og.Type,
+		"og:site_name":   &og.SiteName,
+		"og:locale":      &og.Locale,
+	}
+	found := false
+
+	var f func(*html.Node)
+	f = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.Data == "meta" {
+			property := strings.ToLower(attr(n, "property"))
+			if strings.HasPrefix(property, "og:") {
+				found = true
+				if field, ok := fields[property]; ok && *field == "" {
+					*field = strings.TrimSpace(attr(n, "content"))
+				}
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			f(c)
+		}
+	}
+	f(doc)
+
+	if !found {
+		return nil, nil
+	}
+	return og, nil
+}
+
+// ExtractOpenGraph returns the page's Open Graph fields, or nil if it has none.
+func (st *SummaryTool) ExtractOpenGraph(htmlContent string) (*OpenGraphData, error) {
+	return extractOpenGraph(htmlContent)
+}
@@ -0,0 +1,28 @@
+package summary_tool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractOpenGraph(t *testing.T) {
+	og, err := extractOpenGraph(`<html><head>
+		<meta property="og:title" content=" Launch Day ">
+		<meta property="og:title" content="Ignored duplicate">
+		<meta property="og:image" content="https://example.com/cover.png">
+		<meta property="og:site_name" content="Example">
+		<meta property="og:image:width" content="1200">
+		<meta name="description" content="Not Open Graph">
+	</head></html>`)
+	assert.NoError(t, err)
+	assert.Equal(t, &OpenGraphData{
+		Title:    "Launch Day",
+		Image:    "https://example.com/cover.png",
+		SiteName: "Example",
+	}, og)
+
+	og, err = extractOpenGraph(`<html><head><title>Plain</title></head></html>`)
+	assert.NoError(t, err)
+	assert.Nil(t, og)
+}
@@ -0,0 +1,279 @@
+/*
+ * A compact implementation of the Readability API (https://github.com/mozilla/readability):
+ * `new Readability(document).parse()` returns the main article of a page as
+ * {title, byline, content, textContent, excerpt, length}, or null if the page has no
+ * article-like content. Like the original, it scores paragraphs, propagates the scores to
+ * their ancestors, picks the best container and keeps related siblings. Pass a clone of the
+ * document (document.cloneNode(true)) to leave the live page untouched.
+ */
+(function (global) {
+  "use strict";
+
+  var UNLIKELY = /-ad-|ai2html|banner|breadcrumbs|combx|comment|community|cover-wrap|disqus|extra|footer|gdpr|header|legends|menu|related|remark|replies|rss|shoutbox|sidebar|skyscraper|social|sponsor|supplemental|ad-break|agegate|pagination|pager|popup|yom-remote|cookie|newsletter|share/i;
+  var MAYBE = /and|article|body|column|content|main|shadow|post|story|text/i;
+  var POSITIVE = /article|body|content|entry|hentry|h-entry|main|page|pagination|post|text|blog|story/i;
+  var NEGATIVE = /-ad-|hidden|^hid$| hid$| hid |^hid |banner|combx|comment|com-|contact|footer|gdpr|masthead|media|meta|outbrain|promo|related|scroll|share|shoutbox|sidebar|skyscraper|sponsor|shopping|tags|widget/i;
+  var REMOVE_TAGS = "script, style, noscript, template, iframe, object, embed, form, button, input, select, textarea, nav, aside, footer, svg, canvas, link, meta";
+  var SCORED_TAGS = { P: 1, PRE: 1, TD: 1, BLOCKQUOTE: 1, SECTION: 1, H2: 1, H3: 1, H4: 1 };
+  var TITLE_SEPARATOR = /\s+[|\-–—\\/>»]\s+/;
+
+  function Readability(doc, options) {
+    if (!doc || !doc.documentElement) {
+      throw new Error("Readability needs a document");
+    }
+    this._doc = doc;
+    this._charThreshold = (options && options.charThreshold) || 140;
+  }
+
+  function textOf(node) {
+    return (node.textContent || "").replace(/\s+/g, " ").trim();
+  }
+
+  function classWeight(node) {
+    var weight = 0;
+    [node.className, node.id].forEach(function (value) {
+      if (typeof value !== "string" || !value) {
+        return;
+      }
+      if (NEGATIVE.test(value)) {
+        weight -= 25;
+      }
+      if (POSITIVE.test(value)) {
+        weight += 25;
+      }
+    });
+    return weight;
+  }
+
+  function baseScore(node) {
+    switch (node.tagName) {
+      case "DIV":
+      case "ARTICLE":
+      case "MAIN":
+        return 5;
+      case "PRE":
+      case "TD":
+      case "BLOCKQUOTE":
+        return 3;
+      case "ADDRESS":
+      case "OL":
+      case "UL":
+      case "DL":
+      case "DD":
+      case "DT":
+      case "LI":
+        return -3;
+      case "H1":
+      case "H2":
+      case "H3":
+      case "H4":
+      case "H5":
+      case "H6":
+      case "TH":
+        return -5;
+    }
+    return 0;
+  }
+
+  function linkDensity(node) {
+    var length = textOf(node).length;
+    if (!length) {
+      return 0;
+    }
+    var linkLength = 0;
+    node.querySelectorAll("a").forEach(function (a) {
+      var href = a.getAttribute("href");
+      var coefficient = href && /^#./.test(href) ? 0.3 : 1;
+      linkLength += textOf(a).length * coefficient;
+    });
+    return linkLength / length;
+  }
+
+  Readability.prototype._removeClutter = function (body) {
+    body.querySelectorAll(REMOVE_TAGS).forEach(function (node) {
+      node.remove();
+    });
+    body.querySelectorAll("[hidden], [aria-hidden=true], [role=dialog], [role=navigation], [role=complementary]").forEach(function (node) {
+      node.remove();
+    });
+    body.querySelectorAll("*").forEach(function (node) {
+      if (!node.isConnected || node.tagName === "BODY" || node.tagName === "A") {
+        return;
+      }
+      var match = (typeof node.className === "string" ? node.className : "") + " " + node.id;
+      if (UNLIKELY.test(match) && !MAYBE.test(match) && !node.closest("article, main, table, code")) {
+        node.remove();
+      }
+    });
+  };
+
+  Readability.prototype._grabArticle = function (body) {
+    var candidates = [];
+    var scores = new Map();
+
+    function initialize(node) {
+      if (!scores.has(node)) {
+        scores.set(node, baseScore(node) + classWeight(node));
+        candidates.push(node);
+      }
+    }
+
+    body.querySelectorAll("*").forEach(function (node) {
+      if (!SCORED_TAGS[node.tagName]) {
+        return;
+      }
+      var text = textOf(node);
+      if (text.length < 25) {
+        return;
+      }
+      var score = 1 + text.split(/[,，、]/).length - 1 + Math.min(Math.floor(text.length / 100), 3);
+      var ancestor = node.parentElement;
+      for (var level = 0; ancestor && level < 3; level++, ancestor = ancestor.parentElement) {
+        if (ancestor.tagName === "HTML") {
+          break;
+        }
+        initialize(ancestor);
+        var divider = level === 0 ? 1 : level === 1 ? 2 : level * 3;
+        scores.set(ancestor, scores.get(ancestor) + score / divider);
+      }
+    });
+    if (!candidates.length) {
+      return null;
+    }
+
+    var top = null;
+    var topScore = -Infinity;
+    candidates.forEach(function (node) {
+      var score = scores.get(node) * (1 - linkDensity(node));
+      scores.set(node, score);
+      if (score > topScore) {
+        top = node;
+        topScore = score;
+      }
+    });
+
+    // Prefer a parent that holds most of the content, e.g. when paragraphs are split across divs.
+    var parent = top.parentElement;
+    while (parent && parent.tagName !== "BODY" && scores.has(parent) && scores.get(parent) >= topScore * 0.75) {
+      top = parent;
+      topScore = scores.get(parent);
+      parent = top.parentElement;
+    }
+
+    var article = this._doc.createElement("div");
+    var siblings = top.parentElement ? Array.prototype.slice.call(top.parentElement.children) : [top];
+    var threshold = Math.max(10, topScore * 0.2);
+    siblings.forEach(function (sibling) {
+      var keep = sibling === top;
+      if (!keep && scores.has(sibling)) {
+        keep = scores.get(sibling) >= threshold;
+      }
+      if (!keep && sibling.tagName === "P") {
+        var text = textOf(sibling);
+        var density = linkDensity(sibling);
+        keep = (text.length > 80 && density < 0.25) || (text.length > 0 && density === 0 && /\.( |$)/.test(text));
+      }
+      if (keep) {
+        article.appendChild(sibling);
+      }
+    });
+    return article;
+  };
+
+  Readability.prototype._clean = function (article) {
+    article.querySelectorAll("ul, ol, div, section, table").forEach(function (node) {
+      if (!node.isConnected || node.closest("pre, code")) {
+        return;
+      }
+      var text = textOf(node);
+      if (text.length < 200 && linkDensity(node) > 0.5 && !node.querySelector("img")) {
+        node.remove();
+      }
+    });
+    article.querySelectorAll("p").forEach(function (node) {
+      if (!textOf(node) && !node.querySelector("img, picture, video")) {
+        node.remove();
+      }
+    });
+    article.querySelectorAll("*").forEach(function (node) {
+      ["style", "class", "id", "align", "onclick"].forEach(function (name) {
+        node.removeAttribute(name);
+      });
+    });
+  };
+
+  Readability.prototype._meta = function (selectors) {
+    for (var i = 0; i < selectors.length; i++) {
+      var node = this._doc.querySelector(selectors[i]);
+      if (!node) {
+        continue;
+      }
+      var value = node.getAttribute("content") || textOf(node);
+      if (value && value.trim()) {
+        return value.trim();
+      }
+    }
+    return "";
+  };
+
+  Readability.prototype._title = function () {
+    var title = this._meta(['meta[property="og:title"]', 'meta[name="twitter:title"]']);
+    if (title) {
+      return title;
+    }
+    var titleNode = this._doc.querySelector("title");
+    title = titleNode ? textOf(titleNode) : "";
+    var headings = this._doc.querySelectorAll("h1");
+    if (headings.length === 1 && textOf(headings[0]) && (!title || title.indexOf(textOf(headings[0])) !== -1)) {
+      return textOf(headings[0]);
+    }
+    var parts = title.split(TITLE_SEPARATOR);
+    if (parts.length > 1 && parts[0].split(/\s+/).length >= 3) {
+      return parts[0];
+    }
+    return title;
+  };
+
+  Readability.prototype.parse = function () {
+    var title = this._title();
+    var byline = this._meta([
+      'meta[name="author"]',
+      'meta[property="article:author"]',
+      '[rel="author"]',
+      '[itemprop="author"]',
+      ".byline",
+      ".author",
+    ]);
+    var excerpt = this._meta(['meta[name="description"]', 'meta[property="og:description"]']);
+
+    var body = this._doc.body;
+    if (!body) {
+      return null;
+    }
+    this._removeClutter(body);
+    var article = this._grabArticle(body);
+    if (!article) {
+      return null;
+    }
+    this._clean(article);
+
+    var textContent = textOf(article);
+    if (textContent.length < this._charThreshold) {
+      return null;
+    }
+    if (!excerpt) {
+      var first = article.querySelector("p");
+      excerpt = first ? textOf(first) : "";
+    }
+    return {
+      title: title,
+      byline: byline,
+      content: article.innerHTML,
+      textContent: textContent,
+      excerpt: excerpt,
+      length: textContent.length,
+    };
+  };
+
+  global.Readability = Readability;
+})(typeof window !== "undefined" ? window : this);
@@ -0,0 +1,65 @@
+package summary_tool
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// StructuredData holds the JSON-LD items found on a page.
+type StructuredData struct {
+	Items   []any `json:"items"`   // Parsed JSON-LD objects; top-level arrays are flattened
+	Skipped int   `json:"skipped"` // Number of JSON-LD blocks that could not be parsed
+}
+
+// ExtractStructuredData finds all <script type="application/ld+json"> blocks in the HTML
+// and combines their parsed contents. Malformed blocks are skipped and counted.
+func (st *SummaryTool) ExtractStructuredData(htmlContent string) (*StructuredData, error) {
+	doc, err := html.Parse(strings.NewReader(htmlContent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	data := &StructuredData{Items: []any{}}
+
+	var f func(*html.Node)
+	f = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.Data == "script" && isJSONLDScript(n) {
+			var text strings.Builder
+			for c := n.FirstChild; c != nil; c = c.NextSibling {
+				if c.Type == html.TextNode {
+					text.WriteString(c.Data)
+				}
+			}
+
+			var item any
+			if err := json.Unmarshal([]byte(text.String()), &item); err != nil {
+				st.logger.Warn("Skipping malformed JSON-LD block", "error", err)
+				data.Skipped++
+			} else if items, ok := item.([]any); ok {
+				data.Items = append(data.Items, items...)
+			} else {
+				data.Items = append(data.Items, item)
+			}
+			return
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			f(c)
+		}
+	}
+	f(doc)
+
+	return data, nil
+}
+
+// isJSONLDScript reports whether a <script> element holds JSON-LD.
+func isJSONLDScript(n *html.Node) bool {
+	for _, a := range n.Attr {
+		if a.Key == "type" {
+			return strings.EqualFold(strings.TrimSpace(a.Val), "application/ld+json")
+		}
+	}
+	return false
+}
@@ -2,11 +2,14 @@ package summary_tool
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/url"
 	"strings"
+	"time"
 
+	"github.com/Camelket/mcp-browser-tools/internal/analysis"
 	"github.com/Camelket/mcp-browser-tools/internal/playwright_integration"
 	"golang.org/x/net/html"
 )
@@ -19,11 +22,50 @@ type SummaryTool struct {
 
 // PageSummary holds the captured URL, HTML content, screenshot data, extracted links, and network activity.
 type PageSummary struct {
-	URL             string
-	HTML            string
-	Screenshot      []byte
-	Links           []string
-	NetworkActivity []playwright_integration.CapturedNetworkActivity
+	URL                  string                                           `json:"url"`         // URL as requested
+	FinalURL             string                                           `json:"final_url"`   // URL after redirects
+	StatusCode           int                                              `json:"status_code"` // HTTP status of the main document
+	Certificate          *playwright_integration.CertificateDetails       `json:"certificate,omitempty"`
+	MainDocumentProtocol string                                           `json:"main_document_protocol,omitempty"` // e.g. "h2" or "http/1.1"; Chromium only
+	UserAgent            string                                           `json:"user_agent"`                       // User-Agent the page was loaded with
+	HTML                 string                                           `json:"html"`
+	Screenshot           []byte                                           `json:"screenshot,omitempty"` // PNG data, base64 encoded in JSON
+	Links                []Link                                           `json:"links"`
+	Headings             []Heading                                        `json:"headings"`
+	Tables               []TableData                                      `json:"tables"`
+	NetworkActivity      []playwright_integration.CapturedNetworkActivity `json:"network_activity"`
+	Frameworks           []string                                         `json:"frameworks"`                   // Detected frontend frameworks, e.g. "React"
+	ThirdPartyDomains    int                                              `json:"third_party_domains"`          // External registered domains the page contacted
+	ThirdPartyRequests   int                                              `json:"third_party_requests"`         // Requests to those domains
+	MixedContentURLs     []string                                         `json:"mixed_content_urls,omitempty"` // http:// resources loaded by an HTTPS page
+	BlockedRequests      int                                              `json:"blocked_requests"`             // Requests aborted by block rules, which may explain missing images or styles
+	Metadata             *PageMetadata                                    `json:"metadata,omitempty"`
+	Article              *Article                                         `json:"article,omitempty"` // Main content, nil if the page is not an article
+	ContentStats         *ContentStats                                    `json:"content_stats,omitempty"`
+	OpenGraph            *OpenGraphData                                   `json:"open_graph,omitempty"`
+	TwitterCard          *TwitterCardData                                 `json:"twitter_card,omitempty"`
+	Manifest             *WebAppManifest                                  `json:"manifest,omitempty"` // Web app manifest, nil if the page links none
+	Timings              *analysis.NavigationTimings                      `json:"timings,omitempty"`  // Load milestones of the main document
+}
+
+// defaultNavigationTimeout bounds the navigation of CapturePageSummary when SummaryOptions
+// does not set a timeout. The MCP tools always pass the per-call timeout_ms instead.
+const defaultNavigationTimeout = 60 * time.Second
+
+// SummaryOptions configures how CapturePageSummary loads the page.
+type SummaryOptions struct {
+	// Interception is passed to SetupNetworkInterception, e.g. to mock API responses.
+	Interception *playwright_integration.InterceptionOptions
+	// Navigation is passed to NavigateToURLOnPage. A zero Timeout defaults to 60 seconds.
+	Navigation *playwright_integration.NavigationOptions
+	// Page configures the browser context of the captured page, e.g. for device emulation.
+	Page *playwright_integration.PageOptions
+	// IncludeMetadata adds the page's title, description and social preview fields to the summary.
+	IncludeMetadata bool
+	// ViewportOnly limits the screenshot to the viewport instead of the full scrollable page.
+	ViewportOnly bool
+	// Progress, if set, receives a report as each stage of the capture completes.
+	Progress playwright_integration.ProgressFunc
 }
 
 // NewSummaryTool creates and returns a new SummaryTool instance.
@@ -34,11 +76,16 @@ func NewSummaryTool(pw *playwright_integration.PlaywrightIntegration, logger *sl
 	}
 }
 
+// Playwright returns the PlaywrightIntegration used by the summary tool.
+func (st *SummaryTool) Playwright() *playwright_integration.PlaywrightIntegration {
+	return st.playwright
+}
+
 // CapturePageSummary navigates to a URL, captures its HTML content, a full-page screenshot, and network activity.
-func (st *SummaryTool) CapturePageSummary(ctx context.Context, url string) (*PageSummary, error) {
+func (st *SummaryTool) CapturePageSummary(ctx context.Context, url string, opts SummaryOptions) (*PageSummary, error) {
 	st.logger.Info("Capturing page summary", "url", url)
 
-	page, err := st.playwright.NewPage(ctx)
+	page, err := st.playwright.NewPage(ctx, opts.Page)
 	if err != nil {
 		st.logger.Error("Failed to create new page", "error", err)
 		return nil, fmt.Errorf("failed to create new page: %w", err)
@@ -49,64 +96,208 @@ func (st *SummaryTool) CapturePageSummary(ctx context.Context, url string) (*Pag
 		}
 	}()
 
+	opts.Progress.Report(0.05, "Page created")
+
 	// Setup network interception before navigation
-	if err := st.playwright.SetupNetworkInterception(ctx, page); err != nil {
+	if err := st.playwright.SetupNetworkInterception(ctx, page, opts.Interception); err != nil {
 		st.logger.Error("Failed to set up network interception", "error", err)
 		return nil, fmt.Errorf("failed to set up network interception: %w", err)
 	}
 
-	// Use the PlaywrightIntegration's NavigateToURL function to leverage its timeout and logging.
-	// Temporarily setting a 60-second timeout for debugging.
-	page, err = st.playwright.NavigateToURL(ctx, url, nil, 60.0) // 60 seconds timeout
+	// Navigate the intercepted page so mocks and captured network data apply to it.
+	navigation := playwright_integration.NavigationOptions{}
+	if opts.Navigation != nil {
+		navigation = *opts.Navigation
+	}
+	if navigation.Timeout == 0 {
+		navigation.Timeout = defaultNavigationTimeout
+	}
+	if opts.Progress != nil {
+		navigation.Progress = opts.Progress.Scale(0.05, 0.5)
+	}
+	status, err := st.playwright.NavigateToURLOnPage(ctx, page, url, &navigation)
 	if err != nil {
 		st.logger.Error("Failed to navigate to URL", "url", url, "error", err)
 		return nil, fmt.Errorf("failed to navigate to %s: %w", url, err)
 	}
 
-	htmlContent, err := page.Content()
+	htmlContent, err := st.playwright.GetContent(ctx, page)
 	if err != nil {
 		st.logger.Error("Failed to get HTML content", "url", url, "error", err)
 		return nil, fmt.Errorf("failed to get HTML content for %s: %w", url, err)
 	}
+	opts.Progress.Report(0.6, "Content captured")
+
+	// Relative links resolve against the page the browser landed on, not the requested URL.
+	finalURL := page.URL()
+	if finalURL != url {
+		st.logger.Info("Page was redirected", "url", url, "final_url", finalURL)
+	}
+
+	var userAgent string
+	if ua, err := page.Evaluate("() => navigator.userAgent"); err != nil {
+		st.logger.Warn("Failed to read user agent", "url", url, "error", err)
+	} else {
+		userAgent, _ = ua.(string)
+	}
 
-	screenshot, err := st.playwright.CaptureScreenshot(ctx, page, playwright_integration.PageScreenshotOptions{FullPage: true})
+	screenshot, err := st.playwright.CaptureScreenshot(ctx, page, playwright_integration.PageScreenshotOptions{FullPage: !opts.ViewportOnly})
+	var tooTall *playwright_integration.ScreenshotTooTallError
+	if errors.As(err, &tooTall) {
+		// Keep the summary usable for very long pages by capturing only the top of the page.
+		st.logger.Warn("Page too tall for a full-page screenshot, capturing the top only", "url", url, "height", tooTall.Height)
+		screenshot, err = st.playwright.CaptureScreenshot(ctx, page, playwright_integration.PageScreenshotOptions{
+			FullPage: true,
+			Clip:     &playwright_integration.Rect{Width: float64(tooTall.Width), Height: float64(tooTall.MaxHeight)},
+		})
+	}
 	if err != nil {
 		st.logger.Error("Failed to capture screenshot", "url", url, "error", err)
 		return nil, fmt.Errorf("failed to capture screenshot for %s: %w", url, err)
 	}
+	opts.Progress.Report(0.85, "Screenshot captured")
 
 	// Get captured network data
-	networkActivity := st.playwright.GetCapturedNetworkData()
+	networkActivity := st.playwright.GetCapturedNetworkData(page)
 	st.logger.Info("Captured network activity", "count", len(networkActivity), "url", url)
 
 	st.logger.Info("Successfully captured page summary", "url", url)
 
-	links, err := st.extractLinks(htmlContent, url)
+	links, err := st.extractLinks(htmlContent, finalURL)
 	if err != nil {
 		st.logger.Error("Failed to extract links", "url", url, "error", err)
 		// Continue even if link extraction fails, as it's not critical for the summary itself
 	} else {
 		st.logger.Info("Extracted links", "count", len(links), "url", url)
 	}
+	opts.Progress.Report(0.9, "Links extracted")
+
+	headings, err := extractHeadings(htmlContent)
+	if err != nil {
+		st.logger.Error("Failed to extract headings", "url", url, "error", err)
+	}
+
+	tables, err := extractTables(htmlContent)
+	if err != nil {
+		st.logger.Error("Failed to extract tables", "url", url, "error", err)
+	}
+
+	openGraph, err := extractOpenGraph(htmlContent)
+	if err != nil {
+		st.logger.Error("Failed to extract Open Graph data", "url", url, "error", err)
+	}
+
+	twitterCard, err := extractTwitterCard(htmlContent)
+	if err != nil {
+		st.logger.Error("Failed to extract Twitter card", "url", url, "error", err)
+	}
+
+	manifest, err := st.ExtractManifest(ctx, page)
+	if err != nil {
+		st.logger.Error("Failed to extract web app manifest", "url", url, "error", err)
+	}
+
+	frameworks, err := analysis.DetectFramework(page)
+	if err != nil {
+		st.logger.Error("Failed to detect frameworks", "url", url, "error", err)
+	}
+
+	var timings *analysis.NavigationTimings
+	if measured, err := analysis.MeasureNavigationTimings(page); err != nil {
+		st.logger.Error("Failed to measure navigation timings", "url", url, "error", err)
+	} else {
+		timings = &measured
+	}
+
+	contentStats, err := st.ExtractContentStats(ctx, page)
+	if err != nil {
+		st.logger.Error("Failed to compute content stats", "url", url, "error", err)
+	}
+
+	article, err := st.ExtractArticle(ctx, page)
+	if errors.Is(err, ErrNotArticle) {
+		st.logger.Debug("Page is not an article", "url", url)
+	} else if err != nil {
+		st.logger.Error("Failed to extract article", "url", url, "error", err)
+	}
+
+	var thirdPartyDomains, thirdPartyRequests int
+	if thirdParty, err := analysis.ListThirdPartyResources(finalURL, networkActivity); err != nil {
+		st.logger.Error("Failed to list third-party resources", "url", url, "error", err)
+	} else {
+		thirdPartyDomains = len(thirdParty)
+		for _, resource := range thirdParty {
+			thirdPartyRequests += resource.RequestCount
+		}
+	}
+
+	var metadata *PageMetadata
+	if opts.IncludeMetadata {
+		if metadata, err = st.ExtractMetadata(htmlContent); err != nil {
+			st.logger.Error("Failed to extract metadata", "url", url, "error", err)
+		}
+	}
 
+	opts.Progress.Report(1, "Page summary complete")
 	return &PageSummary{
-		URL:             url,
-		HTML:            htmlContent,
-		Screenshot:      screenshot,
-		Links:           links,
-		NetworkActivity: networkActivity,
+		URL:                  url,
+		FinalURL:             finalURL,
+		StatusCode:           status.StatusCode,
+		Certificate:          status.Certificate,
+		MainDocumentProtocol: st.playwright.DocumentProtocol(page, finalURL),
+		UserAgent:            userAgent,
+		HTML:                 htmlContent,
+		Screenshot:           screenshot,
+		Links:                links,
+		Headings:             headings,
+		Tables:               tables,
+		NetworkActivity:      networkActivity,
+		Frameworks:           frameworks,
+		ThirdPartyDomains:    thirdPartyDomains,
+		ThirdPartyRequests:   thirdPartyRequests,
+		MixedContentURLs:     analysis.DetectMixedContent(finalURL, networkActivity),
+		BlockedRequests:      st.playwright.GetBlockedRequestCount(page),
+		Metadata:             metadata,
+		Article:              article,
+		ContentStats:         contentStats,
+		OpenGraph:            openGraph,
+		TwitterCard:          twitterCard,
+		Manifest:             manifest,
+		Timings:              timings,
 	}, nil
 }
 
-// extractLinks parses the HTML content and extracts all unique, absolute URLs from <a> tags.
-func (st *SummaryTool) extractLinks(htmlContent string, baseURL string) ([]string, error) {
+// Link is a hyperlink found on a page.
+type Link struct {
+	Href string `json:"href"`           // Absolute URL
+	Text string `json:"text,omitempty"` // Anchor text with whitespace collapsed, or its aria-label
+}
+
+// LinkURLs returns the URLs of the links, the form Links had before it carried anchor text.
+func (ps *PageSummary) LinkURLs() []string {
+	return linkHrefs(ps.Links)
+}
+
+// linkHrefs returns the URLs of links.
+func linkHrefs(links []Link) []string {
+	hrefs := make([]string, len(links))
+	for i, link := range links {
+		hrefs[i] = link.Href
+	}
+	return hrefs
+}
+
+// extractLinks parses the HTML content and extracts all unique, absolute URLs from <a> tags
+// along with their anchor text. When a URL is linked more than once, the first non-empty
+// text is kept.
+func (st *SummaryTool) extractLinks(htmlContent string, baseURL string) ([]Link, error) {
 	doc, err := html.Parse(strings.NewReader(htmlContent))
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse HTML: %w", err)
 	}
 
-	var links []string
-	visited := make(map[string]bool)
+	var links []Link
+	visited := make(map[string]int) // Index into links by URL
 	base, err := url.Parse(baseURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse base URL %s: %w", baseURL, err)
@@ -123,9 +314,12 @@ func (st *SummaryTool) extractLinks(htmlContent string, baseURL string) ([]strin
 						continue
 					}
 					resolvedURL := base.ResolveReference(parsedURL).String()
-					if _, ok := visited[resolvedURL]; !ok {
-						links = append(links, resolvedURL)
-						visited[resolvedURL] = true
+					text := anchorText(n)
+					if i, ok := visited[resolvedURL]; !ok {
+						visited[resolvedURL] = len(links)
+						links = append(links, Link{Href: resolvedURL, Text: text})
+					} else if links[i].Text == "" {
+						links[i].Text = text
 					}
 					break
 				}
@@ -139,3 +333,35 @@ func (st *SummaryTool) extractLinks(htmlContent string, baseURL string) ([]strin
 
 	return links, nil
 }
+
+// anchorText returns the text content of an anchor, accumulated from all nested nodes with
+// whitespace collapsed. Anchors without text, such as icon links, fall back to their aria-label
+// or the alt text of a nested image.
+func anchorText(a *html.Node) string {
+	var sb strings.Builder
+	var alt string
+	var collect func(*html.Node)
+	collect = func(n *html.Node) {
+		switch {
+		case n.Type == html.TextNode:
+			sb.WriteString(n.Data)
+			sb.WriteByte(' ')
+		case n.Type == html.ElementNode && (n.Data == "script" || n.Data == "style"):
+			return
+		case n.Type == html.ElementNode && n.Data == "img" && alt == "":
+			alt = attr(n, "alt")
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			collect(c)
+		}
+	}
+	collect(a)
+
+	if text := strings.Join(strings.Fields(sb.String()), " "); text != "" {
+		return text
+	}
+	if label := strings.TrimSpace(attr(a, "aria-label")); label != "" {
+		return label
+	}
+	return strings.TrimSpace(alt)
+}
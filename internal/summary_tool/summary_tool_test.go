@@ -0,0 +1,30 @@
+package summary_tool
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractLinks(t *testing.T) {
+	st := &SummaryTool{logger: slog.Default()}
+	links, err := st.extractLinks(`
+		<a href="/docs">
+			Read the <strong>full</strong>
+			documentation
+		</a>
+		<a href="https://example.org/"><img src="logo.png" alt="Example logo"></a>
+		<a href="/search" aria-label="Search"><svg></svg></a>
+		<a href="/docs">Docs again</a>
+		<a href="/pricing"></a>
+		<a href="/pricing">Pricing</a>
+	`, "https://example.com/index.html")
+	assert.NoError(t, err)
+	assert.Equal(t, []Link{
+		{Href: "https://example.com/docs", Text: "Read the full documentation"},
+		{Href: "https://example.org/", Text: "Example logo"},
+		{Href: "https://example.com/search", Text: "Search"},
+		{Href: "https://example.com/pricing", Text: "Pricing"},
+	}, links)
+}
@@ -0,0 +1,281 @@
+package summary_tool
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// maxCellSpan caps colspan and rowspan values so malformed markup cannot blow up a table.
+const maxCellSpan = 1000
+
+// TableData holds the contents of an HTML table.
+type TableData struct {
+	Caption string     `json:"caption"`
+	Headers []string   `json:"headers"`
+	Rows    [][]string `json:"rows"`
+}
+
+// tableRow is a row of raw cells before spans are expanded.
+type tableRow struct {
+	cells  []tableCell
+	inHead bool // Row is inside <thead>
+}
+
+type tableCell struct {
+	text    string
+	header  bool // Cell is a <th>
+	colspan int
+	rowspan int
+}
+
+// pendingSpan is a cell that still covers rows below the one it was declared in.
+type pendingSpan struct {
+	text      string
+	remaining int
+}
+
+// extractTables returns all <table> elements in document order. Headers come from the
+// <thead> rows, or from the first row if it consists only of <th> cells. Cells spanning
+// several columns or rows are duplicated into each position they cover, so every row
+// lines up with the headers.
+func extractTables(htmlContent string) ([]TableData, error) {
+	doc, err := html.Parse(strings.NewReader(htmlContent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	tables := []TableData{}
+	var f func(*html.Node)
+	f = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.Data == "table" {
+			tables = append(tables, parseTable(n))
+		}
+		// Keep descending so nested tables are extracted as well.
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			f(c)
+		}
+	}
+	f(doc)
+
+	return tables, nil
+}
+
+// ExtractTables returns the tables found in the HTML, as described by extractTables.
+func (st *SummaryTool) ExtractTables(htmlContent string) ([]TableData, error) {
+	return extractTables(htmlContent)
+}
+
+// TableRecords holds a table as row objects keyed by column header.
+type TableRecords struct {
+	Caption string              `json:"caption"`
+	Headers []string            `json:"headers"`
+	Rows    []map[string]string `json:"rows"`
+}
+
+// Records converts the table into row objects. A table without header cells uses its first
+// row as headers. Empty header names become "column_N" and repeated ones, as produced by a
+// colspan, get a numeric suffix ("Score", "Score_2"), so no cell is lost.
+func (t TableData) Records() TableRecords {
+	headers, rows := t.Headers, t.Rows
+	if len(headers) == 0 && len(rows) > 0 {
+		headers, rows = rows[0], rows[1:]
+	}
+
+	keys := make([]string, len(headers))
+	seen := make(map[string]int, len(headers))
+	for i, header := range headers {
+		key := header
+		if key == "" {
+			key = fmt.Sprintf("column_%d", i+1)
+		}
+		if seen[key]++; seen[key] > 1 {
+			key = fmt.Sprintf("%s_%d", key, seen[key])
+		}
+		keys[i] = key
+	}
+
+	records := TableRecords{Caption: t.Caption, Headers: keys, Rows: make([]map[string]string, 0, len(rows))}
+	for _, row := range rows {
+		record := make(map[string]string, len(row))
+		for i, value := range row {
+			if i >= len(keys) {
+				// Rows wider than the header row get positional keys.
+				record[fmt.Sprintf("column_%d", i+1)] = value
+				continue
+			}
+			record[keys[i]] = value
+		}
+		records.Rows = append(records.Rows, record)
+	}
+	return records
+}
+
+// ExtractTableRecords returns the tables found in the HTML as row objects, see TableData.Records.
+func (st *SummaryTool) ExtractTableRecords(htmlContent string) ([]TableRecords, error) {
+	tables, err := extractTables(htmlContent)
+	if err != nil {
+		return nil, err
+	}
+	records := make([]TableRecords, 0, len(tables))
+	for _, table := range tables {
+		records = append(records, table.Records())
+	}
+	return records, nil
+}
+
+// parseTable converts a <table> element into TableData.
+func parseTable(table *html.Node) TableData {
+	var caption string
+	var rows []tableRow
+
+	var collect func(n *html.Node, inHead bool)
+	collect = func(n *html.Node, inHead bool) {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type != html.ElementNode {
+				continue
+			}
+			switch c.Data {
+			case "caption":
+				if caption == "" {
+					caption = collapseSpace(nodeText(c))
+				}
+			case "thead":
+				collect(c, true)
+			case "tbody", "tfoot":
+				collect(c, false)
+			case "tr":
+				rows = append(rows, parseTableRow(c, inHead))
+			}
+		}
+	}
+	collect(table, false)
+
+	grid := expandSpans(rows)
+	data := TableData{Caption: caption, Headers: []string{}, Rows: [][]string{}}
+
+	headRows := 0
+	for headRows < len(rows) && rows[headRows].inHead {
+		headRows++
+	}
+	if headRows == 0 && len(rows) > 0 && allHeaderCells(rows[0]) {
+		headRows = 1
+	}
+	if headRows > 0 {
+		// With several header rows, the last one holds the most specific column names.
+		data.Headers = grid[headRows-1]
+	}
+	for i := headRows; i < len(grid); i++ {
+		if rows[i].inHead {
+			continue
+		}
+		data.Rows = append(data.Rows, grid[i])
+	}
+	return data
+}
+
+// parseTableRow reads the <th> and <td> cells of a <tr> element.
+func parseTableRow(tr *html.Node, inHead bool) tableRow {
+	row := tableRow{inHead: inHead}
+	for c := tr.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type != html.ElementNode || (c.Data != "td" && c.Data != "th") {
+			continue
+		}
+		row.cells = append(row.cells, tableCell{
+			text:    collapseSpace(nodeText(c)),
+			header:  c.Data == "th",
+			colspan: spanAttr(c, "colspan"),
+			rowspan: spanAttr(c, "rowspan"),
+		})
+	}
+	return row
+}
+
+// expandSpans lays the rows out on a grid, duplicating cells across their colspan and rowspan.
+func expandSpans(rows []tableRow) [][]string {
+	grid := make([][]string, len(rows))
+	spans := make(map[int]pendingSpan) // Column index -> cell spanning down from an earlier row
+
+	for i, row := range rows {
+		out := []string{}
+		col := 0
+		// fillSpans copies cells spanning down from earlier rows into the current position.
+		fillSpans := func() {
+			for {
+				span, ok := spans[col]
+				if !ok {
+					return
+				}
+				out = append(out, span.text)
+				if span.remaining--; span.remaining == 0 {
+					delete(spans, col)
+				} else {
+					spans[col] = span
+				}
+				col++
+			}
+		}
+
+		for _, cell := range row.cells {
+			fillSpans()
+			for j := 0; j < cell.colspan; j++ {
+				out = append(out, cell.text)
+				if cell.rowspan > 1 {
+					spans[col] = pendingSpan{text: cell.text, remaining: cell.rowspan - 1}
+				}
+				col++
+			}
+		}
+		// Spans may also continue past the last cell of this row.
+		for len(spans) > 0 && col <= maxSpanColumn(spans) {
+			if _, ok := spans[col]; ok {
+				fillSpans()
+			} else {
+				out = append(out, "")
+				col++
+			}
+		}
+		grid[i] = out
+	}
+	return grid
+}
+
+// maxSpanColumn returns the highest column index with a pending span.
+func maxSpanColumn(spans map[int]pendingSpan) int {
+	maxCol := -1
+	for col := range spans {
+		if col > maxCol {
+			maxCol = col
+		}
+	}
+	return maxCol
+}
+
+// allHeaderCells reports whether a row consists only of <th> cells.
+func allHeaderCells(row tableRow) bool {
+	if len(row.cells) == 0 {
+		return false
+	}
+	for _, cell := range row.cells {
+		if !cell.header {
+			return false
+		}
+	}
+	return true
+}
+
+// spanAttr parses a colspan or rowspan attribute, defaulting to 1 for missing or invalid values.
+func spanAttr(n *html.Node, key string) int {
+	span, err := strconv.Atoi(strings.TrimSpace(attr(n, key)))
+	if err != nil || span < 1 {
+		return 1
+	}
+	return min(span, maxCellSpan)
+}
+
+// collapseSpace trims s and collapses internal runs of whitespace to single spaces.
+func collapseSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,43 @@
+package summary_tool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractTables_Spans(t *testing.T) {
+	tables, err := extractTables(`
+		<table>
+			<caption> Results </caption>
+			<thead><tr><th>Team</th><th colspan="2">Score</th></tr></thead>
+			<tbody>
+				<tr><td rowspan="2">Red</td><td>1</td><td>2</td></tr>
+				<tr><td>3</td><td>4</td></tr>
+			</tbody>
+		</table>
+		<table><tr><th>Item</th><th>Price</th></tr><tr><td>Tea</td><td>2.50</td></tr></table>`)
+	assert.NoError(t, err)
+	assert.Len(t, tables, 2)
+
+	assert.Equal(t, "Results", tables[0].Caption)
+	assert.Equal(t, []string{"Team", "Score", "Score"}, tables[0].Headers)
+	assert.Equal(t, [][]string{{"Red", "1", "2"}, {"Red", "3", "4"}}, tables[0].Rows)
+
+	assert.Equal(t, []string{"Item", "Price"}, tables[1].Headers)
+	assert.Equal(t, [][]string{{"Tea", "2.50"}}, tables[1].Rows)
+}
+
+func TestTableData_Records(t *testing.T) {
+	tables, err := extractTables(`
+		<table>
+			<tr><td>Name</td><td colspan="2">Score</td><td></td></tr>
+			<tr><td>Ann</td><td>1</td><td>2</td><td>x</td></tr>
+		</table>`)
+	assert.NoError(t, err)
+	if assert.Len(t, tables, 1) {
+		records := tables[0].Records()
+		assert.Equal(t, []string{"Name", "Score", "Score_2", "column_4"}, records.Headers)
+		assert.Equal(t, []map[string]string{{"Name": "Ann", "Score": "1", "Score_2": "2", "column_4": "x"}}, records.Rows)
+	}
+}
@@ -0,0 +1,60 @@
+package summary_tool
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+)
+
+// TruncateHTML shortens htmlContent to at most maxBytes plus a trailing marker comment such as
+// "<!-- truncated: 2.3MB total, 500KB returned -->". The cut is made between tokens, so a tag,
+// comment or attribute is never split; only a text run may be cut, at a UTF-8 boundary.
+// It returns the content unchanged, and false, if it fits or maxBytes is not positive.
+func TruncateHTML(htmlContent string, maxBytes int) (string, bool) {
+	if maxBytes <= 0 || len(htmlContent) <= maxBytes {
+		return htmlContent, false
+	}
+
+	z := html.NewTokenizer(strings.NewReader(htmlContent))
+	end := 0
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+		size := len(z.Raw())
+		if end+size <= maxBytes {
+			end += size
+			continue
+		}
+		if tt == html.TextToken {
+			end = utf8Boundary(htmlContent, maxBytes)
+		}
+		break
+	}
+
+	marker := fmt.Sprintf("<!-- truncated: %s total, %s returned -->", formatBytes(len(htmlContent)), formatBytes(end))
+	return htmlContent[:end] + "\n" + marker, true
+}
+
+// utf8Boundary returns the largest index <= n that does not split a UTF-8 sequence in s.
+func utf8Boundary(s string, n int) int {
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return n
+}
+
+// formatBytes renders a byte count with a binary unit, e.g. "512B", "500KB" or "2.3MB".
+func formatBytes(n int) string {
+	switch {
+	case n >= 1<<20:
+		return strings.TrimSuffix(fmt.Sprintf("%.1f", float64(n)/(1<<20)), ".0") + "MB"
+	case n >= 1<<10:
+		return strings.TrimSuffix(fmt.Sprintf("%.1f", float64(n)/(1<<10)), ".0") + "KB"
+	default:
+		return fmt.Sprintf("%dB", n)
+	}
+}
@@ -0,0 +1,33 @@
+package summary_tool
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTruncateHTML(t *testing.T) {
+	page := `<html><body><a href="/a" title="x > y">link</a><p>héllo wörld</p></body></html>`
+
+	out, truncated := TruncateHTML(page, len(page))
+	assert.False(t, truncated)
+	assert.Equal(t, page, out)
+
+	// A limit inside the <a> tag cuts before it rather than mid-attribute.
+	out, truncated = TruncateHTML(page, strings.Index(page, "title"))
+	assert.True(t, truncated)
+	assert.True(t, strings.HasPrefix(out, "<html><body>\n<!-- truncated: "), out)
+
+	// Text may be cut, but never inside a multi-byte character.
+	limit := strings.Index(page, "é") + 1
+	out, _ = TruncateHTML(page, limit)
+	assert.True(t, strings.HasPrefix(out, page[:limit-1]+"\n"), out)
+	assert.Contains(t, out, "<!-- truncated: 81B total, 51B returned -->")
+}
+
+func TestFormatBytes(t *testing.T) {
+	assert.Equal(t, "512B", formatBytes(512))
+	assert.Equal(t, "500KB", formatBytes(500*1024))
+	assert.Equal(t, "2.3MB", formatBytes(2400000))
+}
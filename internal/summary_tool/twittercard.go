@@ -0,0 +1,69 @@
+package summary_tool
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// TwitterCardData holds the Twitter (X) card fields used for link previews.
+type TwitterCardData struct {
+	Card        string `json:"card,omitempty"` // e.g. "summary" or "summary_large_image"
+	Site        string `json:"site,omitempty"`
+	Creator     string `json:"creator,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Image       string `json:"image,omitempty"`
+}
+
+// extractTwitterCard collects the twitter:* meta tags of a page, declared with either a name
+// or a property attribute. The first occurrence of each field wins. It returns nil, not an
+// error, if the page has no Twitter card tags.
+func extractTwitterCard(htmlContent string) (*TwitterCardData, error) {
+	doc, err := html.Parse(strings.NewReader(htmlContent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	card := &TwitterCardData{}
+	fields := map[string]*string{
+		"twitter:card":        &card.Card,
+		"twitter:site":        &card.Site,
+		"twitter:creator":     &card.Creator,
+		"twitter:title":       &card.Title,
+		"twitter:description": &card.Description,
+		"twitter:image":       &card.Image,
+	}
+	found := false
+
+	var f func(*html.Node)
+	f = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.Data == "meta" {
+			key := strings.ToLower(attr(n, "name"))
+			if key == "" {
+				key = strings.ToLower(attr(n, "property"))
+			}
+			if strings.HasPrefix(key, "twitter:") {
+				found = true
+				if field, ok := fields[key]; ok && *field == "" {
+					*field = strings.TrimSpace(attr(n, "content"))
+				}
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			f(c)
+		}
+	}
+	f(doc)
+
+	if !found {
+		return nil, nil
+	}
+	return card, nil
+}
+
+// ExtractTwitterCard returns the page's Twitter card fields, or nil if it has none.
+func (st *SummaryTool) ExtractTwitterCard(htmlContent string) (*TwitterCardData, error) {
+	return extractTwitterCard(htmlContent)
+}
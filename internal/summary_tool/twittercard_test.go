@@ -0,0 +1,22 @@
+package summary_tool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractTwitterCard(t *testing.T) {
+	card, err := extractTwitterCard(`<html><head>
+		<meta name="twitter:card" content="summary_large_image">
+		<meta property="twitter:site" content="@example">
+		<meta name="twitter:title" content="Launch Day">
+		<meta property="og:title" content="Not a Twitter tag">
+	</head></html>`)
+	assert.NoError(t, err)
+	assert.Equal(t, &TwitterCardData{Card: "summary_large_image", Site: "@example", Title: "Launch Day"}, card)
+
+	card, err = extractTwitterCard(`<html><head><meta property="og:title" content="Only OG"></head></html>`)
+	assert.NoError(t, err)
+	assert.Nil(t, card)
+}
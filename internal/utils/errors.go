@@ -1,7 +1,9 @@
 package utils
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
 	"time"
 )
 
@@ -14,46 +16,68 @@ func (e *RetryableError) Error() string {
 	return fmt.Sprintf("retryable error: %v", e.Err)
 }
 
+// Unwrap returns the underlying error.
+func (e *RetryableError) Unwrap() error {
+	return e.Err
+}
+
 // IsRetryable checks if an error is a RetryableError.
 func IsRetryable(err error) bool {
 	_, ok := err.(*RetryableError)
 	return ok
 }
 
-// withRetry executes a function with retry logic for transient errors.
-// It retries the function up to maxRetries times with an exponential backoff.
-func WithRetry(fn func() error, maxRetries int, initialDelay time.Duration) error {
+// WithRetry executes fn, retrying it while it fails with a RetryableError, for up to
+// maxRetries attempts in total. The delay before the first retry is initialDelay and doubles
+// on each subsequent one. Waiting stops early when ctx is done. Retries are logged to logger,
+// which may be nil.
+func WithRetry(ctx context.Context, logger *slog.Logger, fn func() error, maxRetries int, initialDelay time.Duration) error {
+	var lastErr error
 	for i := 0; i < maxRetries; i++ {
 		err := fn()
 		if err == nil {
 			return nil
 		}
 
-		if IsRetryable(err) {
-			fmt.Printf("Attempt %d failed with retryable error: %v. Retrying in %v...\n", i+1, err, initialDelay)
-			time.Sleep(initialDelay)
-			initialDelay *= 2 // Exponential backoff
-			continue
+		// If it's not a retryable error, return immediately
+		if !IsRetryable(err) {
+			return err
+		}
+		lastErr = err
+		if i == maxRetries-1 {
+			break
 		}
 
-		// If it's not a retryable error, return immediately
-		return err
+		if logger != nil {
+			logger.Debug("Attempt failed with retryable error", "attempt", i+1, "error", err, "retry_in", initialDelay)
+		}
+		timer := time.NewTimer(initialDelay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%w; last attempt failed: %v", ctx.Err(), lastErr)
+		case <-timer.C:
+		}
+		initialDelay *= 2 // Exponential backoff
 	}
-	return fmt.Errorf("function failed after %d retries", maxRetries)
+	return fmt.Errorf("function failed after %d retries: %w", maxRetries, lastErr)
 }
 
 // ErrorHandler provides utilities for consistent error handling.
-type ErrorHandler struct{}
+type ErrorHandler struct {
+	logger *slog.Logger
+}
 
-// NewErrorHandler creates a new instance of ErrorHandler.
-func NewErrorHandler() *ErrorHandler {
-	return &ErrorHandler{}
+// NewErrorHandler creates a new instance of ErrorHandler that reports errors to logger. The
+// logger must not write to stdout, which carries the MCP protocol.
+func NewErrorHandler(logger *slog.Logger) *ErrorHandler {
+	return &ErrorHandler{logger: logger}
 }
 
 // Handle logs and processes an error.
 func (eh *ErrorHandler) Handle(err error, message string) {
 	if err != nil {
-		fmt.Printf("ERROR: %s: %v\n", message, err)
+		eh.logger.Error(message, "error", err)
 		// In a real application, you might send this to a logging service,
 		// trigger alerts, or perform other error-specific actions.
 	}
@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithRetry(t *testing.T) {
+	calls := 0
+	err := WithRetry(context.Background(), nil, func() error {
+		calls++
+		if calls < 3 {
+			return &RetryableError{Err: errors.New("flaky")}
+		}
+		return nil
+	}, 3, time.Millisecond)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+
+	calls = 0
+	permanent := errors.New("permanent")
+	err = WithRetry(context.Background(), nil, func() error {
+		calls++
+		return permanent
+	}, 3, time.Millisecond)
+	assert.ErrorIs(t, err, permanent)
+	assert.Equal(t, 1, calls)
+}
+
+func TestWithRetryStopsWaitingWhenContextIsDone(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := WithRetry(ctx, nil, func() error {
+		return &RetryableError{Err: errors.New("flaky")}
+	}, 5, time.Minute)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, "flaky")
+	assert.Less(t, time.Since(start), 10*time.Second)
+}
+
+func TestErrorHandlerLogs(t *testing.T) {
+	var logs bytes.Buffer
+	handler := NewErrorHandler(slog.New(slog.NewTextHandler(&logs, nil)))
+	handler.Handle(nil, "nothing happened")
+	assert.Empty(t, logs.String())
+
+	handler.Handle(errors.New("boom"), "failed to load page")
+	assert.Contains(t, logs.String(), `msg="failed to load page" error=boom`)
+}
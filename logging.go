@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// toolLoggingMiddleware logs every tool call with the tool name, its url argument, the duration
+// and the outcome as structured fields, e.g. to find slow scrapes in production.
+func toolLoggingMiddleware(logger *slog.Logger) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			start := time.Now()
+			result, err := next(ctx, request)
+
+			attrs := []any{
+				slog.String("tool", request.Params.Name),
+				slog.Int64("duration_ms", time.Since(start).Milliseconds()),
+			}
+			if rawURL := request.GetString("url", ""); rawURL != "" {
+				attrs = append(attrs, slog.String("url", redactURL(rawURL)))
+			}
+			switch {
+			case err != nil:
+				logger.Warn("Tool call failed", append(attrs, slog.Any("error", err))...)
+			case result != nil && result.IsError:
+				logger.Warn("Tool call returned an error result", attrs...)
+			default:
+				logger.Info("Tool call completed", attrs...)
+			}
+			return result, err
+		}
+	}
+}
+
+// redactURL hides a password embedded in a URL's user info before it is logged.
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	return u.Redacted()
+}
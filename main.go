@@ -3,23 +3,48 @@ package main
 import (
 	"context"
 	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"github.com/playwright-community/playwright-go"
 
+	"github.com/Camelket/mcp-browser-tools/internal/analysis"
 	"github.com/Camelket/mcp-browser-tools/internal/browser"
+	"github.com/Camelket/mcp-browser-tools/internal/cache"
+	"github.com/Camelket/mcp-browser-tools/internal/config"
 	"github.com/Camelket/mcp-browser-tools/internal/playwright_integration"
+	"github.com/Camelket/mcp-browser-tools/internal/resources"
+	"github.com/Camelket/mcp-browser-tools/internal/safety"
 	"github.com/Camelket/mcp-browser-tools/internal/summary_tool"
 )
 
 func main() {
-	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	// Stdout carries the MCP stdio protocol, so logs go to stderr.
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+
+	cfg, err := config.Load()
+	if err != nil {
+		logger.Error("Failed to load configuration", "error", err)
+		os.Exit(1)
+	}
 
 	browserManager := browser.NewBrowserInstanceManager(logger.With("component", "BrowserInstanceManager"))
 	defer browserManager.CloseBrowserInstance()
+	browserManager.SetProxy(cfg.Proxy)
+	browserManager.SetInactivityTimeout(cfg.InactivityTimeout)
+	browserManager.SetAutoInstall(cfg.AutoInstall)
+	// A missing install is reported now rather than on the first tool call. The server still
+	// starts, and tool calls fail with the same instructions until the browsers are installed.
+	if err := browserManager.CheckInstallation(); err != nil {
+		logger.Error("Playwright self-check failed", "error", err)
+	}
 
 	pwIntegration, err := playwright_integration.NewPlaywrightIntegration(browserManager, logger.With("component", "PlaywrightIntegration"))
 	if err != nil {
@@ -27,125 +52,2630 @@ func main() {
 		os.Exit(1)
 	}
 	// No need to defer pwIntegration.Close() here, as browserManager handles the lifecycle.
+	pwIntegration.SetNavigationRateLimit(cfg.NavigationRateLimit)
+	pwIntegration.SetDefaultTimeout(cfg.DefaultTimeout)
+	pwIntegration.SetDebugScreenshotDir(cfg.DebugScreenshotDir)
+	pwIntegration.SetIgnoreHTTPSErrors(cfg.IgnoreHTTPSErrors)
+	pwIntegration.SetCaptureLimits(cfg.CaptureMaxBodyBytes, cfg.CaptureMaxTotalBytes)
+	pwIntegration.SetWebSocketFrameLimit(cfg.CaptureMaxFrameBytes)
+	if cfg.AllowPrivateNetworks {
+		logger.Warn("SSRF protection disabled: tools may fetch loopback, private and metadata service addresses")
+	}
+	policy := &safety.Policy{
+		AllowPrivate:  cfg.AllowPrivateNetworks,
+		AllowedHosts:  cfg.AllowedHosts,
+		HostAllowlist: cfg.HostAllowlist,
+		HostDenylist:  cfg.HostDenylist,
+	}
+	if policy.Restrictive() {
+		pwIntegration.SetURLPolicy(policy)
+	}
 
 	summaryTool := summary_tool.NewSummaryTool(pwIntegration, logger)
 
+	// Large outputs are served as MCP resources. They are tied to the browser they were
+	// captured with, so a restart drops them.
+	resourceStore := resources.NewStore(cfg.ResourceStoreBytes, cfg.ResourceTTL)
+	browserManager.OnLaunch(resourceStore.Clear)
+
+	// Repeated get_html and get_screenshot calls within BROWSER_CACHE_TTL_SECONDS reuse the
+	// first capture instead of loading the page again.
+	resultCache := cache.NewMemoryCache(cfg.CacheMaxEntries)
+
+	if cfg.MetricsAddr != "" {
+		metricsServer := serveMetrics(cfg.MetricsAddr, logger.With("component", "Metrics"))
+		defer metricsServer.Close()
+	}
+
 	// Create a new MCP server
 	s := server.NewMCPServer(
 		"web_tool_server",
 		"1.0.0",
 		server.WithToolCapabilities(false),
+		server.WithResourceCapabilities(false, false),
+		server.WithToolHandlerMiddleware(toolLoggingMiddleware(logger.With("component", "Tools"))),
+		server.WithToolHandlerMiddleware(toolMetricsMiddleware()),
 	)
 
+	s.AddResourceTemplate(mcp.NewResourceTemplate(resources.URITemplate, "Captured output",
+		mcp.WithTemplateDescription("Screenshots and HTML returned by tools as capture:// resources instead of inline content."),
+	), captureResourceHandler(resourceStore))
+
 	// Add get_page_summary tool
-	s.AddTool(mcp.NewTool("get_page_summary",
-		mcp.WithDescription("Returns the HTML content and a base64 encoded screenshot of the current page."),
+	s.AddTool(mcp.NewTool("get_page_summary", withNavigationParams(withWaitParams(withMediaParams(
+		mcp.WithDescription("Returns the HTML content, links and network activity of the page as text, plus a screenshot (of the full page unless full_page is false) as an image."),
 		mcp.WithString("url",
 			mcp.Required(),
 			mcp.Description("The URL of the page to get summary from."),
 		),
-	), GetPageSummaryHandler(summaryTool))
+		mcp.WithString("format",
+			mcp.Description(`Output format: "json" (default) for a structured object, "markdown" for a readable document, or "text" for the legacy plain-text layout.`),
+			mcp.Enum("json", "markdown", "text"),
+		),
+		mcp.WithBoolean("legacy_base64_text",
+			mcp.Description(legacyBase64Description),
+		),
+		mcp.WithBoolean("full_page",
+			mcp.Description("Whether the screenshot covers the full scrollable page rather than just the viewport. Defaults to true."),
+		),
+		mcp.WithBoolean("include_metadata",
+			mcp.Description("Whether to include the page title, description, canonical URL and OpenGraph/Twitter card fields. Defaults to false."),
+		),
+		mcp.WithBoolean("sanitize",
+			mcp.Description(sanitizeDescription),
+		),
+		mcp.WithNumber("max_bytes",
+			mcp.Description(htmlLimitDescription),
+			mcp.Min(0),
+		),
+		mcp.WithBoolean("as_resource",
+			mcp.Description(asResourceDescription),
+		),
+	)...)...)...), GetPageSummaryHandler(summaryTool, cfg, resourceStore))
 
 	// Add get_html tool
-	s.AddTool(mcp.NewTool("get_html",
+	s.AddTool(mcp.NewTool("get_html", withNavigationParams(withWaitParams(
 		mcp.WithDescription("Returns the HTML content of the specified URL."),
 		mcp.WithString("url",
 			mcp.Required(),
 			mcp.Description("The URL of the page to get HTML from."),
 		),
-	), GetHTMLHandler(pwIntegration))
+		mcp.WithString("mode",
+			mcp.Description(`Optional part of the page to return: "full" (default) for the whole document, "head" for just the <head> element, or "selector" for the outer HTML of the elements matching 'selector'.`),
+			mcp.Enum("full", "head", "selector"),
+		),
+		mcp.WithBoolean("rendered",
+			mcp.Description(`Whether to return the live DOM after scripts have run (default true). False returns the HTML source exactly as the server sent it, which for a single-page app is often little more than an empty root element and script tags; comparing the two shows what JavaScript renders. The source is only available for the whole document (mode "full").`),
+		),
+		mcp.WithString("selector",
+			mcp.Description("CSS selector of the fragment to return; required when mode is \"selector\"."),
+		),
+		mcp.WithBoolean("sanitize",
+			mcp.Description(sanitizeDescription),
+		),
+		mcp.WithNumber("max_bytes",
+			mcp.Description(htmlLimitDescription),
+			mcp.Min(0),
+		),
+		mcp.WithBoolean("as_resource",
+			mcp.Description(asResourceDescription),
+		),
+	)...)...), GetHTMLHandler(pwIntegration, cfg, resourceStore, resultCache))
 
 	// Add get_screenshot tool
-	s.AddTool(mcp.NewTool("get_screenshot",
-		mcp.WithDescription("Returns a base64 encoded screenshot of the specified URL."),
+	s.AddTool(mcp.NewTool("get_screenshot", withNavigationParams(withWaitParams(withMediaParams(
+		mcp.WithDescription("Returns a screenshot of the specified URL as an image."),
 		mcp.WithString("url",
 			mcp.Required(),
 			mcp.Description("The URL of the page to get a screenshot from."),
 		),
 		mcp.WithBoolean("full_page",
-			mcp.Description("Whether to take a full page screenshot. Defaults to false."),
+			mcp.Description("Whether to take a full page screenshot. Defaults to false, or true with print_mode."),
+		),
+		mcp.WithBoolean("print_mode",
+			mcp.Description("Whether to render the page with its print stylesheet, as media=\"print\" does, and capture it as an image rather than a PDF. Defaults to false."),
+		),
+		mcp.WithBoolean("legacy_base64_text",
+			mcp.Description(legacyBase64Description),
+		),
+		mcp.WithNumber("clip_x",
+			mcp.Description("Optional left edge of the region to capture, in CSS pixels from the top-left of the page. Requires clip_width and clip_height."),
+			mcp.Min(0),
+		),
+		mcp.WithNumber("clip_y",
+			mcp.Description("Optional top edge of the region to capture, in CSS pixels from the top-left of the page."),
+			mcp.Min(0),
+		),
+		mcp.WithNumber("clip_width",
+			mcp.Description("Optional width of the region to capture, in CSS pixels. The region must lie within the page."),
 		),
-	), GetScreenshotHandler(pwIntegration))
+		mcp.WithNumber("clip_height",
+			mcp.Description("Optional height of the region to capture, in CSS pixels."),
+		),
+		mcp.WithString("format",
+			mcp.Description("Image format. JPEG is much smaller for photos and long pages. Defaults to png."),
+			mcp.Enum(playwright_integration.ScreenshotFormatPNG, playwright_integration.ScreenshotFormatJPEG),
+		),
+		mcp.WithNumber("quality",
+			mcp.Description("JPEG quality from 0 to 100. Only valid with format \"jpeg\"."),
+			mcp.Min(0),
+			mcp.Max(100),
+		),
+		mcp.WithNumber("max_width",
+			mcp.Description("Optional maximum image width in pixels; wider screenshots are downscaled, e.g. 800 for a thumbnail."),
+			mcp.Min(1),
+		),
+		mcp.WithNumber("max_height",
+			mcp.Description(fmt.Sprintf("Optional maximum image height in CSS pixels for full-page screenshots. Taller pages are returned as several images, each preceded by a text item giving its offset from the top. Defaults to %d, the largest capture Chromium supports.", playwright_integration.DefaultMaxScreenshotHeight)),
+			mcp.Min(1),
+			mcp.Max(playwright_integration.DefaultMaxScreenshotHeight),
+		),
+		mcp.WithBoolean("as_resource",
+			mcp.Description(asResourceDescription),
+		),
+	)...)...)...), GetScreenshotHandler(pwIntegration, cfg, resourceStore, resultCache))
 
-	// Start the stdio server
-	if err := server.ServeStdio(s); err != nil {
-		logger.Error("Server error", "error", err)
-		os.Exit(1)
-	}
-}
+	// Add responsive_screenshot tool
+	s.AddTool(mcp.NewTool("responsive_screenshot", withNavigationParams(withWaitParams(withMediaParams(
+		mcp.WithDescription("Loads the URL once and takes a screenshot at each of several viewport widths, resizing the page and waiting for it to reflow in between. Each image is preceded by a text item naming its width. Useful for checking responsive layouts."),
+		mcp.WithString("url",
+			mcp.Required(),
+			mcp.Description("The URL of the page to capture."),
+		),
+		mcp.WithArray("widths",
+			mcp.Required(),
+			mcp.Description(fmt.Sprintf("Viewport widths in CSS pixels, e.g. [375, 768, 1280]. At most %d widths, each between %d and %d.", maxResponsiveWidths, config.MinViewportSize, config.MaxViewportSize)),
+			mcp.Items(map[string]any{"type": "number"}),
+		),
+		mcp.WithBoolean("full_page",
+			mcp.Description("Whether to capture the full scrollable page at each width. Defaults to false."),
+		),
+		mcp.WithString("format",
+			mcp.Description("Image format. Defaults to png."),
+			mcp.Enum(playwright_integration.ScreenshotFormatPNG, playwright_integration.ScreenshotFormatJPEG),
+		),
+		mcp.WithNumber("quality",
+			mcp.Description("JPEG quality from 0 to 100. Only valid with format \"jpeg\"."),
+			mcp.Min(0),
+			mcp.Max(100),
+		),
+	)...)...)...), ResponsiveScreenshotHandler(pwIntegration, cfg))
 
-// GetPageSummaryHandler handles the get_page_summary MCP tool call.
-func GetPageSummaryHandler(st *summary_tool.SummaryTool) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		url, err := request.RequireString("url")
-		if err != nil {
-			return nil, fmt.Errorf("missing or invalid 'url' argument: %w", err)
-		}
+	// Add get_pdf_batch tool
+	s.AddTool(mcp.NewTool("get_pdf_batch", withNavigationParams(
+		mcp.WithDescription("Loads each URL and prints it to PDF with its print stylesheet, returning a single PDF document with the pages in the order given, preceded by a text item listing the URLs. Chromium only."),
+		mcp.WithArray("urls",
+			mcp.Required(),
+			mcp.Description(fmt.Sprintf("The URLs of the pages to print, at most %d.", playwright_integration.MaxPDFBatch)),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithString("format",
+			mcp.Description("Paper format. Defaults to Letter."),
+			mcp.Enum(playwright_integration.PDFFormats...),
+		),
+		mcp.WithBoolean("landscape",
+			mcp.Description("Whether to print in landscape orientation. Defaults to false."),
+		),
+		mcp.WithNumber("max_concurrency",
+			mcp.Description("Number of pages loaded in parallel. Defaults to 2."),
+			mcp.Min(1),
+			mcp.Max(5),
+		),
+	)...), GetPDFBatchHandler(pwIntegration, cfg))
 
-		pageSummary, err := st.CapturePageSummary(ctx, url)
-		if err != nil {
-			return nil, fmt.Errorf("failed to capture page summary: %w", err)
-		}
+	// Add get_resource_timings tool
+	s.AddTool(mcp.NewTool("get_resource_timings", withNavigationParams(
+		mcp.WithDescription("Navigates to the URL, waits for network idle and returns the Resource Timing API entries as a JSON array, slowest first."),
+		mcp.WithString("url",
+			mcp.Required(),
+			mcp.Description("The URL of the page to collect resource timings from."),
+		),
+		mcp.WithNumber("max_entries",
+			mcp.Description("Maximum number of entries to return. Defaults to 200."),
+			mcp.Min(1),
+		),
+	)...), GetResourceTimingsHandler(pwIntegration, cfg))
 
-		encodedScreenshot := base64.StdEncoding.EncodeToString(pageSummary.Screenshot)
+	// Add crawl tool
+	s.AddTool(mcp.NewTool("crawl", withNavigationParams(
+		mcp.WithDescription("Starts at a URL and follows same-origin links breadth-first, returning a JSON object mapping each fetched URL to the links found on it. With respect_robots_txt, pages robots.txt disallows are skipped and its Crawl-delay is honored."),
+		mcp.WithString("url",
+			mcp.Required(),
+			mcp.Description("The URL to start crawling from."),
+		),
+		mcp.WithNumber("depth",
+			mcp.Description("How many link levels to follow from the start page. Defaults to 1."),
+			mcp.Min(0),
+			mcp.Max(3),
+		),
+		mcp.WithNumber("max_pages",
+			mcp.Description(fmt.Sprintf("Maximum number of pages to fetch. Defaults to 20; larger values are capped at %d.", maxCrawlPages)),
+			mcp.Min(1),
+		),
+		mcp.WithNumber("concurrency",
+			mcp.Description(fmt.Sprintf("Number of pages fetched in parallel. Defaults to 4; larger values are capped at %d.", maxCrawlConcurrency)),
+			mcp.Min(1),
+		),
+	)...), CrawlHandler(summaryTool, cfg))
 
-		// Use mcp.NewToolResultText or a similar function
-		return mcp.NewToolResultText(fmt.Sprintf("URL: %s\nHTML: %s\nScreenshot: %s\nLinks: %v", pageSummary.URL, pageSummary.HTML, encodedScreenshot, pageSummary.Links)), nil
-	}
-}
+	// Add extract_structured_data tool
+	s.AddTool(mcp.NewTool("extract_structured_data", withNavigationParams(
+		mcp.WithDescription("Returns the JSON-LD structured data embedded in the page as JSON, with a count of malformed blocks that were skipped."),
+		mcp.WithString("url",
+			mcp.Required(),
+			mcp.Description("The URL of the page to extract structured data from."),
+		),
+	)...), ExtractStructuredDataHandler(summaryTool, pwIntegration, cfg))
 
-// GetHTMLHandler handles the get_html MCP tool call.
-func GetHTMLHandler(pi *playwright_integration.PlaywrightIntegration) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		url, err := request.RequireString("url")
-		if err != nil {
-			return nil, fmt.Errorf("missing or invalid 'url' argument: %w", err)
-		}
+	// Add check_links tool
+	s.AddTool(mcp.NewTool("check_links", withNavigationParams(
+		mcp.WithDescription("Extracts the links from a page and checks each one with a HEAD request (GET if HEAD is not allowed), returning a JSON array of {url, status_code, error, redirect_url}. Links disallowed by their site's robots.txt are not requested and are reported with an error."),
+		mcp.WithString("url",
+			mcp.Required(),
+			mcp.Description("The URL of the page whose links should be checked."),
+		),
+		mcp.WithNumber("concurrency",
+			mcp.Description("Number of links checked in parallel. Defaults to 10."),
+		),
+	)...), CheckLinksHandler(summaryTool, cfg))
 
-		page, err := pi.NavigateToURL(ctx, url, nil, 0)
-		if err != nil {
-			return nil, fmt.Errorf("failed to navigate to URL: %w", err)
-		}
-		defer page.Close()
+	// Add get_metadata tool
+	s.AddTool(mcp.NewTool("get_metadata", withNavigationParams(
+		mcp.WithDescription("Returns the page title, description, canonical URL, and OpenGraph and Twitter card fields as JSON, for building link previews."),
+		mcp.WithString("url",
+			mcp.Required(),
+			mcp.Description("The URL of the page to get metadata from."),
+		),
+	)...), GetMetadataHandler(summaryTool, cfg))
 
-		htmlContent, err := page.Content()
-		if err != nil {
-			return nil, fmt.Errorf("failed to get HTML content: %w", err)
-		}
+	// Add get_article tool
+	s.AddTool(mcp.NewTool("get_article", withNavigationParams(withWaitParams(
+		mcp.WithDescription("Extracts the main article of the page with Readability, dropping navigation, ads and sidebars, and returns its title, byline, cleaned HTML content, plain text, excerpt and length as JSON. Fails if the page has no article-like content."),
+		mcp.WithString("url",
+			mcp.Required(),
+			mcp.Description("The URL of the article to extract."),
+		),
+	)...)...), GetArticleHandler(summaryTool, cfg))
 
-		return mcp.NewToolResultText(htmlContent), nil
-	}
-}
+	// Add get_content_stats tool
+	s.AddTool(mcp.NewTool("get_content_stats", withNavigationParams(withWaitParams(
+		mcp.WithDescription("Returns text statistics of the page's visible text as JSON: word, paragraph, sentence and character counts, and the estimated reading time in minutes at 200 words per minute."),
+		mcp.WithString("url",
+			mcp.Required(),
+			mcp.Description("The URL of the page to analyze."),
+		),
+	)...)...), GetContentStatsHandler(summaryTool, cfg))
 
-// GetScreenshotHandler handles the get_screenshot MCP tool call.
-func GetScreenshotHandler(pi *playwright_integration.PlaywrightIntegration) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		url, err := request.RequireString("url")
-		if err != nil {
-			return nil, fmt.Errorf("missing or invalid 'url' argument: %w", err)
-		}
+	// Add get_timings tool
+	s.AddTool(mcp.NewTool("get_timings", withNavigationParams(withWaitParams(
+		mcp.WithDescription("Loads the URL and returns the Navigation Timing milestones of the main document as JSON, in milliseconds from the start of the fetch: ttfb_ms (first response byte), dom_content_loaded_ms and load_ms. A milestone not reached before the call returned, e.g. load_ms with wait_until=domcontentloaded, is 0."),
+		mcp.WithString("url",
+			mcp.Required(),
+			mcp.Description("The URL of the page to time."),
+		),
+	)...)...), GetTimingsHandler(pwIntegration, cfg))
 
-		fullPage := false // Default value
-		fullPageStr, err := request.RequireString("full_page")
-		if err == nil { // Parameter was provided
-			if fullPageStr == "true" {
-				fullPage = true
-			}
-		}
+	// Add get_performance tool
+	s.AddTool(mcp.NewTool("get_performance", withNavigationParams(withWaitParams(
+		mcp.WithDescription("Loads the URL and returns load and paint milestones as JSON, in milliseconds from the start of the navigation: ttfb_ms, dom_content_loaded_ms, load_ms, first_paint_ms and first_contentful_paint_ms. Milestones the browser does not report or the page has not reached are omitted; source names the timing API used."),
+		mcp.WithString("url",
+			mcp.Required(),
+			mcp.Description("The URL of the page to measure."),
+		),
+	)...)...), GetPerformanceHandler(pwIntegration, cfg))
 
-		page, err := pi.NavigateToURL(ctx, url, nil, 0)
-		if err != nil {
-			return nil, fmt.Errorf("failed to navigate to URL: %w", err)
-		}
-		defer page.Close()
+	// Add get_trace tool
+	s.AddTool(mcp.NewTool("get_trace", withNavigationParams(withWaitParams(
+		mcp.WithDescription("Loads the URL while recording a Playwright trace and returns the trace archive as an embedded application/zip resource (base64). The trace holds the action log, DOM snapshots, screenshots and network activity; open it with \"npx playwright show-trace\" or at trace.playwright.dev. It is typically 1-5 MB."),
+		mcp.WithString("url",
+			mcp.Required(),
+			mcp.Description("The URL of the page to trace."),
+		),
+	)...)...), GetTraceHandler(pwIntegration, cfg))
 
-		screenshotBytes, err := pi.CaptureScreenshot(ctx, page, playwright_integration.PageScreenshotOptions{FullPage: fullPage})
-		if err != nil {
-			return nil, fmt.Errorf("failed to capture screenshot: %w", err)
-		}
+	// Add get_coverage tool
+	s.AddTool(mcp.NewTool("get_coverage", withNavigationParams(withWaitParams(
+		mcp.WithDescription("Loads the URL while recording JavaScript and CSS coverage and returns, as a JSON array, how much of each script and stylesheet the page used during load: url, type (js or css), total_bytes, used_bytes and used_percent. Entries are sorted least used first, so the most wasteful come first; inline scripts and styles are counted under the page URL. Chromium only."),
+		mcp.WithString("url",
+			mcp.Required(),
+			mcp.Description("The URL of the page to measure."),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description(fmt.Sprintf("Optional maximum number of entries to return. Defaults to %d.", playwright_integration.DefaultCoverageLimit)),
+			mcp.Min(1),
+		),
+	)...)...), GetCoverageHandler(pwIntegration, cfg))
+
+	// Add get_open_graph tool
+	s.AddTool(mcp.NewTool("get_open_graph", withNavigationParams(
+		mcp.WithDescription("Returns the page's Open Graph fields (title, description, image, url, type, site_name, locale) as JSON, or null if the page has no og:* tags."),
+		mcp.WithString("url",
+			mcp.Required(),
+			mcp.Description("The URL of the page to get Open Graph data from."),
+		),
+	)...), GetOpenGraphHandler(summaryTool, cfg))
+
+	// Add get_twitter_card tool
+	s.AddTool(mcp.NewTool("get_twitter_card", withNavigationParams(
+		mcp.WithDescription("Returns the page's Twitter (X) card fields (card, site, creator, title, description, image) as JSON, or null if the page has no twitter:* tags."),
+		mcp.WithString("url",
+			mcp.Required(),
+			mcp.Description("The URL of the page to get the Twitter card from."),
+		),
+	)...), GetTwitterCardHandler(summaryTool, cfg))
+
+	// Add get_manifest tool
+	s.AddTool(mcp.NewTool("get_manifest", withNavigationParams(
+		mcp.WithDescription("Loads the URL, fetches the web app manifest it links with <link rel=\"manifest\"> and returns its PWA fields (url, name, short_name, start_url, display, background_color, theme_color, icons) as JSON, or null if the page links no manifest. start_url and icon sources are resolved to absolute URLs."),
+		mcp.WithString("url",
+			mcp.Required(),
+			mcp.Description("The URL of the page to get the manifest of."),
+		),
+	)...), GetManifestHandler(summaryTool, cfg))
+
+	// Add get_tables tool
+	s.AddTool(mcp.NewTool("get_tables", withNavigationParams(
+		mcp.WithDescription("Returns all HTML tables on the page as a JSON array of {caption, headers, rows}. Cells spanning several rows or columns are repeated in each position they cover."),
+		mcp.WithString("url",
+			mcp.Required(),
+			mcp.Description("The URL of the page to extract tables from."),
+		),
+	)...), GetTablesHandler(summaryTool, cfg))
+
+	// Add extract_tables tool
+	s.AddTool(mcp.NewTool("extract_tables", withNavigationParams(
+		mcp.WithDescription("Returns all HTML tables on the page as a JSON array of {caption, headers, rows}, where each row is an object keyed by column header. Tables without header cells use their first row as headers; cells spanning several columns or rows are repeated in each position they cover."),
+		mcp.WithString("url",
+			mcp.Required(),
+			mcp.Description("The URL of the page to extract tables from."),
+		),
+	)...), ExtractTablesHandler(summaryTool, cfg))
+
+	// Add search_text tool
+	s.AddTool(mcp.NewTool("search_text", withNavigationParams(
+		mcp.WithDescription("Searches the rendered text of a page for a string and returns each match with its parent element and about 100 characters of surrounding text, plus the total match count."),
+		mcp.WithString("url",
+			mcp.Required(),
+			mcp.Description("The URL of the page to search."),
+		),
+		mcp.WithString("query",
+			mcp.Required(),
+			mcp.Description("The text to search for."),
+		),
+		mcp.WithBoolean("case_sensitive",
+			mcp.Description("Whether the search is case-sensitive. Defaults to false."),
+		),
+	)...), SearchTextHandler(pwIntegration, cfg))
+
+	// Add get_elements_by_xpath tool
+	s.AddTool(mcp.NewTool("get_elements_by_xpath", withNavigationParams(
+		mcp.WithDescription("Returns the innerHTML, innerText and attributes of all elements matching an XPath expression as a JSON array."),
+		mcp.WithString("url",
+			mcp.Required(),
+			mcp.Description("The URL of the page to extract elements from."),
+		),
+		mcp.WithString("xpath",
+			mcp.Required(),
+			mcp.Description("The XPath expression to match, e.g. \"//h1\" or \"//table[@id='results']//td\"."),
+		),
+		mcp.WithNumber("max_results",
+			mcp.Description("Maximum number of elements to return. Defaults to 100."),
+			mcp.Min(1),
+		),
+		mcp.WithString("frame",
+			mcp.Description(frameDescription),
+		),
+	)...), GetElementsByXPathHandler(pwIntegration, cfg))
+
+	// Add get_elements tool
+	s.AddTool(mcp.NewTool("get_elements", withNavigationParams(
+		mcp.WithDescription("Returns the innerHTML, innerText and attributes of all elements matching a CSS selector as JSON, along with the total number of matches."),
+		mcp.WithString("url",
+			mcp.Required(),
+			mcp.Description("The URL of the page to extract elements from."),
+		),
+		mcp.WithString("selector",
+			mcp.Required(),
+			mcp.Description("The CSS selector to match, e.g. \"article h2 > a\"."),
+		),
+		mcp.WithNumber("max_results",
+			mcp.Description("Maximum number of elements to return. Defaults to 100; total_matches still counts all matches."),
+			mcp.Min(1),
+		),
+		mcp.WithString("frame",
+			mcp.Description(frameDescription),
+		),
+	)...), GetElementsHandler(pwIntegration, cfg))
+
+	// Add extract_elements tool
+	s.AddTool(mcp.NewTool("extract_elements", withNavigationParams(
+		mcp.WithDescription("Returns a JSON array with the text content, or a named attribute, of every element matching a CSS selector. Useful for scraping lists and tables without a custom script."),
+		mcp.WithString("url",
+			mcp.Required(),
+			mcp.Description("The URL of the page to extract values from."),
+		),
+		mcp.WithString("selector",
+			mcp.Required(),
+			mcp.Description("The CSS selector to match, e.g. \"ul.results > li\"."),
+		),
+		mcp.WithString("attribute",
+			mcp.Description("Optional attribute to extract instead of the text content, e.g. \"href\". Elements without it yield null."),
+		),
+		mcp.WithString("frame",
+			mcp.Description(frameDescription),
+		),
+	)...), ExtractElementsHandler(pwIntegration, cfg))
+
+	// Add count_elements tool
+	s.AddTool(mcp.NewTool("count_elements", withNavigationParams(
+		mcp.WithDescription("Returns the number of elements matching a CSS selector as JSON ({\"count\": n}), without transferring their content."),
+		mcp.WithString("url",
+			mcp.Required(),
+			mcp.Description("The URL of the page to count elements on."),
+		),
+		mcp.WithString("selector",
+			mcp.Required(),
+			mcp.Description("The CSS selector to match."),
+		),
+		mcp.WithString("frame",
+			mcp.Description(frameDescription),
+		),
+	)...), CountElementsHandler(pwIntegration, cfg))
+
+	// Add a11y_snapshot tool
+	s.AddTool(mcp.NewTool("a11y_snapshot", withNavigationParams(
+		mcp.WithDescription("Returns the page's accessibility tree as JSON: nested nodes with role, name, value and properties such as focusable or checked. A semantic view of the page that is much smaller than its HTML. Chromium only."),
+		mcp.WithString("url",
+			mcp.Required(),
+			mcp.Description("The URL of the page to snapshot."),
+		),
+		mcp.WithBoolean("interesting_only",
+			mcp.Description("Optional. Drop ignored nodes and unnamed structural containers such as generic divs. Defaults to true."),
+		),
+	)...), A11ySnapshotHandler(pwIntegration, cfg))
+
+	// Add check_contrast tool
+	s.AddTool(mcp.NewTool("check_contrast", withNavigationParams(withWaitParams(
+		mcp.WithDescription("Loads the URL and returns visible text elements whose color contrast with their background fails WCAG 2.1 as a JSON array, worst first and at most 50: selector, text, fg_color, bg_color, ratio, large_text and fails_level (AA or AAA). Text on background images or gradients is not checked."),
+		mcp.WithString("url",
+			mcp.Required(),
+			mcp.Description("The URL of the page to check."),
+		),
+		mcp.WithString("level",
+			mcp.Description("Optional. The WCAG level to check against: AA (4.5:1, or 3:1 for large text) or AAA (7:1, or 4.5:1 for large text). Defaults to AA."),
+			mcp.Enum(analysis.ContrastLevelAA, analysis.ContrastLevelAAA),
+		),
+	)...)...), CheckContrastHandler(pwIntegration, cfg))
+
+	// Add click_element tool
+	s.AddTool(mcp.NewTool("click_element", withNavigationParams(
+		mcp.WithDescription("Loads the URL, clicks the first element matching a CSS selector and returns the resulting URL, HTML and base64 screenshot as JSON. Optionally waits for the URL to change, e.g. after client-side routing."),
+		mcp.WithString("url",
+			mcp.Required(),
+			mcp.Description("The URL of the page to load."),
+		),
+		mcp.WithString("selector",
+			mcp.Required(),
+			mcp.Description("The CSS selector of the element to click."),
+		),
+		mcp.WithString("wait_for_url_pattern",
+			mcp.Description("Optional URL pattern to wait for after the click. If the URL does not match in time, the current page is returned without an error and url_matched is false."),
+		),
+		mcp.WithString("url_pattern_type",
+			mcp.Description("How wait_for_url_pattern is interpreted: a Playwright glob (default) or a regular expression."),
+			mcp.Enum("glob", "regex"),
+		),
+		mcp.WithNumber("wait_timeout_ms",
+			mcp.Description("Maximum time for the click and the URL wait in milliseconds. Defaults to 10000."),
+			mcp.Min(1),
+		),
+		mcp.WithString("frame",
+			mcp.Description(frameDescription),
+		),
+	)...), ClickElementHandler(pwIntegration, cfg))
+
+	// Add get_har tool
+	s.AddTool(mcp.NewTool("get_har", withNavigationParams(
+		mcp.WithDescription("Loads the URL while recording its network traffic and returns it as an HTTP Archive (HAR 1.2) JSON document, for use with browser developer tools."),
+		mcp.WithString("url",
+			mcp.Required(),
+			mcp.Description("The URL of the page to record."),
+		),
+	)...), GetHARHandler(pwIntegration, cfg))
+
+	// Add resolve_url tool
+	s.AddTool(mcp.NewTool("resolve_url", withNavigationParams(
+		mcp.WithDescription("Loads the URL and returns the URL the browser ends up on after HTTP and client-side redirects, as JSON {url, final_url, redirected}."),
+		mcp.WithString("url",
+			mcp.Required(),
+			mcp.Description("The URL to resolve."),
+		),
+	)...), ResolveURLHandler(pwIntegration, cfg))
+
+	// Add get_network_activity tool
+	s.AddTool(mcp.NewTool("get_network_activity", withNavigationParams(
+		mcp.WithDescription("Loads the URL while capturing its network requests and returns them as a JSON array, optionally filtered by response status (e.g. status_min 400 for failed requests only), content type and resource type."),
+		mcp.WithString("url",
+			mcp.Required(),
+			mcp.Description("The URL of the page to load."),
+		),
+		mcp.WithNumber("status_min",
+			mcp.Description("Optional lowest response status to include. 0 or unset means no lower bound."),
+			mcp.Min(0),
+		),
+		mcp.WithNumber("status_max",
+			mcp.Description("Optional highest response status to include. 0 or unset means no upper bound."),
+			mcp.Min(0),
+		),
+		mcp.WithString("content_type_filter",
+			mcp.Description("Optional substring of the response Content-Type to include, case-insensitive, e.g. \"json\" for API responses."),
+		),
+		mcp.WithString("resource_type_filter",
+			mcp.Description("Optional request resource type to include, e.g. \"xhr\", \"fetch\", \"document\", \"image\" or \"font\"."),
+		),
+	)...), GetNetworkActivityHandler(pwIntegration, cfg))
+
+	// Add get_websocket_activity tool
+	s.AddTool(mcp.NewTool("get_websocket_activity", withNavigationParams(withWaitParams(
+		mcp.WithDescription(fmt.Sprintf("Loads the URL while recording the WebSockets it opens and returns them as a JSON array: url, opened_at, closed, error and frames, each with direction (sent or received), time, payload and size. Binary payloads are base64 with encoding \"base64\"; payloads are cut to %d bytes and flagged with truncated, and at most %d frames are kept per socket. Use settle_ms to keep listening after the page has loaded.", cfg.CaptureMaxFrameBytes, playwright_integration.MaxWebSocketFrames)),
+		mcp.WithString("url",
+			mcp.Required(),
+			mcp.Description("The URL of the page to load."),
+		),
+	)...)...), GetWebSocketActivityHandler(pwIntegration, cfg))
+
+	// Add get_policy tool
+	s.AddTool(mcp.NewTool("get_policy",
+		mcp.WithDescription("Returns the server's URL policy as JSON: whether private network addresses are blocked, the hosts exempt from that, and the host allow and deny lists. Check it before requesting a URL that might be refused."),
+	), GetPolicyHandler(pwIntegration))
+
+	// Add get_redirect_chain tool
+	s.AddTool(mcp.NewTool("get_redirect_chain",
+		mcp.WithDescription("Follows the HTTP redirects of a URL without a browser and returns each hop (url, status_code, location), the final URL and whether a redirect loop was detected, as JSON. Follows at most 20 redirects; JavaScript and meta refresh redirects are not seen."),
+		mcp.WithString("url",
+			mcp.Required(),
+			mcp.Description("The URL to follow."),
+		),
+	), GetRedirectChainHandler(pwIntegration))
+
+	// Add get_security_headers tool
+	s.AddTool(mcp.NewTool("get_security_headers", withNavigationParams(
+		mcp.WithDescription("Loads the URL and audits the security headers of its main document response: Strict-Transport-Security, Content-Security-Policy, X-Content-Type-Options, X-Frame-Options, Referrer-Policy and Permissions-Policy. Each is reported with its value and a rating of \"good\", \"weak\" (with a note explaining why) or \"missing\"."),
+		mcp.WithString("url",
+			mcp.Required(),
+			mcp.Description("The URL of the page to audit."),
+		),
+	)...), GetSecurityHeadersHandler(pwIntegration, cfg))
+
+	// Add check_mixed_content tool
+	s.AddTool(mcp.NewTool("check_mixed_content", withNavigationParams(
+		mcp.WithDescription("Loads an HTTPS URL and returns the resources it requested over plain http://, which cause mixed content warnings or get blocked by the browser. Returns an empty list for pages without mixed content and for non-HTTPS pages."),
+		mcp.WithString("url",
+			mcp.Required(),
+			mcp.Description("The URL of the page to check."),
+		),
+	)...), CheckMixedContentHandler(pwIntegration, cfg))
+
+	// Add get_third_party_resources tool
+	s.AddTool(mcp.NewTool("get_third_party_resources", withNavigationParams(
+		mcp.WithDescription("Loads the URL and lists the third-party domains it contacted, i.e. registered domains other than the page's own (so cdn.example.com is first-party for www.example.com). Each domain is reported with its request count and resource types, most requested first. Useful for privacy and performance audits."),
+		mcp.WithString("url",
+			mcp.Required(),
+			mcp.Description("The URL of the page to audit."),
+		),
+	)...), GetThirdPartyResourcesHandler(pwIntegration, cfg))
 
-		encodedScreenshot := base64.StdEncoding.EncodeToString(screenshotBytes)
+	// Add extract_graphql tool
+	s.AddTool(mcp.NewTool("extract_graphql", withNavigationParams(withWaitParams(
+		mcp.WithDescription("Loads the URL while capturing its network traffic and returns the GraphQL operations it sent as a JSON array: url, operation_name, query, variables, status and response (the operation's data and errors). GraphQL requests are POSTs with a JSON body holding a query or operationName; batched requests are split into one entry per operation with a batch_index. Use wait_until=networkidle to include operations sent after the load event."),
+		mcp.WithString("url",
+			mcp.Required(),
+			mcp.Description("The URL of the page to load."),
+		),
+		mcp.WithNumber("max_response_bytes",
+			mcp.Description(fmt.Sprintf("Optional cap on the response of each operation in bytes. Larger responses are left out and flagged with response_truncated. Defaults to %d.", analysis.DefaultGraphQLResponseBytes)),
+			mcp.Min(1),
+		),
+	)...)...), ExtractGraphQLHandler(pwIntegration, cfg))
 
-		return mcp.NewToolResultText(encodedScreenshot), nil
+	// Add get_frameworks tool
+	s.AddTool(mcp.NewTool("get_frameworks", withNavigationParams(withWaitParams(
+		mcp.WithDescription("Loads the URL and detects the frontend frameworks it uses (React, Next.js, Vue, Nuxt, Angular, AngularJS, Svelte, Ember, Alpine.js) from their globals and DOM markers. Helps decide whether a page renders client-side. Detection is heuristic and may miss frameworks that hide their globals."),
+		mcp.WithString("url",
+			mcp.Required(),
+			mcp.Description("The URL of the page to inspect."),
+		),
+	)...)...), GetFrameworksHandler(pwIntegration, cfg))
+
+	// Add get_protocol tool
+	s.AddTool(mcp.NewTool("get_protocol", withNavigationParams(
+		mcp.WithDescription("Loads the URL and reports the network protocol its main document was served over (\"h2\", \"h3\", \"http/1.1\" or \"http/1.0\"), plus the number of subresources loaded over each protocol. Useful to verify that a server negotiates HTTP/2. Requires Chromium."),
+		mcp.WithString("url",
+			mcp.Required(),
+			mcp.Description("The URL of the page to load."),
+		),
+	)...), GetProtocolHandler(pwIntegration, cfg))
+
+	// Add run_actions tool
+	s.AddTool(mcp.NewTool("run_actions", withNavigationParams(
+		mcp.WithDescription("Loads the URL and then runs a sequence of steps (navigate, click, type, wait, wait_for_url, screenshot) in order on the same page, keeping cookies, form input and other page state between steps. Returns the result of the final step as JSON, or of every step with return_all, followed by the images of any screenshot steps. Stops at the first failing step."),
+		mcp.WithString("url",
+			mcp.Required(),
+			mcp.Description("The URL of the page to load before the first step."),
+		),
+		mcp.WithArray("actions",
+			mcp.Required(),
+			mcp.Description(fmt.Sprintf(`Steps to run, at most %d, e.g. [{"type":"type","selector":"#q","text":"shoes"},{"type":"click","selector":"button[type=submit]"},{"type":"wait","selector":".results"},{"type":"screenshot"}]. Each step has a "type": "navigate" (with "url"), "click" (with "selector"), "type" (with "selector" and "text", replacing the field's value), "wait" (with "selector" to wait for, or "duration_ms" up to %d), "wait_for_url" (with "url_pattern", a glob or a regular expression wrapped in slashes) or "screenshot" (with optional "full_page"). Click, type and wait steps may add "frame", the selector of the iframe containing the element.`, playwright_integration.MaxActions, playwright_integration.MaxActionWait.Milliseconds())),
+			mcp.Items(map[string]any{"type": "object"}),
+		),
+		mcp.WithBoolean("return_all",
+			mcp.Description("Whether to return the result of every step instead of only the last one. Defaults to false."),
+		),
+		mcp.WithNumber("step_timeout_ms",
+			mcp.Description("Maximum time for each click, type, selector wait and URL wait in milliseconds. Defaults to 10000."),
+			mcp.Min(1),
+		),
+	)...), RunActionsHandler(pwIntegration, cfg))
+
+	// Add run_js_audit tool
+	s.AddTool(mcp.NewTool("run_js_audit", withNavigationParams(withWaitParams(
+		mcp.WithDescription("Loads the URL, runs a custom JavaScript check in the page and returns its result as JSON. If the script throws, the call fails with the JavaScript error message and stack."),
+		mcp.WithString("url",
+			mcp.Required(),
+			mcp.Description("The URL of the page to audit."),
+		),
+		mcp.WithString("script",
+			mcp.Required(),
+			mcp.Description(`A JavaScript expression, e.g. "document.querySelectorAll('img:not([alt])').length", or the body of an async function that ends with a return statement, e.g. "const r = await fetch('/health'); return r.status;". The result must be JSON-serializable; undefined becomes null. Limited to the server's BROWSER_MAX_SCRIPT_BYTES, 50KB by default.`),
+		),
+	)...)...), RunJSAuditHandler(pwIntegration, cfg))
+
+	// Add get_storage tool
+	s.AddTool(mcp.NewTool("get_storage", withNavigationParams(withWaitParams(
+		mcp.WithDescription("Loads the URL and returns the localStorage and sessionStorage items of the page's origin as JSON, e.g. to inspect client-side state."),
+		mcp.WithString("url",
+			mcp.Required(),
+			mcp.Description("The URL of the page to read storage from."),
+		),
+	)...)...), GetStorageHandler(pwIntegration, cfg))
+
+	// Add set_storage tool
+	s.AddTool(mcp.NewTool("set_storage", withNavigationParams(withWaitParams(
+		mcp.WithDescription("Seeds localStorage and sessionStorage for the URL's origin before the page's scripts run, e.g. to load a page in a logged-in state with a saved token, then returns the storage after the page loaded as JSON. The final URL in the metadata shows whether the page accepted the state or redirected, e.g. to a login page."),
+		mcp.WithString("url",
+			mcp.Required(),
+			mcp.Description("The URL of the page to load; its origin receives the items."),
+		),
+		mcp.WithObject("local_storage",
+			mcp.Description(`Optional localStorage items, e.g. {"auth_token": "..."}.`),
+			mcp.AdditionalProperties(map[string]any{"type": "string"}),
+		),
+		mcp.WithObject("session_storage",
+			mcp.Description("Optional sessionStorage items."),
+			mcp.AdditionalProperties(map[string]any{"type": "string"}),
+		),
+	)...)...), SetStorageHandler(pwIntegration, cfg))
+
+	// Add wait_for_response tool
+	s.AddTool(mcp.NewTool("wait_for_response", withNavigationParams(
+		mcp.WithDescription("Navigates to the URL and waits for the first network response whose URL matches a pattern, returning its status, headers and body as JSON."),
+		mcp.WithString("url",
+			mcp.Required(),
+			mcp.Description("The URL of the page to load."),
+		),
+		mcp.WithString("url_pattern",
+			mcp.Required(),
+			mcp.Description(`The response URL to wait for: a Playwright glob such as "**/api/users*", or a regular expression wrapped in slashes such as "/\/api\/v[0-9]+\//".`),
+		),
+	)...), WaitForResponseHandler(pwIntegration, cfg))
+
+	// Add wait_for_url tool
+	s.AddTool(mcp.NewTool("wait_for_url", withNavigationParams(
+		mcp.WithDescription(`Loads the URL, optionally clicks an element, and waits until the page URL matches a pattern, e.g. after a JavaScript redirect or client-side routing. Returns the URL reached as JSON: {"url": "..."}. Fails if the URL does not match before the timeout.`),
+		mcp.WithString("url",
+			mcp.Required(),
+			mcp.Description("The URL of the page to load."),
+		),
+		mcp.WithString("url_pattern",
+			mcp.Required(),
+			mcp.Description(`The URL to wait for: a Playwright glob such as "**/checkout/*", or a regular expression wrapped in slashes such as "/\/orders\/[0-9]+$/".`),
+		),
+		mcp.WithString("selector",
+			mcp.Description("Optional. CSS selector of an element to click once the page has loaded, such as a link handled by a client-side router."),
+		),
+	)...), WaitForURLHandler(pwIntegration, cfg))
+
+	// Start the stdio server
+	if err := server.ServeStdio(s); err != nil {
+		logger.Error("Server error", "error", err)
+		os.Exit(1)
+	}
+}
+
+// GetPageSummaryHandler handles the get_page_summary MCP tool call.
+func GetPageSummaryHandler(st *summary_tool.SummaryTool, cfg *config.Config, store *resources.Store) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		nr, err := parseNavigationRequest(request, cfg)
+		if err != nil {
+			return nil, err
+		}
+		format := request.GetString("format", "json")
+		if format != "json" && format != "markdown" && format != "text" {
+			return nil, fmt.Errorf("invalid 'format' argument %q: expected json, markdown, or text", format)
+		}
+		fullPage, err := optionalBool(request, "full_page", true)
+		if err != nil {
+			return nil, err
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, nr.Navigation.Budget())
+		defer cancel()
+
+		if err := nr.checkRobots(ctx, st.Playwright()); err != nil {
+			return nil, err
+		}
+		// CapturePageSummary always routes requests, so the header rules apply through its interception.
+		routing := nr.routing()
+		pageSummary, err := st.CapturePageSummary(ctx, nr.URL, summary_tool.SummaryOptions{
+			Interception:    &routing,
+			Navigation:      nr.Navigation,
+			Page:            nr.Page,
+			IncludeMetadata: request.GetBool("include_metadata", false),
+			ViewportOnly:    !fullPage,
+			Progress:        progressNotifier(ctx, nr.ProgressToken),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to capture page summary: %w", err)
+		}
+		nr.UserAgent = pageSummary.UserAgent
+		nr.Document = &playwright_integration.DocumentStatus{
+			StatusCode:  pageSummary.StatusCode,
+			FinalURL:    pageSummary.FinalURL,
+			Certificate: pageSummary.Certificate,
+		}
+		metadata := nr.metadata(st.Playwright())
+		if pageSummary.HTML, err = sanitizeHTML(request, pageSummary.HTML, metadata); err != nil {
+			return nil, err
+		}
+		var stored bool
+		if pageSummary.HTML, stored, err = storeHTML(request, cfg, store, pageSummary.HTML, metadata); err != nil {
+			return nil, err
+		}
+		if !stored {
+			if pageSummary.HTML, err = limitHTML(request, cfg, pageSummary.HTML, metadata); err != nil {
+				return nil, err
+			}
+		}
+
+		// Unless the client relies on the old layout, the screenshot is sent as image content
+		// and left out of the text.
+		legacy := request.GetBool("legacy_base64_text", false)
+		screenshot := pageSummary.Screenshot
+		if !legacy {
+			pageSummary.Screenshot = nil
+			if screenshot, _, err = storeScreenshot(request, cfg, store, screenshot, playwright_integration.PageScreenshotOptions{}, metadata); err != nil {
+				return nil, err
+			}
+		}
+
+		var result *mcp.CallToolResult
+		switch format {
+		case "json":
+			data, err := pageSummary.ToJSON()
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode page summary: %w", err)
+			}
+			result = mcp.NewToolResultText(string(data))
+		case "markdown":
+			result = mcp.NewToolResultText(pageSummary.ToMarkdown())
+		default:
+			var sb strings.Builder
+			fmt.Fprintf(&sb, "URL: %s\nHTML: %s\n", pageSummary.URL, pageSummary.HTML)
+			if legacy {
+				fmt.Fprintf(&sb, "Screenshot: %s\n", base64.StdEncoding.EncodeToString(screenshot))
+			}
+			fmt.Fprintf(&sb, "Links: %v\nBlocked requests: %d", pageSummary.LinkURLs(), pageSummary.BlockedRequests)
+			result = mcp.NewToolResultText(sb.String())
+		}
+		if !legacy && len(screenshot) > 0 {
+			result.Content = append(result.Content, mcp.NewImageContent(base64.StdEncoding.EncodeToString(screenshot), "image/png"))
+		}
+		return withMetadata(result, metadata), nil
+	}
+}
+
+// GetHTMLHandler handles the get_html MCP tool call.
+func GetHTMLHandler(pi *playwright_integration.PlaywrightIntegration, cfg *config.Config, store *resources.Store, resultCache cache.Cache) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		nr, err := parseNavigationRequest(request, cfg)
+		if err != nil {
+			return nil, err
+		}
+		mode := request.GetString("mode", "full")
+		selector := request.GetString("selector", "")
+		switch mode {
+		case "full", "head":
+		case "selector":
+			if selector == "" {
+				return nil, fmt.Errorf("missing 'selector' argument: required when mode is \"selector\"")
+			}
+		default:
+			return nil, fmt.Errorf("invalid 'mode' argument %q: expected full, head, or selector", mode)
+		}
+		rendered := request.GetBool("rendered", true)
+		if !rendered && mode != "full" {
+			return nil, fmt.Errorf("invalid 'mode' argument %q: only full is supported with rendered=false", mode)
+		}
+
+		cacheKey := captureCacheKey(request)
+		capture, cached := loadCapture(resultCache, cfg, cacheKey)
+		if !cached {
+			ctx, cancel := context.WithTimeout(ctx, nr.Navigation.Budget())
+			defer cancel()
+
+			// The server's source is taken from the network capture of the main document.
+			var page playwright.Page
+			if rendered {
+				page, err = nr.open(ctx, pi)
+			} else if page, err = nr.newCapturingPage(ctx, pi); err == nil {
+				if err = nr.navigate(ctx, pi, page); err != nil {
+					page.Close()
+				}
+			}
+			if err != nil {
+				return nil, err
+			}
+			defer page.Close()
+
+			var htmlContent, charset string
+			switch {
+			case !rendered:
+				htmlContent, err = pi.DocumentSource(page, nr.Document.FinalURL)
+				if response, ok := pi.DocumentResponse(page, nr.Document.FinalURL); ok {
+					charset = response.Charset
+				}
+			case mode == "head":
+				htmlContent, err = pi.GetOuterHTML(ctx, page, "head")
+			case mode == "selector":
+				htmlContent, err = pi.GetOuterHTML(ctx, page, selector)
+			default:
+				htmlContent, err = pi.GetContent(ctx, page)
+			}
+			if err != nil {
+				return nil, err
+			}
+			if rendered {
+				if charset, err = pi.DocumentCharset(ctx, page); err != nil {
+					return nil, err
+				}
+			}
+			capture = &cachedCapture{Data: []byte(htmlContent), Metadata: nr.metadata(pi)}
+			if charset != "" {
+				capture.Metadata["charset"] = charset
+			}
+			saveCapture(resultCache, cfg, cacheKey, capture.Data, capture.Metadata)
+		}
+		htmlContent, metadata := string(capture.Data), capture.Metadata
+
+		if htmlContent, err = sanitizeHTML(request, htmlContent, metadata); err != nil {
+			return nil, err
+		}
+		htmlContent, stored, err := storeHTML(request, cfg, store, htmlContent, metadata)
+		if err != nil {
+			return nil, err
+		}
+		if !stored {
+			if htmlContent, err = limitHTML(request, cfg, htmlContent, metadata); err != nil {
+				return nil, err
+			}
+		}
+		return withMetadata(mcp.NewToolResultText(htmlContent), metadata), nil
+	}
+}
+
+// GetScreenshotHandler handles the get_screenshot MCP tool call.
+func GetScreenshotHandler(pi *playwright_integration.PlaywrightIntegration, cfg *config.Config, store *resources.Store, resultCache cache.Cache) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		nr, err := parseNavigationRequest(request, cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		// Print layouts flow to the full content height, so print_mode captures the full page by default.
+		printMode := request.GetBool("print_mode", false)
+		if printMode {
+			if nr.Page == nil {
+				nr.Page = &playwright_integration.PageOptions{}
+			}
+			if nr.Page.Media != "" && nr.Page.Media != "print" {
+				return nil, fmt.Errorf("print_mode cannot be combined with media %q", nr.Page.Media)
+			}
+			nr.Page.Media = "print"
+		}
+		screenshotOptions, err := screenshotOptionsFromRequest(request, printMode)
+		if err != nil {
+			return nil, err
+		}
+		fullPage, clip := screenshotOptions.FullPage, screenshotOptions.Clip
+		legacy := request.GetBool("legacy_base64_text", false)
+
+		cacheKey := captureCacheKey(request)
+		capture, cached := loadCapture(resultCache, cfg, cacheKey)
+		if !cached {
+			ctx, cancel := context.WithTimeout(ctx, nr.Navigation.Budget())
+			defer cancel()
+
+			page, err := nr.open(ctx, pi)
+			if err != nil {
+				return nil, err
+			}
+			defer page.Close()
+
+			var screenshotBytes []byte
+			if fullPage && clip == nil && !legacy {
+				segments, err := pi.CaptureScreenshotSegments(ctx, page, screenshotOptions)
+				if err != nil {
+					return nil, fmt.Errorf("failed to capture screenshot: %w", err)
+				}
+				if len(segments) > 1 {
+					result := &mcp.CallToolResult{}
+					for i, segment := range segments {
+						result.Content = append(result.Content,
+							mcp.NewTextContent(fmt.Sprintf("segment %d of %d: y=%d, height=%d", i+1, len(segments), segment.Y, segment.Height)),
+							mcp.NewImageContent(base64.StdEncoding.EncodeToString(segment.Data), screenshotOptions.MIMEType()),
+						)
+					}
+					return withMetadata(result, nr.metadata(pi)), nil
+				}
+				screenshotBytes = segments[0].Data
+			} else if screenshotBytes, err = pi.CaptureScreenshot(ctx, page, screenshotOptions); err != nil {
+				return nil, fmt.Errorf("failed to capture screenshot: %w", err)
+			}
+			capture = &cachedCapture{Data: screenshotBytes, Metadata: nr.metadata(pi)}
+			saveCapture(resultCache, cfg, cacheKey, capture.Data, capture.Metadata)
+		}
+		screenshotBytes, metadata := capture.Data, capture.Metadata
+
+		if legacy {
+			encodedScreenshot := base64.StdEncoding.EncodeToString(screenshotBytes)
+			return withMetadata(mcp.NewToolResultText(encodedScreenshot), metadata), nil
+		}
+
+		image, stored, err := storeScreenshot(request, cfg, store, screenshotBytes, screenshotOptions, metadata)
+		if err != nil {
+			return nil, err
+		}
+		result := &mcp.CallToolResult{
+			Content: []mcp.Content{mcp.NewImageContent(base64.StdEncoding.EncodeToString(image), screenshotOptions.MIMEType())},
+		}
+		if stored {
+			result.Content = append([]mcp.Content{mcp.NewTextContent(fmt.Sprintf("The full screenshot is stored as resource %s; a thumbnail follows.", metadata["screenshot_resource"]))}, result.Content...)
+		}
+		return withMetadata(result, metadata), nil
+	}
+}
+
+// maxResponsiveWidths bounds the number of screenshots a responsive_screenshot call takes.
+const maxResponsiveWidths = 10
+
+// ResponsiveScreenshotHandler handles the responsive_screenshot MCP tool call.
+func ResponsiveScreenshotHandler(pi *playwright_integration.PlaywrightIntegration, cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		nr, err := parseNavigationRequest(request, cfg)
+		if err != nil {
+			return nil, err
+		}
+		widths := request.GetIntSlice("widths", nil)
+		if len(widths) == 0 || len(widths) > maxResponsiveWidths {
+			return nil, fmt.Errorf("invalid 'widths' argument: expected between 1 and %d widths, got %d", maxResponsiveWidths, len(widths))
+		}
+		for _, width := range widths {
+			if err := config.ValidateViewportSize("width", width); err != nil {
+				return nil, fmt.Errorf("invalid 'widths' argument: %w", err)
+			}
+		}
+		screenshotOptions := playwright_integration.PageScreenshotOptions{
+			FullPage: request.GetBool("full_page", false),
+			Format:   request.GetString("format", ""),
+			Quality:  request.GetInt("quality", 0),
+		}
+		if err := screenshotOptions.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid screenshot arguments: %w", err)
+		}
+		height := 720
+		if nr.Page != nil && nr.Page.ViewportHeight > 0 {
+			height = nr.Page.ViewportHeight
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, nr.Navigation.Budget())
+		defer cancel()
+
+		page, err := nr.open(ctx, pi)
+		if err != nil {
+			return nil, err
+		}
+		defer page.Close()
+
+		result := &mcp.CallToolResult{}
+		for _, width := range widths {
+			if err := pi.ResizeViewport(ctx, page, width, height); err != nil {
+				return nil, err
+			}
+			screenshot, err := pi.CaptureScreenshot(ctx, page, screenshotOptions)
+			if err != nil {
+				return nil, fmt.Errorf("failed to capture screenshot at width %d: %w", width, err)
+			}
+			result.Content = append(result.Content,
+				mcp.NewTextContent(fmt.Sprintf("width: %d", width)),
+				mcp.NewImageContent(base64.StdEncoding.EncodeToString(screenshot), screenshotOptions.MIMEType()),
+			)
+		}
+		return withMetadata(result, nr.metadata(pi)), nil
+	}
+}
+
+// parseURLList decodes the urls argument, accepting either an array or a JSON string.
+func parseURLList(request mcp.CallToolRequest) ([]string, error) {
+	raw, ok := request.GetArguments()["urls"]
+	if !ok {
+		return nil, fmt.Errorf("missing 'urls' argument")
+	}
+	if data, isString := raw.(string); isString {
+		var urls []string
+		if err := json.Unmarshal([]byte(data), &urls); err != nil {
+			return nil, fmt.Errorf("invalid 'urls' argument: %w", err)
+		}
+		return urls, nil
+	}
+	urls, err := request.RequireStringSlice("urls")
+	if err != nil {
+		return nil, fmt.Errorf("invalid 'urls' argument: %w", err)
+	}
+	return urls, nil
+}
+
+// GetPDFBatchHandler handles the get_pdf_batch MCP tool call.
+func GetPDFBatchHandler(pi *playwright_integration.PlaywrightIntegration, cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		urls, err := parseURLList(request)
+		if err != nil {
+			return nil, err
+		}
+		nr, err := parseNavigationArgs(request, cfg)
+		if err != nil {
+			return nil, err
+		}
+		concurrency := request.GetInt("max_concurrency", 2)
+		if concurrency < 1 || concurrency > 5 {
+			return nil, fmt.Errorf("invalid 'max_concurrency' argument: must be between 1 and 5, got %d", concurrency)
+		}
+		opts := playwright_integration.PDFOptions{
+			Format:    request.GetString("format", ""),
+			Landscape: request.GetBool("landscape", false),
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, nr.Navigation.Budget())
+		defer cancel()
+
+		// Each page gets its own copy of the request and its navigation options, which
+		// navigate writes to, so that concurrent pages do not share them.
+		open := func(ctx context.Context, url string) (playwright.Page, error) {
+			pageRequest := *nr
+			pageRequest.URL = url
+			navigation := *nr.Navigation
+			pageRequest.Navigation = &navigation
+			return pageRequest.open(ctx, pi)
+		}
+		pdfs, err := pi.RenderPDFs(ctx, urls, opts, open, concurrency)
+		if err != nil {
+			return nil, err
+		}
+
+		merged, err := playwright_integration.MergePDFs(pdfs)
+		if err != nil {
+			return nil, err
+		}
+
+		var contents strings.Builder
+		fmt.Fprintf(&contents, "PDF of %d pages, in order:", len(urls))
+		for i, url := range urls {
+			fmt.Fprintf(&contents, "\n%d. %s", i+1, url)
+		}
+		return &mcp.CallToolResult{Content: []mcp.Content{
+			mcp.NewTextContent(contents.String()),
+			mcp.NewEmbeddedResource(mcp.BlobResourceContents{
+				URI:      urls[0],
+				MIMEType: "application/pdf",
+				Blob:     base64.StdEncoding.EncodeToString(merged),
+			}),
+		}}, nil
+	}
+}
+
+// GetResourceTimingsHandler handles the get_resource_timings MCP tool call.
+func GetResourceTimingsHandler(pi *playwright_integration.PlaywrightIntegration, cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		nr, err := parseNavigationRequest(request, cfg)
+		if err != nil {
+			return nil, err
+		}
+		nr.Navigation.WaitUntil = playwright.WaitUntilStateNetworkidle
+		maxEntries := request.GetInt("max_entries", 200)
+		if maxEntries <= 0 {
+			return nil, fmt.Errorf("invalid 'max_entries' argument: must be positive, got %d", maxEntries)
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, nr.Navigation.Budget())
+		defer cancel()
+
+		page, err := nr.open(ctx, pi)
+		if err != nil {
+			return nil, err
+		}
+		defer page.Close()
+
+		timings, err := pi.GetResourceTimings(ctx, page, maxEntries)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get resource timings: %w", err)
+		}
+
+		data, err := json.Marshal(timings)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode resource timings: %w", err)
+		}
+		return withMetadata(mcp.NewToolResultText(string(data)), nr.metadata(pi)), nil
+	}
+}
+
+// maxCrawlPages and maxCrawlConcurrency bound the pages a crawl call fetches and how many it
+// loads at once.
+const (
+	maxCrawlPages       = 100
+	maxCrawlConcurrency = 8
+)
+
+// CrawlHandler handles the crawl MCP tool call.
+func CrawlHandler(st *summary_tool.SummaryTool, cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		nr, err := parseNavigationRequest(request, cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		depth := request.GetInt("depth", 1)
+		if depth < 0 || depth > 3 {
+			return nil, fmt.Errorf("invalid 'depth' argument: must be between 0 and 3, got %d", depth)
+		}
+		maxPages := request.GetInt("max_pages", 20)
+		if maxPages <= 0 {
+			return nil, fmt.Errorf("invalid 'max_pages' argument: must be positive, got %d", maxPages)
+		}
+		concurrency := request.GetInt("concurrency", 4)
+		if concurrency <= 0 {
+			return nil, fmt.Errorf("invalid 'concurrency' argument: must be positive, got %d", concurrency)
+		}
+
+		crawlOptions := summary_tool.CrawlOptions{
+			MaxDepth:      depth,
+			MaxPages:      min(maxPages, maxCrawlPages),
+			Concurrency:   min(concurrency, maxCrawlConcurrency),
+			Page:          nr.Page,
+			Navigation:    nr.Navigation,
+			RespectRobots: nr.RespectRobots,
+		}
+		if nr.needsRouting(st.Playwright()) {
+			routing := nr.routing()
+			routing.SkipCapture = true
+			crawlOptions.Interception = &routing
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, nr.Navigation.Budget())
+		defer cancel()
+
+		siteMap, err := st.Crawl(ctx, nr.URL, crawlOptions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to crawl %s: %w", nr.URL, err)
+		}
+
+		data, err := json.Marshal(siteMap)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode crawl results: %w", err)
+		}
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+// ExtractStructuredDataHandler handles the extract_structured_data MCP tool call.
+func ExtractStructuredDataHandler(st *summary_tool.SummaryTool, pi *playwright_integration.PlaywrightIntegration, cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		nr, err := parseNavigationRequest(request, cfg)
+		if err != nil {
+			return nil, err
+		}
+		ctx, cancel := context.WithTimeout(ctx, nr.Navigation.Budget())
+		defer cancel()
+
+		page, err := nr.open(ctx, pi)
+		if err != nil {
+			return nil, err
+		}
+		defer page.Close()
+
+		htmlContent, err := pi.GetContent(ctx, page)
+		if err != nil {
+			return nil, err
+		}
+
+		structuredData, err := st.ExtractStructuredData(htmlContent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract structured data: %w", err)
+		}
+
+		data, err := json.Marshal(structuredData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode structured data: %w", err)
+		}
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+// CheckLinksHandler handles the check_links MCP tool call.
+func CheckLinksHandler(st *summary_tool.SummaryTool, cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		nr, err := parseNavigationRequest(request, cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		// The timeout applies to loading the page; the link checks have their own per-request timeout.
+		htmlContent, err := func() (string, error) {
+			navCtx, cancel := context.WithTimeout(ctx, nr.Navigation.Budget())
+			defer cancel()
+
+			page, err := nr.open(navCtx, st.Playwright())
+			if err != nil {
+				return "", err
+			}
+			defer page.Close()
+			return st.Playwright().GetContent(navCtx, page)
+		}()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get HTML content: %w", err)
+		}
+
+		statuses, err := st.CheckLinks(ctx, htmlContent, nr.URL, request.GetInt("concurrency", 10))
+		if err != nil {
+			return nil, fmt.Errorf("failed to check links: %w", err)
+		}
+
+		data, err := json.Marshal(statuses)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode link statuses: %w", err)
+		}
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+// GetMetadataHandler handles the get_metadata MCP tool call.
+func GetMetadataHandler(st *summary_tool.SummaryTool, cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		nr, err := parseNavigationRequest(request, cfg)
+		if err != nil {
+			return nil, err
+		}
+		ctx, cancel := context.WithTimeout(ctx, nr.Navigation.Budget())
+		defer cancel()
+
+		page, err := nr.open(ctx, st.Playwright())
+		if err != nil {
+			return nil, err
+		}
+		defer page.Close()
+
+		htmlContent, err := st.Playwright().GetContent(ctx, page)
+		if err != nil {
+			return nil, err
+		}
+
+		metadata, err := st.ExtractMetadata(htmlContent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract metadata: %w", err)
+		}
+
+		data, err := json.Marshal(metadata)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode metadata: %w", err)
+		}
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+// GetArticleHandler handles the get_article MCP tool call.
+func GetArticleHandler(st *summary_tool.SummaryTool, cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		nr, err := parseNavigationRequest(request, cfg)
+		if err != nil {
+			return nil, err
+		}
+		ctx, cancel := context.WithTimeout(ctx, nr.Navigation.Budget())
+		defer cancel()
+
+		page, err := nr.open(ctx, st.Playwright())
+		if err != nil {
+			return nil, err
+		}
+		defer page.Close()
+
+		article, err := st.ExtractArticle(ctx, page)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := json.Marshal(article)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode article: %w", err)
+		}
+		return withMetadata(mcp.NewToolResultText(string(data)), nr.metadata(st.Playwright())), nil
+	}
+}
+
+// GetContentStatsHandler handles the get_content_stats MCP tool call.
+func GetContentStatsHandler(st *summary_tool.SummaryTool, cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		nr, err := parseNavigationRequest(request, cfg)
+		if err != nil {
+			return nil, err
+		}
+		ctx, cancel := context.WithTimeout(ctx, nr.Navigation.Budget())
+		defer cancel()
+
+		page, err := nr.open(ctx, st.Playwright())
+		if err != nil {
+			return nil, err
+		}
+		defer page.Close()
+
+		stats, err := st.ExtractContentStats(ctx, page)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := json.Marshal(stats)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode content stats: %w", err)
+		}
+		return withMetadata(mcp.NewToolResultText(string(data)), nr.metadata(st.Playwright())), nil
+	}
+}
+
+// RunJSAuditHandler handles the run_js_audit MCP tool call.
+func RunJSAuditHandler(pi *playwright_integration.PlaywrightIntegration, cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		script, err := request.RequireString("script")
+		if err != nil {
+			return nil, fmt.Errorf("missing or invalid 'script' argument: %w", err)
+		}
+		if cfg.MaxScriptBytes > 0 && len(script) > cfg.MaxScriptBytes {
+			return nil, fmt.Errorf("invalid 'script' argument: %d bytes exceeds the limit of %d bytes", len(script), cfg.MaxScriptBytes)
+		}
+		nr, err := parseNavigationRequest(request, cfg)
+		if err != nil {
+			return nil, err
+		}
+		ctx, cancel := context.WithTimeout(ctx, nr.Navigation.Budget())
+		defer cancel()
+
+		page, err := nr.open(ctx, pi)
+		if err != nil {
+			return nil, err
+		}
+		defer page.Close()
+
+		result, err := pi.RunAudit(ctx, page, script)
+		if err != nil {
+			return nil, err
+		}
+		return withMetadata(mcp.NewToolResultText(string(result)), nr.metadata(pi)), nil
+	}
+}
+
+// GetStorageHandler handles the get_storage MCP tool call.
+func GetStorageHandler(pi *playwright_integration.PlaywrightIntegration, cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		nr, err := parseNavigationRequest(request, cfg)
+		if err != nil {
+			return nil, err
+		}
+		ctx, cancel := context.WithTimeout(ctx, nr.Navigation.Budget())
+		defer cancel()
+
+		page, err := nr.open(ctx, pi)
+		if err != nil {
+			return nil, err
+		}
+		defer page.Close()
+
+		return storageResult(ctx, pi, page, nr)
+	}
+}
+
+// SetStorageHandler handles the set_storage MCP tool call.
+func SetStorageHandler(pi *playwright_integration.PlaywrightIntegration, cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		nr, err := parseNavigationRequest(request, cfg)
+		if err != nil {
+			return nil, err
+		}
+		seed := playwright_integration.Storage{Origin: nr.URL}
+		if seed.LocalStorage, err = stringMapArgument(request, "local_storage"); err != nil {
+			return nil, err
+		}
+		if seed.SessionStorage, err = stringMapArgument(request, "session_storage"); err != nil {
+			return nil, err
+		}
+		ctx, cancel := context.WithTimeout(ctx, nr.Navigation.Budget())
+		defer cancel()
+
+		page, err := nr.newPage(ctx, pi)
+		if err != nil {
+			return nil, err
+		}
+		defer page.Close()
+
+		if err := pi.SeedStorage(page, seed); err != nil {
+			return nil, err
+		}
+		if err := nr.navigate(ctx, pi, page); err != nil {
+			return nil, err
+		}
+		return storageResult(ctx, pi, page, nr)
+	}
+}
+
+// storageResult returns the storage of a loaded page as a JSON tool result.
+func storageResult(ctx context.Context, pi *playwright_integration.PlaywrightIntegration, page playwright.Page, nr *navigationRequest) (*mcp.CallToolResult, error) {
+	storage, err := pi.GetStorage(ctx, page)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(storage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode storage: %w", err)
+	}
+	return withMetadata(mcp.NewToolResultText(string(data)), nr.metadata(pi)), nil
+}
+
+// GetTimingsHandler handles the get_timings MCP tool call.
+func GetTimingsHandler(pi *playwright_integration.PlaywrightIntegration, cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		nr, err := parseNavigationRequest(request, cfg)
+		if err != nil {
+			return nil, err
+		}
+		ctx, cancel := context.WithTimeout(ctx, nr.Navigation.Budget())
+		defer cancel()
+
+		page, err := nr.open(ctx, pi)
+		if err != nil {
+			return nil, err
+		}
+		defer page.Close()
+
+		timings, err := analysis.MeasureNavigationTimings(page)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := json.Marshal(timings)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode timings: %w", err)
+		}
+		return withMetadata(mcp.NewToolResultText(string(data)), nr.metadata(pi)), nil
+	}
+}
+
+// GetPerformanceHandler handles the get_performance MCP tool call.
+func GetPerformanceHandler(pi *playwright_integration.PlaywrightIntegration, cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		nr, err := parseNavigationRequest(request, cfg)
+		if err != nil {
+			return nil, err
+		}
+		ctx, cancel := context.WithTimeout(ctx, nr.Navigation.Budget())
+		defer cancel()
+
+		page, err := nr.open(ctx, pi)
+		if err != nil {
+			return nil, err
+		}
+		defer page.Close()
+
+		metrics, err := pi.PerformanceMetrics(ctx, page)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := json.Marshal(metrics)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode performance metrics: %w", err)
+		}
+		return withMetadata(mcp.NewToolResultText(string(data)), nr.metadata(pi)), nil
+	}
+}
+
+// GetTraceHandler handles the get_trace MCP tool call.
+func GetTraceHandler(pi *playwright_integration.PlaywrightIntegration, cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		nr, err := parseNavigationRequest(request, cfg)
+		if err != nil {
+			return nil, err
+		}
+		ctx, cancel := context.WithTimeout(ctx, nr.Navigation.Budget())
+		defer cancel()
+
+		page, err := nr.newPage(ctx, pi)
+		if err != nil {
+			return nil, err
+		}
+		defer page.Close()
+
+		trace, err := pi.RecordTrace(ctx, page, func() error {
+			return nr.navigate(ctx, pi, page)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		result := &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.NewTextContent(fmt.Sprintf("Playwright trace of %s (%d bytes)", nr.URL, len(trace))),
+				mcp.NewEmbeddedResource(mcp.BlobResourceContents{
+					URI:      nr.URL,
+					MIMEType: "application/zip",
+					Blob:     base64.StdEncoding.EncodeToString(trace),
+				}),
+			},
+		}
+		return withMetadata(result, nr.metadata(pi)), nil
+	}
+}
+
+// GetCoverageHandler handles the get_coverage MCP tool call.
+func GetCoverageHandler(pi *playwright_integration.PlaywrightIntegration, cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		nr, err := parseNavigationRequest(request, cfg)
+		if err != nil {
+			return nil, err
+		}
+		limit := request.GetInt("limit", playwright_integration.DefaultCoverageLimit)
+		if limit < 1 {
+			return nil, fmt.Errorf("invalid 'limit' argument: must be positive, got %d", limit)
+		}
+		ctx, cancel := context.WithTimeout(ctx, nr.Navigation.Budget())
+		defer cancel()
+
+		page, err := nr.newPage(ctx, pi)
+		if err != nil {
+			return nil, err
+		}
+		defer page.Close()
+
+		entries, err := pi.RecordCoverage(ctx, page, func() error {
+			return nr.navigate(ctx, pi, page)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(entries) > limit {
+			entries = entries[:limit]
+		}
+
+		data, err := json.Marshal(entries)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode coverage: %w", err)
+		}
+		return withMetadata(mcp.NewToolResultText(string(data)), nr.metadata(pi)), nil
+	}
+}
+
+// GetOpenGraphHandler handles the get_open_graph MCP tool call.
+func GetOpenGraphHandler(st *summary_tool.SummaryTool, cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		nr, err := parseNavigationRequest(request, cfg)
+		if err != nil {
+			return nil, err
+		}
+		ctx, cancel := context.WithTimeout(ctx, nr.Navigation.Budget())
+		defer cancel()
+
+		page, err := nr.open(ctx, st.Playwright())
+		if err != nil {
+			return nil, err
+		}
+		defer page.Close()
+
+		htmlContent, err := st.Playwright().GetContent(ctx, page)
+		if err != nil {
+			return nil, err
+		}
+
+		openGraph, err := st.ExtractOpenGraph(htmlContent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract Open Graph data: %w", err)
+		}
+
+		data, err := json.Marshal(openGraph)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode Open Graph data: %w", err)
+		}
+		return withMetadata(mcp.NewToolResultText(string(data)), nr.metadata(st.Playwright())), nil
+	}
+}
+
+// GetTwitterCardHandler handles the get_twitter_card MCP tool call.
+func GetTwitterCardHandler(st *summary_tool.SummaryTool, cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		nr, err := parseNavigationRequest(request, cfg)
+		if err != nil {
+			return nil, err
+		}
+		ctx, cancel := context.WithTimeout(ctx, nr.Navigation.Budget())
+		defer cancel()
+
+		page, err := nr.open(ctx, st.Playwright())
+		if err != nil {
+			return nil, err
+		}
+		defer page.Close()
+
+		htmlContent, err := st.Playwright().GetContent(ctx, page)
+		if err != nil {
+			return nil, err
+		}
+
+		twitterCard, err := st.ExtractTwitterCard(htmlContent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract Twitter card: %w", err)
+		}
+
+		data, err := json.Marshal(twitterCard)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode Twitter card: %w", err)
+		}
+		return withMetadata(mcp.NewToolResultText(string(data)), nr.metadata(st.Playwright())), nil
+	}
+}
+
+// GetManifestHandler handles the get_manifest MCP tool call.
+func GetManifestHandler(st *summary_tool.SummaryTool, cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		nr, err := parseNavigationRequest(request, cfg)
+		if err != nil {
+			return nil, err
+		}
+		ctx, cancel := context.WithTimeout(ctx, nr.Navigation.Budget())
+		defer cancel()
+
+		page, err := nr.open(ctx, st.Playwright())
+		if err != nil {
+			return nil, err
+		}
+		defer page.Close()
+
+		manifest, err := st.ExtractManifest(ctx, page)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := json.Marshal(manifest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode web app manifest: %w", err)
+		}
+		return withMetadata(mcp.NewToolResultText(string(data)), nr.metadata(st.Playwright())), nil
+	}
+}
+
+// GetTablesHandler handles the get_tables MCP tool call.
+func GetTablesHandler(st *summary_tool.SummaryTool, cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		nr, err := parseNavigationRequest(request, cfg)
+		if err != nil {
+			return nil, err
+		}
+		ctx, cancel := context.WithTimeout(ctx, nr.Navigation.Budget())
+		defer cancel()
+
+		page, err := nr.open(ctx, st.Playwright())
+		if err != nil {
+			return nil, err
+		}
+		defer page.Close()
+
+		htmlContent, err := st.Playwright().GetContent(ctx, page)
+		if err != nil {
+			return nil, err
+		}
+
+		tables, err := st.ExtractTables(htmlContent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract tables: %w", err)
+		}
+
+		data, err := json.Marshal(tables)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode tables: %w", err)
+		}
+		return withMetadata(mcp.NewToolResultText(string(data)), nr.metadata(st.Playwright())), nil
+	}
+}
+
+// ExtractTablesHandler handles the extract_tables MCP tool call.
+func ExtractTablesHandler(st *summary_tool.SummaryTool, cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		nr, err := parseNavigationRequest(request, cfg)
+		if err != nil {
+			return nil, err
+		}
+		ctx, cancel := context.WithTimeout(ctx, nr.Navigation.Budget())
+		defer cancel()
+
+		page, err := nr.open(ctx, st.Playwright())
+		if err != nil {
+			return nil, err
+		}
+		defer page.Close()
+
+		htmlContent, err := st.Playwright().GetContent(ctx, page)
+		if err != nil {
+			return nil, err
+		}
+
+		tables, err := st.ExtractTableRecords(htmlContent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract tables: %w", err)
+		}
+
+		data, err := json.Marshal(tables)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode tables: %w", err)
+		}
+		return withMetadata(mcp.NewToolResultText(string(data)), nr.metadata(st.Playwright())), nil
+	}
+}
+
+// SearchTextHandler handles the search_text MCP tool call.
+func SearchTextHandler(pi *playwright_integration.PlaywrightIntegration, cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		nr, err := parseNavigationRequest(request, cfg)
+		if err != nil {
+			return nil, err
+		}
+		query, err := request.RequireString("query")
+		if err != nil {
+			return nil, fmt.Errorf("missing or invalid 'query' argument: %w", err)
+		}
+		ctx, cancel := context.WithTimeout(ctx, nr.Navigation.Budget())
+		defer cancel()
+
+		page, err := nr.open(ctx, pi)
+		if err != nil {
+			return nil, err
+		}
+		defer page.Close()
+
+		search, err := pi.SearchText(ctx, page, query, request.GetBool("case_sensitive", false))
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := json.Marshal(search)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode text matches: %w", err)
+		}
+		return withMetadata(mcp.NewToolResultText(string(data)), nr.metadata(pi)), nil
+	}
+}
+
+// GetElementsByXPathHandler handles the get_elements_by_xpath MCP tool call.
+func GetElementsByXPathHandler(pi *playwright_integration.PlaywrightIntegration, cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		nr, err := parseNavigationRequest(request, cfg)
+		if err != nil {
+			return nil, err
+		}
+		xpath, err := request.RequireString("xpath")
+		if err != nil {
+			return nil, fmt.Errorf("missing or invalid 'xpath' argument: %w", err)
+		}
+		ctx, cancel := context.WithTimeout(ctx, nr.Navigation.Budget())
+		defer cancel()
+
+		page, err := nr.open(ctx, pi)
+		if err != nil {
+			return nil, err
+		}
+		defer page.Close()
+
+		elements, _, err := pi.QueryElements(ctx, page, request.GetString("frame", ""), "xpath="+xpath, request.GetInt("max_results", playwright_integration.DefaultMaxElements))
+		if err != nil {
+			return nil, fmt.Errorf("invalid or failing XPath %q: %w", xpath, err)
+		}
+
+		data, err := json.Marshal(elements)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode elements: %w", err)
+		}
+		return withMetadata(mcp.NewToolResultText(string(data)), nr.metadata(pi)), nil
+	}
+}
+
+// GetElementsHandler handles the get_elements MCP tool call.
+func GetElementsHandler(pi *playwright_integration.PlaywrightIntegration, cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		nr, err := parseNavigationRequest(request, cfg)
+		if err != nil {
+			return nil, err
+		}
+		selector, err := request.RequireString("selector")
+		if err != nil {
+			return nil, fmt.Errorf("missing or invalid 'selector' argument: %w", err)
+		}
+		ctx, cancel := context.WithTimeout(ctx, nr.Navigation.Budget())
+		defer cancel()
+
+		page, err := nr.open(ctx, pi)
+		if err != nil {
+			return nil, err
+		}
+		defer page.Close()
+
+		elements, total, err := pi.QueryElements(ctx, page, request.GetString("frame", ""), selector, request.GetInt("max_results", playwright_integration.DefaultMaxElements))
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := json.Marshal(map[string]any{
+			"elements":      elements,
+			"total_matches": total,
+			"truncated":     total > len(elements),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode elements: %w", err)
+		}
+		return withMetadata(mcp.NewToolResultText(string(data)), nr.metadata(pi)), nil
+	}
+}
+
+// ExtractElementsHandler handles the extract_elements MCP tool call.
+func ExtractElementsHandler(pi *playwright_integration.PlaywrightIntegration, cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		nr, err := parseNavigationRequest(request, cfg)
+		if err != nil {
+			return nil, err
+		}
+		selector, err := request.RequireString("selector")
+		if err != nil {
+			return nil, fmt.Errorf("missing or invalid 'selector' argument: %w", err)
+		}
+		attribute := request.GetString("attribute", "")
+
+		ctx, cancel := context.WithTimeout(ctx, nr.Navigation.Budget())
+		defer cancel()
+
+		page, err := nr.open(ctx, pi)
+		if err != nil {
+			return nil, err
+		}
+		defer page.Close()
+
+		values, err := pi.QueryAll(ctx, page, request.GetString("frame", ""), selector, attribute)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := json.Marshal(values)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode values: %w", err)
+		}
+		return withMetadata(mcp.NewToolResultText(string(data)), nr.metadata(pi)), nil
+	}
+}
+
+// CountElementsHandler handles the count_elements MCP tool call.
+func CountElementsHandler(pi *playwright_integration.PlaywrightIntegration, cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		nr, err := parseNavigationRequest(request, cfg)
+		if err != nil {
+			return nil, err
+		}
+		selector, err := request.RequireString("selector")
+		if err != nil {
+			return nil, fmt.Errorf("missing or invalid 'selector' argument: %w", err)
+		}
+		ctx, cancel := context.WithTimeout(ctx, nr.Navigation.Budget())
+		defer cancel()
+
+		page, err := nr.open(ctx, pi)
+		if err != nil {
+			return nil, err
+		}
+		defer page.Close()
+
+		count, err := pi.CountElements(ctx, page, request.GetString("frame", ""), selector)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := json.Marshal(map[string]int{"count": count})
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode count: %w", err)
+		}
+		return withMetadata(mcp.NewToolResultText(string(data)), nr.metadata(pi)), nil
+	}
+}
+
+// A11ySnapshotHandler handles the a11y_snapshot MCP tool call.
+func A11ySnapshotHandler(pi *playwright_integration.PlaywrightIntegration, cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		nr, err := parseNavigationRequest(request, cfg)
+		if err != nil {
+			return nil, err
+		}
+		interestingOnly := request.GetBool("interesting_only", true)
+
+		ctx, cancel := context.WithTimeout(ctx, nr.Navigation.Budget())
+		defer cancel()
+
+		page, err := nr.open(ctx, pi)
+		if err != nil {
+			return nil, err
+		}
+		defer page.Close()
+
+		snapshot, err := pi.AccessibilitySnapshot(ctx, page, interestingOnly)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := json.Marshal(snapshot)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode accessibility snapshot: %w", err)
+		}
+		return withMetadata(mcp.NewToolResultText(string(data)), nr.metadata(pi)), nil
+	}
+}
+
+// CheckContrastHandler handles the check_contrast MCP tool call.
+func CheckContrastHandler(pi *playwright_integration.PlaywrightIntegration, cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		nr, err := parseNavigationRequest(request, cfg)
+		if err != nil {
+			return nil, err
+		}
+		level := request.GetString("level", analysis.ContrastLevelAA)
+		if level != analysis.ContrastLevelAA && level != analysis.ContrastLevelAAA {
+			return nil, fmt.Errorf("invalid 'level' argument %q: expected AA or AAA", level)
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, nr.Navigation.Budget())
+		defer cancel()
+
+		page, err := nr.open(ctx, pi)
+		if err != nil {
+			return nil, err
+		}
+		defer page.Close()
+
+		violations, err := analysis.CheckContrast(page, level)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := json.Marshal(violations)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode contrast violations: %w", err)
+		}
+		return withMetadata(mcp.NewToolResultText(string(data)), nr.metadata(pi)), nil
+	}
+}
+
+// ClickElementHandler handles the click_element MCP tool call.
+func ClickElementHandler(pi *playwright_integration.PlaywrightIntegration, cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		nr, err := parseNavigationRequest(request, cfg)
+		if err != nil {
+			return nil, err
+		}
+		selector, err := request.RequireString("selector")
+		if err != nil {
+			return nil, fmt.Errorf("missing or invalid 'selector' argument: %w", err)
+		}
+		patternType := request.GetString("url_pattern_type", "glob")
+		if patternType != "glob" && patternType != "regex" {
+			return nil, fmt.Errorf("invalid 'url_pattern_type' argument %q: expected glob or regex", patternType)
+		}
+		clickOptions := playwright_integration.ClickOptions{
+			WaitForURL:   request.GetString("wait_for_url_pattern", ""),
+			RegexPattern: patternType == "regex",
+			Timeout:      time.Duration(request.GetInt("wait_timeout_ms", 10000)) * time.Millisecond,
+			Frame:        request.GetString("frame", ""),
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, nr.Navigation.Budget()+clickOptions.Timeout)
+		defer cancel()
+
+		page, err := nr.open(ctx, pi)
+		if err != nil {
+			return nil, err
+		}
+		defer page.Close()
+
+		click, err := pi.ClickElement(ctx, page, selector, clickOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		htmlContent, err := pi.GetContent(ctx, page)
+		if err != nil {
+			return nil, err
+		}
+		screenshot, err := pi.CaptureScreenshot(ctx, page, playwright_integration.PageScreenshotOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to capture screenshot: %w", err)
+		}
+
+		data, err := json.Marshal(map[string]any{
+			"url":         click.URL,
+			"url_matched": click.URLMatched,
+			"html":        htmlContent,
+			"screenshot":  screenshot,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode click result: %w", err)
+		}
+		return withMetadata(mcp.NewToolResultText(string(data)), nr.metadata(pi)), nil
+	}
+}
+
+// GetHARHandler handles the get_har MCP tool call.
+func GetHARHandler(pi *playwright_integration.PlaywrightIntegration, cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		nr, err := parseNavigationRequest(request, cfg)
+		if err != nil {
+			return nil, err
+		}
+		ctx, cancel := context.WithTimeout(ctx, nr.Navigation.Budget())
+		defer cancel()
+
+		page, err := nr.newCapturingPage(ctx, pi)
+		if err != nil {
+			return nil, err
+		}
+		defer page.Close()
+
+		capturedAt := time.Now()
+		if err := nr.navigate(ctx, pi, page); err != nil {
+			return nil, err
+		}
+
+		har, err := playwright_integration.ToHAR(pi.GetCapturedNetworkData(page), nr.URL, capturedAt)
+		if err != nil {
+			return nil, err
+		}
+		return withMetadata(mcp.NewToolResultText(string(har)), nr.metadata(pi)), nil
+	}
+}
+
+// ResolveURLHandler handles the resolve_url MCP tool call.
+func ResolveURLHandler(pi *playwright_integration.PlaywrightIntegration, cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		nr, err := parseNavigationRequest(request, cfg)
+		if err != nil {
+			return nil, err
+		}
+		ctx, cancel := context.WithTimeout(ctx, nr.Navigation.Budget())
+		defer cancel()
+
+		page, err := nr.open(ctx, pi)
+		if err != nil {
+			return nil, err
+		}
+		defer page.Close()
+
+		finalURL := page.URL()
+		data, err := json.Marshal(map[string]any{
+			"url":        nr.URL,
+			"final_url":  finalURL,
+			"redirected": finalURL != nr.URL,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode resolved URL: %w", err)
+		}
+		return withMetadata(mcp.NewToolResultText(string(data)), nr.metadata(pi)), nil
+	}
+}
+
+// GetNetworkActivityHandler handles the get_network_activity MCP tool call.
+func GetNetworkActivityHandler(pi *playwright_integration.PlaywrightIntegration, cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		nr, err := parseNavigationRequest(request, cfg)
+		if err != nil {
+			return nil, err
+		}
+		statusMin := request.GetInt("status_min", 0)
+		statusMax := request.GetInt("status_max", 0)
+		if statusMin < 0 || statusMax < 0 || (statusMax != 0 && statusMin > statusMax) {
+			return nil, fmt.Errorf("invalid status range: status_min %d, status_max %d", statusMin, statusMax)
+		}
+		ctx, cancel := context.WithTimeout(ctx, nr.Navigation.Budget())
+		defer cancel()
+
+		page, err := nr.newCapturingPage(ctx, pi)
+		if err != nil {
+			return nil, err
+		}
+		defer page.Close()
+
+		if err := nr.navigate(ctx, pi, page); err != nil {
+			return nil, err
+		}
+
+		data, err := json.Marshal(pi.FilterNetworkActivity(page, playwright_integration.NetworkActivityFilter{
+			StatusMin:    statusMin,
+			StatusMax:    statusMax,
+			ContentType:  request.GetString("content_type_filter", ""),
+			ResourceType: request.GetString("resource_type_filter", ""),
+		}))
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode network activity: %w", err)
+		}
+		return withMetadata(mcp.NewToolResultText(string(data)), nr.metadata(pi)), nil
+	}
+}
+
+// GetWebSocketActivityHandler handles the get_websocket_activity MCP tool call.
+func GetWebSocketActivityHandler(pi *playwright_integration.PlaywrightIntegration, cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		nr, err := parseNavigationRequest(request, cfg)
+		if err != nil {
+			return nil, err
+		}
+		ctx, cancel := context.WithTimeout(ctx, nr.Navigation.Budget())
+		defer cancel()
+
+		page, err := nr.newPage(ctx, pi)
+		if err != nil {
+			return nil, err
+		}
+		defer page.Close()
+
+		if err := pi.CaptureWebSockets(ctx, page); err != nil {
+			return nil, err
+		}
+		if err := nr.navigate(ctx, pi, page); err != nil {
+			return nil, err
+		}
+
+		data, err := json.Marshal(pi.GetCapturedWebSockets(page))
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode WebSocket activity: %w", err)
+		}
+		return withMetadata(mcp.NewToolResultText(string(data)), nr.metadata(pi)), nil
+	}
+}
+
+// CheckMixedContentHandler handles the check_mixed_content MCP tool call.
+func CheckMixedContentHandler(pi *playwright_integration.PlaywrightIntegration, cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		nr, err := parseNavigationRequest(request, cfg)
+		if err != nil {
+			return nil, err
+		}
+		ctx, cancel := context.WithTimeout(ctx, nr.Navigation.Budget())
+		defer cancel()
+
+		page, err := nr.newCapturingPage(ctx, pi)
+		if err != nil {
+			return nil, err
+		}
+		defer page.Close()
+
+		if err := nr.navigate(ctx, pi, page); err != nil {
+			return nil, err
+		}
+
+		mixed := analysis.DetectMixedContent(page.URL(), pi.GetCapturedNetworkData(page))
+		if mixed == nil {
+			mixed = []string{}
+		}
+		data, err := json.Marshal(map[string]any{
+			"url":                page.URL(),
+			"mixed_content_urls": mixed,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode mixed content: %w", err)
+		}
+		return withMetadata(mcp.NewToolResultText(string(data)), nr.metadata(pi)), nil
+	}
+}
+
+// GetThirdPartyResourcesHandler handles the get_third_party_resources MCP tool call.
+func GetThirdPartyResourcesHandler(pi *playwright_integration.PlaywrightIntegration, cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		nr, err := parseNavigationRequest(request, cfg)
+		if err != nil {
+			return nil, err
+		}
+		ctx, cancel := context.WithTimeout(ctx, nr.Navigation.Budget())
+		defer cancel()
+
+		page, err := nr.newCapturingPage(ctx, pi)
+		if err != nil {
+			return nil, err
+		}
+		defer page.Close()
+
+		if err := nr.navigate(ctx, pi, page); err != nil {
+			return nil, err
+		}
+
+		thirdParty, err := analysis.ListThirdPartyResources(page.URL(), pi.GetCapturedNetworkData(page))
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.Marshal(thirdParty)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode third-party resources: %w", err)
+		}
+		return withMetadata(mcp.NewToolResultText(string(data)), nr.metadata(pi)), nil
+	}
+}
+
+// ExtractGraphQLHandler handles the extract_graphql MCP tool call.
+func ExtractGraphQLHandler(pi *playwright_integration.PlaywrightIntegration, cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		nr, err := parseNavigationRequest(request, cfg)
+		if err != nil {
+			return nil, err
+		}
+		maxResponseBytes := request.GetInt("max_response_bytes", analysis.DefaultGraphQLResponseBytes)
+		if maxResponseBytes < 1 {
+			return nil, fmt.Errorf("invalid 'max_response_bytes' argument: must be positive, got %d", maxResponseBytes)
+		}
+		ctx, cancel := context.WithTimeout(ctx, nr.Navigation.Budget())
+		defer cancel()
+
+		page, err := nr.newCapturingPage(ctx, pi)
+		if err != nil {
+			return nil, err
+		}
+		defer page.Close()
+
+		if err := nr.navigate(ctx, pi, page); err != nil {
+			return nil, err
+		}
+
+		operations := analysis.ExtractGraphQLOperations(pi.GetCapturedNetworkData(page), maxResponseBytes)
+		data, err := json.Marshal(operations)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode GraphQL operations: %w", err)
+		}
+		return withMetadata(mcp.NewToolResultText(string(data)), nr.metadata(pi)), nil
+	}
+}
+
+// GetSecurityHeadersHandler handles the get_security_headers MCP tool call.
+func GetSecurityHeadersHandler(pi *playwright_integration.PlaywrightIntegration, cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		nr, err := parseNavigationRequest(request, cfg)
+		if err != nil {
+			return nil, err
+		}
+		ctx, cancel := context.WithTimeout(ctx, nr.Navigation.Budget())
+		defer cancel()
+
+		page, err := nr.newCapturingPage(ctx, pi)
+		if err != nil {
+			return nil, err
+		}
+		defer page.Close()
+
+		if err := nr.navigate(ctx, pi, page); err != nil {
+			return nil, err
+		}
+
+		response, ok := pi.DocumentResponse(page, page.URL())
+		if !ok {
+			return nil, fmt.Errorf("no document response was captured for %s", page.URL())
+		}
+		data, err := json.Marshal(analysis.AnalyzeSecurityHeaders(response.Headers))
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode security header report: %w", err)
+		}
+		return withMetadata(mcp.NewToolResultText(string(data)), nr.metadata(pi)), nil
+	}
+}
+
+// GetFrameworksHandler handles the get_frameworks MCP tool call.
+func GetFrameworksHandler(pi *playwright_integration.PlaywrightIntegration, cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		nr, err := parseNavigationRequest(request, cfg)
+		if err != nil {
+			return nil, err
+		}
+		ctx, cancel := context.WithTimeout(ctx, nr.Navigation.Budget())
+		defer cancel()
+
+		page, err := nr.open(ctx, pi)
+		if err != nil {
+			return nil, err
+		}
+		defer page.Close()
+
+		frameworks, err := analysis.DetectFramework(page)
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.Marshal(frameworks)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode frameworks: %w", err)
+		}
+		return withMetadata(mcp.NewToolResultText(string(data)), nr.metadata(pi)), nil
+	}
+}
+
+// GetProtocolHandler handles the get_protocol MCP tool call.
+func GetProtocolHandler(pi *playwright_integration.PlaywrightIntegration, cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		nr, err := parseNavigationRequest(request, cfg)
+		if err != nil {
+			return nil, err
+		}
+		ctx, cancel := context.WithTimeout(ctx, nr.Navigation.Budget())
+		defer cancel()
+
+		page, err := nr.newCapturingPage(ctx, pi)
+		if err != nil {
+			return nil, err
+		}
+		defer page.Close()
+
+		if err := nr.navigate(ctx, pi, page); err != nil {
+			return nil, err
+		}
+
+		resources := map[string]int{}
+		for _, activity := range pi.GetCapturedNetworkData(page) {
+			if protocol := activity.Response.Protocol; protocol != "" {
+				resources[protocol]++
+			}
+		}
+		finalURL := page.URL()
+		protocol := pi.DocumentProtocol(page, finalURL)
+		if protocol == "" {
+			return nil, fmt.Errorf("could not determine the protocol of %s; protocol detection requires Chromium", finalURL)
+		}
+
+		data, err := json.Marshal(map[string]any{
+			"url":       finalURL,
+			"protocol":  protocol,
+			"resources": resources,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode protocol: %w", err)
+		}
+		return withMetadata(mcp.NewToolResultText(string(data)), nr.metadata(pi)), nil
+	}
+}
+
+// GetPolicyHandler handles the get_policy MCP tool call.
+func GetPolicyHandler(pi *playwright_integration.PlaywrightIntegration) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		data, err := json.Marshal(pi.URLPolicy().Summary())
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode policy: %w", err)
+		}
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+// GetRedirectChainHandler handles the get_redirect_chain MCP tool call.
+func GetRedirectChainHandler(pi *playwright_integration.PlaywrightIntegration) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		url, err := request.RequireString("url")
+		if err != nil {
+			return nil, fmt.Errorf("missing or invalid 'url' argument: %w", err)
+		}
+
+		tracer := &analysis.RedirectTracer{}
+		if policy := pi.URLPolicy(); policy != nil {
+			tracer.Transport = policy.Transport()
+		}
+		hops, err := tracer.Trace(ctx, url)
+		loop := errors.Is(err, analysis.ErrRedirectLoop)
+		if err != nil && !loop && !errors.Is(err, analysis.ErrTooManyRedirects) {
+			return nil, err
+		}
+
+		result := map[string]any{
+			"chain":     hops,
+			"redirects": len(hops),
+			"loop":      loop,
+		}
+		if err == nil {
+			result["redirects"] = len(hops) - 1
+			result["final_url"] = hops[len(hops)-1].URL
+		} else {
+			result["error"] = err.Error()
+		}
+		data, err := json.Marshal(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode redirect chain: %w", err)
+		}
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+// WaitForResponseHandler handles the wait_for_response MCP tool call.
+func WaitForResponseHandler(pi *playwright_integration.PlaywrightIntegration, cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		nr, err := parseNavigationRequest(request, cfg)
+		if err != nil {
+			return nil, err
+		}
+		urlPattern, err := request.RequireString("url_pattern")
+		if err != nil {
+			return nil, fmt.Errorf("missing or invalid 'url_pattern' argument: %w", err)
+		}
+		ctx, cancel := context.WithTimeout(ctx, nr.Navigation.Budget())
+		defer cancel()
+
+		page, err := nr.newPage(ctx, pi)
+		if err != nil {
+			return nil, err
+		}
+		defer page.Close()
+
+		// Start waiting before navigating so responses fired during page load are matched.
+		activity, err := pi.WaitForResponse(ctx, page, urlPattern, nr.Navigation.Timeout.Seconds(), func() error {
+			return nr.navigate(ctx, pi, page)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := json.Marshal(activity)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode response: %w", err)
+		}
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+// WaitForURLHandler handles the wait_for_url MCP tool call.
+func WaitForURLHandler(pi *playwright_integration.PlaywrightIntegration, cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		nr, err := parseNavigationRequest(request, cfg)
+		if err != nil {
+			return nil, err
+		}
+		urlPattern, err := request.RequireString("url_pattern")
+		if err != nil {
+			return nil, fmt.Errorf("missing or invalid 'url_pattern' argument: %w", err)
+		}
+		selector := request.GetString("selector", "")
+
+		ctx, cancel := context.WithTimeout(ctx, nr.Navigation.Budget())
+		defer cancel()
+
+		page, err := nr.open(ctx, pi)
+		if err != nil {
+			return nil, err
+		}
+		defer page.Close()
+
+		if selector != "" {
+			if _, err := pi.ClickElement(ctx, page, selector, playwright_integration.ClickOptions{Timeout: nr.Navigation.Timeout}); err != nil {
+				return nil, err
+			}
+		}
+		finalURL, err := pi.WaitForURL(ctx, page, urlPattern, nr.Navigation.Timeout.Seconds())
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := json.Marshal(map[string]string{"url": finalURL})
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode URL: %w", err)
+		}
+		return withMetadata(mcp.NewToolResultText(string(data)), nr.metadata(pi)), nil
+	}
+}
+
+// parseActions decodes the actions argument, accepting either an array or a JSON string.
+func parseActions(request mcp.CallToolRequest) ([]playwright_integration.Action, error) {
+	raw, ok := request.GetArguments()["actions"]
+	if !ok {
+		return nil, fmt.Errorf("missing 'actions' argument")
+	}
+	data, isString := raw.(string)
+	if !isString {
+		encoded, err := json.Marshal(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid 'actions' argument: %w", err)
+		}
+		data = string(encoded)
+	}
+	var actions []playwright_integration.Action
+	if err := json.Unmarshal([]byte(data), &actions); err != nil {
+		return nil, fmt.Errorf("invalid 'actions' argument: %w", err)
+	}
+	if len(actions) == 0 {
+		return nil, fmt.Errorf("invalid 'actions' argument: at least one step is required")
+	}
+	return actions, nil
+}
+
+// RunActionsHandler handles the run_actions MCP tool call.
+func RunActionsHandler(pi *playwright_integration.PlaywrightIntegration, cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		nr, err := parseNavigationRequest(request, cfg)
+		if err != nil {
+			return nil, err
+		}
+		actions, err := parseActions(request)
+		if err != nil {
+			return nil, err
+		}
+		stepTimeout := time.Duration(request.GetInt("step_timeout_ms", 10000)) * time.Millisecond
+		if stepTimeout <= 0 {
+			return nil, fmt.Errorf("invalid 'step_timeout_ms' argument: must be positive")
+		}
+
+		// Budget a full navigation timeout for the initial load and every navigate step, and a
+		// step timeout (or the requested delay) for everything else.
+		budget := nr.Navigation.Budget()
+		for _, action := range actions {
+			switch action.Type {
+			case playwright_integration.ActionNavigate:
+				budget += nr.Navigation.Budget()
+			case playwright_integration.ActionWait:
+				budget += max(stepTimeout, time.Duration(action.DurationMs)*time.Millisecond)
+			default:
+				budget += stepTimeout
+			}
+		}
+		ctx, cancel := context.WithTimeout(ctx, budget)
+		defer cancel()
+
+		page, err := nr.open(ctx, pi)
+		if err != nil {
+			return nil, err
+		}
+		defer page.Close()
+
+		steps, err := pi.RunActions(ctx, page, actions, playwright_integration.ActionOptions{
+			Navigation:  nr.Navigation,
+			StepTimeout: stepTimeout,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var payload any = steps[len(steps)-1]
+		if request.GetBool("return_all", false) {
+			payload = steps
+		}
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode action results: %w", err)
+		}
+		result := mcp.NewToolResultText(string(data))
+		for _, step := range steps {
+			if step.Screenshot != nil {
+				result.Content = append(result.Content, mcp.NewImageContent(base64.StdEncoding.EncodeToString(step.Screenshot), "image/png"))
+			}
+		}
+		return withMetadata(result, nr.metadata(pi)), nil
 	}
 }
@@ -1,32 +1,48 @@
 package main
 
 import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"image"
+	_ "image/png"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/pdfcpu/pdfcpu/pkg/api"
 	"github.com/playwright-community/playwright-go"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/websocket"
 
+	"github.com/Camelket/mcp-browser-tools/internal/analysis"
+	"github.com/Camelket/mcp-browser-tools/internal/browser"
+	"github.com/Camelket/mcp-browser-tools/internal/config"
 	"github.com/Camelket/mcp-browser-tools/internal/playwright_integration"
+	"github.com/Camelket/mcp-browser-tools/internal/safety"
 	"github.com/Camelket/mcp-browser-tools/internal/summary_tool"
 )
 
 var (
-	pw      *playwright.Playwright
-	browser playwright.Browser
-	logger  *slog.Logger
+	browserManager *browser.BrowserInstanceManager
+	logger         *slog.Logger
 )
 
 func TestMain(m *testing.M) {
 	var err error
-	logger = slog.New(slog.NewTextHandler(os.Stdout, nil))
+	logger = slog.New(slog.NewTextHandler(os.Stderr, nil))
 
 	// Ensure Playwright browsers are installed
 	// This is typically done once during setup, but good to have for E2E tests
@@ -36,26 +52,13 @@ func TestMain(m *testing.M) {
 		os.Exit(1)
 	}
 
-	pw, err = PlaywrightRunFunc()
-	if err != nil {
-		logger.Error("Could not start Playwright", "error", err)
-		os.Exit(1)
-	}
-
-	browser, err = pw.Chromium.Launch()
-	if err != nil {
-		logger.Error("Could not launch Chromium", "error", err)
-		os.Exit(1)
-	}
+	browserManager = browser.NewBrowserInstanceManager(logger.With("component", "BrowserInstanceManager"))
 
 	code := m.Run()
 
-	if err := browser.Close(); err != nil {
+	if err := browserManager.CloseBrowserInstance(); err != nil {
 		logger.Error("Could not close browser", "error", err)
 	}
-	if err := pw.Stop(); err != nil {
-		logger.Error("Could not stop Playwright", "error", err)
-	}
 
 	os.Exit(code)
 }
@@ -71,6 +74,14 @@ func setupTestServer(t *testing.T, htmlContent string) *httptest.Server {
 	return ts
 }
 
+// newTestIntegration creates a PlaywrightIntegration backed by the shared browser manager.
+func newTestIntegration(t *testing.T) *playwright_integration.PlaywrightIntegration {
+	pwIntegration, err := playwright_integration.NewPlaywrightIntegration(browserManager, logger)
+	assert.NoError(t, err)
+	t.Cleanup(pwIntegration.Close)
+	return pwIntegration
+}
+
 func TestCapturePageSummary_Basic(t *testing.T) {
 	htmlContent := `
 		<!DOCTYPE html>
@@ -89,16 +100,13 @@ func TestCapturePageSummary_Basic(t *testing.T) {
 	ts := setupTestServer(t, htmlContent)
 	testURL := ts.URL
 
-	pwIntegration, err := playwright_integration.NewPlaywrightIntegration(pw, logger)
-	assert.NoError(t, err)
-	defer pwIntegration.Close()
-
+	pwIntegration := newTestIntegration(t)
 	st := summary_tool.NewSummaryTool(pwIntegration, logger)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	pageSummary, err := st.CapturePageSummary(ctx, testURL)
+	pageSummary, err := st.CapturePageSummary(ctx, testURL, summary_tool.SummaryOptions{})
 	assert.NoError(t, err)
 	assert.NotNil(t, pageSummary)
 
@@ -111,5 +119,1451 @@ func TestCapturePageSummary_Basic(t *testing.T) {
 		"https://example.com/link2",
 		testURL + "/link3.html",
 	}
-	assert.ElementsMatch(t, expectedLinks, pageSummary.Links)
+	assert.ElementsMatch(t, expectedLinks, pageSummary.LinkURLs())
+	assert.Equal(t, "Link 1", pageSummary.Links[0].Text)
+}
+
+func TestCapturePageSummary_CapturesXHR(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/data":
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"ok": true}`)
+		default:
+			fmt.Fprint(w, `
+				<!DOCTYPE html>
+				<html>
+				<body>
+					<div id="result"></div>
+					<script>
+						// A synchronous XHR completes before the load event, so the capture is deterministic.
+						var xhr = new XMLHttpRequest();
+						xhr.open('GET', '/api/data', false);
+						xhr.send();
+						document.getElementById('result').textContent = xhr.responseText;
+					</script>
+				</body>
+				</html>
+			`)
+		}
+	}))
+	t.Cleanup(ts.Close)
+
+	st := summary_tool.NewSummaryTool(newTestIntegration(t), logger)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pageSummary, err := st.CapturePageSummary(ctx, ts.URL, summary_tool.SummaryOptions{})
+	assert.NoError(t, err)
+	if assert.NotNil(t, pageSummary) {
+		assert.NotEmpty(t, pageSummary.NetworkActivity)
+
+		var sawXHR bool
+		for _, activity := range pageSummary.NetworkActivity {
+			if strings.HasSuffix(activity.Request.URL, "/api/data") {
+				sawXHR = true
+				assert.Equal(t, 200, activity.Response.Status)
+			}
+		}
+		assert.True(t, sawXHR, "expected the XHR to /api/data to be captured")
+	}
+}
+
+func TestCapturePageSummary_CapturesPutBody(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/items/1":
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"updated": true}`)
+		default:
+			fmt.Fprint(w, `
+				<!DOCTYPE html>
+				<html>
+				<body>
+					<script>
+						var xhr = new XMLHttpRequest();
+						xhr.open('PUT', '/api/items/1', false);
+						xhr.setRequestHeader('Content-Type', 'application/json');
+						xhr.send('{"name":"updated"}');
+					</script>
+				</body>
+				</html>
+			`)
+		}
+	}))
+	t.Cleanup(ts.Close)
+
+	st := summary_tool.NewSummaryTool(newTestIntegration(t), logger)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pageSummary, err := st.CapturePageSummary(ctx, ts.URL, summary_tool.SummaryOptions{})
+	assert.NoError(t, err)
+	if assert.NotNil(t, pageSummary) {
+		var sawPut bool
+		for _, activity := range pageSummary.NetworkActivity {
+			if activity.Request.Method == http.MethodPut && strings.HasSuffix(activity.Request.URL, "/api/items/1") {
+				sawPut = true
+				assert.JSONEq(t, `{"name":"updated"}`, activity.Request.Body)
+			}
+		}
+		assert.True(t, sawPut, "expected the PUT to /api/items/1 to be captured")
+	}
+}
+
+func TestCapturePageSummary_CapturesRepeatedAndFailedRequests(t *testing.T) {
+	var polls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/poll":
+			fmt.Fprintf(w, "poll %d", polls.Add(1))
+		default:
+			fmt.Fprint(w, `
+				<!DOCTYPE html>
+				<html>
+				<body>
+					<script>
+						for (let i = 0; i < 3; i++) {
+							const xhr = new XMLHttpRequest();
+							xhr.open('GET', '/api/poll', false);
+							xhr.send();
+						}
+						try {
+							const xhr = new XMLHttpRequest();
+							xhr.open('GET', 'http://127.0.0.1:1/unreachable', false);
+							xhr.send();
+						} catch (e) {}
+					</script>
+				</body>
+				</html>
+			`)
+		}
+	}))
+	t.Cleanup(ts.Close)
+
+	st := summary_tool.NewSummaryTool(newTestIntegration(t), logger)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pageSummary, err := st.CapturePageSummary(ctx, ts.URL, summary_tool.SummaryOptions{})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	var bodies []string
+	var failed []playwright_integration.CapturedNetworkActivity
+	for _, activity := range pageSummary.NetworkActivity {
+		if strings.HasSuffix(activity.Request.URL, "/api/poll") {
+			bodies = append(bodies, activity.Response.Body)
+		}
+		if activity.Failed {
+			failed = append(failed, activity)
+		}
+	}
+	assert.Equal(t, []string{"poll 1", "poll 2", "poll 3"}, bodies)
+	if assert.Len(t, failed, 1) {
+		assert.Equal(t, "http://127.0.0.1:1/unreachable", failed[0].Request.URL)
+		assert.NotEmpty(t, failed[0].Failure)
+	}
+}
+
+func TestCapturePageSummary_TruncatesLargeBodies(t *testing.T) {
+	large := strings.Repeat("x", playwright_integration.DefaultMaxBodyBytes+1000)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/large":
+			w.Header().Set("Content-Type", "text/plain")
+			fmt.Fprint(w, large)
+		default:
+			fmt.Fprint(w, `<html><body><script>
+				const xhr = new XMLHttpRequest();
+				xhr.open('GET', '/api/large', false);
+				xhr.send();
+			</script></body></html>`)
+		}
+	}))
+	t.Cleanup(ts.Close)
+
+	st := summary_tool.NewSummaryTool(newTestIntegration(t), logger)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pageSummary, err := st.CapturePageSummary(ctx, ts.URL, summary_tool.SummaryOptions{})
+	if !assert.NoError(t, err) {
+		return
+	}
+	var found bool
+	for _, activity := range pageSummary.NetworkActivity {
+		if strings.HasSuffix(activity.Request.URL, "/api/large") {
+			found = true
+			assert.True(t, activity.Response.BodyTruncated)
+			assert.Equal(t, len(large), activity.Response.BodySize)
+			assert.Len(t, activity.Response.Body, playwright_integration.DefaultMaxBodyBytes)
+		}
+	}
+	assert.True(t, found, "expected the XHR to /api/large to be captured")
+}
+
+func TestNavigateToURLOnPage_EmulatesLocaleAndTimezone(t *testing.T) {
+	ts := setupTestServer(t, `
+		<!DOCTYPE html>
+		<html>
+		<body>
+			<div id="locale"></div>
+			<div id="timezone"></div>
+			<script>
+				const options = Intl.DateTimeFormat().resolvedOptions();
+				document.getElementById('locale').textContent = options.locale;
+				document.getElementById('timezone').textContent = options.timeZone;
+			</script>
+		</body>
+		</html>
+	`)
+
+	pi := newTestIntegration(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	page, err := pi.NewPage(ctx, &playwright_integration.PageOptions{Locale: "de-DE", TimezoneID: "Asia/Tokyo"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer page.Close()
+
+	_, err = pi.NavigateToURLOnPage(ctx, page, ts.URL, nil)
+	assert.NoError(t, err)
+
+	locale, err := page.Locator("#locale").InnerText()
+	assert.NoError(t, err)
+	assert.Equal(t, "de-DE", locale)
+
+	timezone, err := page.Locator("#timezone").InnerText()
+	assert.NoError(t, err)
+	assert.Equal(t, "Asia/Tokyo", timezone)
+}
+
+func TestNavigateToURL_WaitUntilNetworkIdle(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/late":
+			fmt.Fprint(w, "late content")
+		default:
+			fmt.Fprint(w, `
+				<!DOCTYPE html>
+				<html>
+				<body>
+					<div id="result">pending</div>
+					<script>
+						// Fire the XHR after the load event so only networkidle waits for it.
+						window.addEventListener('load', () => setTimeout(() => {
+							fetch('/api/late').then(r => r.text()).then(t => {
+								document.getElementById('result').textContent = t;
+							});
+						}, 200));
+					</script>
+				</body>
+				</html>
+			`)
+		}
+	}))
+	t.Cleanup(ts.Close)
+
+	pi := newTestIntegration(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	result := func(waitUntil *playwright.WaitUntilState) string {
+		page, err := pi.NavigateToURL(ctx, ts.URL, &playwright_integration.NavigationOptions{WaitUntil: waitUntil})
+		if !assert.NoError(t, err) {
+			return ""
+		}
+		defer page.Close()
+		text, err := page.Locator("#result").InnerText()
+		assert.NoError(t, err)
+		return text
+	}
+
+	assert.Equal(t, "pending", result(playwright.WaitUntilStateLoad))
+	assert.Equal(t, "late content", result(playwright.WaitUntilStateNetworkidle))
+}
+
+func TestCapturePageSummary_NotFoundStatus(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `<html><body><h1>Not Found</h1></body></html>`)
+	}))
+	t.Cleanup(ts.Close)
+
+	pi := newTestIntegration(t)
+	st := summary_tool.NewSummaryTool(pi, logger)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// By default the error page is returned along with its status.
+	pageSummary, err := st.CapturePageSummary(ctx, ts.URL, summary_tool.SummaryOptions{})
+	assert.NoError(t, err)
+	if assert.NotNil(t, pageSummary) {
+		assert.Equal(t, http.StatusNotFound, pageSummary.StatusCode)
+		assert.Contains(t, pageSummary.HTML, "Not Found")
+	}
+
+	// With FailOnHTTPError the status becomes a structured error.
+	_, err = st.CapturePageSummary(ctx, ts.URL, summary_tool.SummaryOptions{
+		Navigation: &playwright_integration.NavigationOptions{FailOnHTTPError: true},
+	})
+	var statusErr *playwright_integration.HTTPStatusError
+	if assert.ErrorAs(t, err, &statusErr) {
+		assert.Equal(t, http.StatusNotFound, statusErr.StatusCode)
+	}
+}
+
+func TestCapturePageSummary_CaptureWithImageBlocking(t *testing.T) {
+	var imageRequests atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/logo.png":
+			imageRequests.Add(1)
+			w.Header().Set("Content-Type", "image/png")
+		case "/api/data":
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"ok": true}`)
+		default:
+			fmt.Fprint(w, `
+				<!DOCTYPE html>
+				<html>
+				<body>
+					<img src="/logo.png">
+					<script>
+						var xhr = new XMLHttpRequest();
+						xhr.open('GET', '/api/data', false);
+						xhr.send();
+					</script>
+				</body>
+				</html>
+			`)
+		}
+	}))
+	t.Cleanup(ts.Close)
+
+	st := summary_tool.NewSummaryTool(newTestIntegration(t), logger)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// Capture and blocking share one route callback, so neither handles a request twice.
+	pageSummary, err := st.CapturePageSummary(ctx, ts.URL, summary_tool.SummaryOptions{
+		Interception: &playwright_integration.InterceptionOptions{BlockResources: []string{"image"}},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Zero(t, imageRequests.Load())
+	assert.Equal(t, 1, pageSummary.BlockedRequests)
+
+	var capturedAPI bool
+	for _, activity := range pageSummary.NetworkActivity {
+		assert.NotContains(t, activity.Request.URL, "/logo.png", "blocked requests are not captured")
+		if strings.HasSuffix(activity.Request.URL, "/api/data") {
+			capturedAPI = true
+		}
+	}
+	assert.True(t, capturedAPI)
+}
+
+func TestDetectFramework(t *testing.T) {
+	ts := setupTestServer(t, `
+		<!DOCTYPE html>
+		<html>
+		<body>
+			<div id="__next"><div x-data="{ open: false }">Hello</div></div>
+			<script>window.React = { version: "18.2.0" };</script>
+		</body>
+		</html>
+	`)
+
+	pi := newTestIntegration(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	page, err := pi.NavigateToURL(ctx, ts.URL, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer page.Close()
+
+	frameworks, err := analysis.DetectFramework(page)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"React", "Next.js", "Alpine.js"}, frameworks)
+}
+
+func TestCapturePageSummary_ReportsProgress(t *testing.T) {
+	ts := setupTestServer(t, `<html><body><a href="/next">Next</a></body></html>`)
+
+	st := summary_tool.NewSummaryTool(newTestIntegration(t), logger)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var messages []string
+	var last float64
+	_, err := st.CapturePageSummary(ctx, ts.URL, summary_tool.SummaryOptions{
+		Progress: func(done float64, message string) {
+			assert.GreaterOrEqual(t, done, last, "progress must not go backwards (%s)", message)
+			last = done
+			messages = append(messages, message)
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"Page created",
+		"Navigating to " + ts.URL,
+		"Navigation complete",
+		"Content captured",
+		"Screenshot captured",
+		"Links extracted",
+		"Page summary complete",
+	}, messages)
+	assert.Equal(t, 1.0, last)
+}
+
+func TestRunActions_KeepsPageStateBetweenSteps(t *testing.T) {
+	ts := setupTestServer(t, `
+		<!DOCTYPE html>
+		<html>
+		<body>
+			<input id="name">
+			<button onclick="document.getElementById('out').textContent = 'Hello, ' + document.getElementById('name').value">Greet</button>
+			<p id="out"></p>
+		</body>
+		</html>
+	`)
+
+	pi := newTestIntegration(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	page, err := pi.NavigateToURL(ctx, ts.URL, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer page.Close()
+
+	results, err := pi.RunActions(ctx, page, []playwright_integration.Action{
+		{Type: playwright_integration.ActionType, Selector: "#name", Text: "Ada"},
+		{Type: playwright_integration.ActionClick, Selector: "button"},
+		{Type: playwright_integration.ActionWait, Selector: "#out:has-text('Hello, Ada')"},
+		{Type: playwright_integration.ActionScreenshot},
+	}, playwright_integration.ActionOptions{StepTimeout: 5 * time.Second})
+	assert.NoError(t, err)
+	if assert.Len(t, results, 4) {
+		assert.Equal(t, ts.URL+"/", results[3].URL)
+		assert.NotEmpty(t, results[3].Screenshot)
+	}
+
+	_, err = pi.RunActions(ctx, page, []playwright_integration.Action{
+		{Type: playwright_integration.ActionClick, Selector: "#missing"},
+	}, playwright_integration.ActionOptions{StepTimeout: 500 * time.Millisecond})
+	assert.ErrorContains(t, err, "step 0 (click) failed")
+}
+
+// setupSlowServer creates a test server whose responses take 10 seconds, or until the client
+// goes away.
+func setupSlowServer(t *testing.T) *httptest.Server {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(10 * time.Second):
+		case <-r.Context().Done():
+			return
+		}
+		fmt.Fprint(w, "<html><body>Finally</body></html>")
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestNavigateToURLOnPage_CancelledMidNavigation(t *testing.T) {
+	ts := setupSlowServer(t)
+	pi := newTestIntegration(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	page, err := pi.NewPage(ctx, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer page.Close()
+
+	time.AfterFunc(500*time.Millisecond, cancel)
+	start := time.Now()
+	_, err = pi.NavigateToURLOnPage(ctx, page, ts.URL, nil)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, time.Since(start), 5*time.Second, "navigation should abort promptly")
+	assert.Eventually(t, page.IsClosed, 2*time.Second, 50*time.Millisecond, "the page should be closed on cancellation")
+}
+
+func TestGetHTMLHandler_Cancelled(t *testing.T) {
+	ts := setupSlowServer(t)
+	handler := GetHTMLHandler(newTestIntegration(t), config.Default(), nil, nil)
+
+	var request mcp.CallToolRequest
+	request.Params.Name = "get_html"
+	request.Params.Arguments = map[string]any{"url": ts.URL}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(500*time.Millisecond, cancel)
+	start := time.Now()
+	_, err := handler(ctx, request)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, time.Since(start), 5*time.Second, "the handler should return promptly")
+}
+
+func TestGetHTMLHandler_RespectsRobotsTxt(t *testing.T) {
+	var pageRequests atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/robots.txt", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "User-agent: *\nDisallow: /private\nCrawl-delay: 1\n")
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		pageRequests.Add(1)
+		fmt.Fprint(w, "<html><body>Hello</body></html>")
+	})
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+
+	handler := GetHTMLHandler(newTestIntegration(t), config.Default(), nil, nil)
+	call := func(path string, respect bool) (*mcp.CallToolResult, error) {
+		var request mcp.CallToolRequest
+		request.Params.Name = "get_html"
+		request.Params.Arguments = map[string]any{"url": ts.URL + path, "respect_robots_txt": respect}
+		return handler(context.Background(), request)
+	}
+
+	_, err := call("/private/page", true)
+	var disallowed *analysis.RobotsDisallowedError
+	assert.ErrorAs(t, err, &disallowed)
+	assert.False(t, disallowed.Result.Allowed)
+	assert.Equal(t, int32(0), pageRequests.Load(), "a disallowed page should not be requested")
+
+	result, err := call("/public", true)
+	assert.NoError(t, err)
+	metadata := result.Content[len(result.Content)-1].(mcp.TextContent).Text
+	assert.Contains(t, metadata, `"crawl_delay_seconds":1`)
+
+	// Without the option robots.txt is ignored.
+	_, err = call("/private/page", false)
+	assert.NoError(t, err)
+}
+
+func TestCrawlHandler_RespectsRobotsTxt(t *testing.T) {
+	var privateRequests atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/robots.txt", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "User-agent: *\nDisallow: /private\n")
+	})
+	mux.HandleFunc("/private", func(w http.ResponseWriter, r *http.Request) {
+		privateRequests.Add(1)
+		fmt.Fprint(w, "<html><body>Secret</body></html>")
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<html><body><a href="/public">Public</a><a href="/private">Private</a></body></html>`)
+	})
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+
+	handler := CrawlHandler(summary_tool.NewSummaryTool(newTestIntegration(t), logger), config.Default())
+	call := func(path string) (*mcp.CallToolResult, error) {
+		var request mcp.CallToolRequest
+		request.Params.Name = "crawl"
+		request.Params.Arguments = map[string]any{"url": ts.URL + path, "respect_robots_txt": true}
+		return handler(context.Background(), request)
+	}
+
+	result, err := call("/")
+	if assert.NoError(t, err) {
+		var siteMap map[string][]string
+		if assert.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &siteMap)) {
+			assert.Contains(t, siteMap, ts.URL+"/public")
+			assert.NotContains(t, siteMap, ts.URL+"/private")
+		}
+	}
+	assert.Equal(t, int32(0), privateRequests.Load(), "a disallowed page should not be requested")
+
+	_, err = call("/private")
+	var disallowed *analysis.RobotsDisallowedError
+	assert.ErrorAs(t, err, &disallowed)
+}
+
+func TestCrawlHandler_RejectsInvalidBounds(t *testing.T) {
+	handler := CrawlHandler(summary_tool.NewSummaryTool(nil, logger), config.Default())
+	for _, tc := range []struct {
+		args map[string]any
+		want string
+	}{
+		{map[string]any{"url": "https://example.com", "max_pages": 0}, "invalid 'max_pages' argument"},
+		{map[string]any{"url": "https://example.com", "concurrency": -1}, "invalid 'concurrency' argument"},
+	} {
+		var request mcp.CallToolRequest
+		request.Params.Name = "crawl"
+		request.Params.Arguments = tc.args
+		_, err := handler(context.Background(), request)
+		assert.ErrorContains(t, err, tc.want)
+	}
+}
+
+func TestGetResourceTimingsHandler_RejectsNonPositiveMaxEntries(t *testing.T) {
+	handler := GetResourceTimingsHandler(nil, config.Default())
+	var request mcp.CallToolRequest
+	request.Params.Arguments = map[string]any{"url": "https://example.com", "max_entries": 0}
+	_, err := handler(context.Background(), request)
+	assert.ErrorContains(t, err, "invalid 'max_entries' argument")
+}
+
+func TestSeedAndGetStorage(t *testing.T) {
+	ts := setupTestServer(t, `
+		<html>
+		<body>
+			<div id="token"></div>
+			<script>
+				// Page scripts run after the seed, so they see the seeded token.
+				document.getElementById('token').textContent = localStorage.getItem('auth_token');
+				sessionStorage.setItem('visited', 'yes');
+			</script>
+		</body>
+		</html>
+	`)
+	pi := newTestIntegration(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	page, err := pi.NewPage(ctx, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer page.Close()
+
+	err = pi.SeedStorage(page, playwright_integration.Storage{
+		Origin:       ts.URL + "/login",
+		LocalStorage: map[string]string{"auth_token": "secret"},
+	})
+	assert.NoError(t, err)
+	_, err = pi.NavigateToURLOnPage(ctx, page, ts.URL, nil)
+	assert.NoError(t, err)
+
+	token, err := page.Locator("#token").InnerText()
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", token)
+
+	storage, err := pi.GetStorage(ctx, page)
+	if assert.NoError(t, err) {
+		assert.Equal(t, ts.URL, storage.Origin)
+		assert.Equal(t, map[string]string{"auth_token": "secret"}, storage.LocalStorage)
+		assert.Equal(t, map[string]string{"visited": "yes"}, storage.SessionStorage)
+	}
+}
+
+func TestMeasureNavigationTimings(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		fmt.Fprint(w, "<html><body>Timed</body></html>")
+	}))
+	t.Cleanup(ts.Close)
+	pi := newTestIntegration(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	page, err := pi.NavigateToURL(ctx, ts.URL, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer page.Close()
+
+	timings, err := analysis.MeasureNavigationTimings(page)
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, timings.TTFB, 50*time.Millisecond, "the server delays the first byte")
+	assert.GreaterOrEqual(t, timings.DOMContentLoaded, timings.TTFB)
+	assert.GreaterOrEqual(t, timings.Load, timings.DOMContentLoaded)
+}
+
+func TestCheckContrast(t *testing.T) {
+	ts := setupTestServer(t, `<html><body style="background: #fff">
+		<p id="low" style="color: #777">Low contrast</p>
+		<p id="aaa" style="color: #666">Passes AA only</p>
+		<p id="ok" style="color: #000">Black on white</p>
+		<p style="display: none; color: #eee">Hidden</p>
+	</body></html>`)
+	pi := newTestIntegration(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	page, err := pi.NavigateToURL(ctx, ts.URL, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer page.Close()
+
+	violations, err := analysis.CheckContrast(page, analysis.ContrastLevelAA)
+	assert.NoError(t, err)
+	assert.Equal(t, []analysis.ContrastViolation{{
+		Selector:   "#low",
+		Text:       "Low contrast",
+		FGColor:    "#777777",
+		BGColor:    "#ffffff",
+		Ratio:      4.48,
+		FailsLevel: analysis.ContrastLevelAA,
+	}}, violations)
+
+	violations, err = analysis.CheckContrast(page, analysis.ContrastLevelAAA)
+	assert.NoError(t, err)
+	if assert.Len(t, violations, 2) {
+		assert.Equal(t, "#low", violations[0].Selector, "worst ratio first")
+		assert.Equal(t, "#aaa", violations[1].Selector)
+		assert.Equal(t, analysis.ContrastLevelAAA, violations[1].FailsLevel)
+	}
+}
+
+func TestWaitForURL(t *testing.T) {
+	ts := setupTestServer(t, `<html><body>
+		<a id="route" href="#" onclick="setTimeout(() => history.pushState({}, '', '/orders/42'), 200); return false;">Orders</a>
+	</body></html>`)
+	pi := newTestIntegration(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	page, err := pi.NavigateToURL(ctx, ts.URL, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer page.Close()
+
+	_, err = pi.ClickElement(ctx, page, "#route", playwright_integration.ClickOptions{})
+	assert.NoError(t, err)
+	finalURL, err := pi.WaitForURL(ctx, page, `/\/orders\/[0-9]+$/`, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, ts.URL+"/orders/42", finalURL)
+
+	_, err = pi.WaitForURL(ctx, page, "**/never", 0.5)
+	assert.ErrorIs(t, err, playwright.ErrTimeout)
+}
+
+func TestRenderPDFs(t *testing.T) {
+	first := setupTestServer(t, `<html><body><h1>First</h1></body></html>`)
+	second := setupTestServer(t, `<html><body><h1>Second</h1></body></html>`)
+	pi := newTestIntegration(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	pdfs, err := pi.RenderPDFs(ctx, []string{first.URL, second.URL}, playwright_integration.PDFOptions{Format: "A4"}, nil, 2)
+	assert.NoError(t, err)
+	if assert.Len(t, pdfs, 2) {
+		for _, pdf := range pdfs {
+			assert.True(t, bytes.HasPrefix(pdf, []byte("%PDF-")))
+		}
+	}
+
+	_, err = pi.RenderPDFs(ctx, []string{first.URL, "http://127.0.0.1:1/"}, playwright_integration.PDFOptions{}, nil, 2)
+	assert.ErrorContains(t, err, "failed to render http://127.0.0.1:1/")
+}
+
+func TestGetPDFBatchHandler_MergesPDFs(t *testing.T) {
+	first := setupTestServer(t, `<html><body><h1>First</h1></body></html>`)
+	second := setupTestServer(t, `<html><body><h1>Second</h1></body></html>`)
+
+	handler := GetPDFBatchHandler(newTestIntegration(t), config.Default())
+	var request mcp.CallToolRequest
+	request.Params.Name = "get_pdf_batch"
+	request.Params.Arguments = map[string]any{"urls": []any{first.URL, second.URL}}
+	result, err := handler(context.Background(), request)
+	if !assert.NoError(t, err) || !assert.Len(t, result.Content, 2) {
+		return
+	}
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "2. "+second.URL)
+	blob := result.Content[1].(mcp.EmbeddedResource).Resource.(mcp.BlobResourceContents)
+	assert.Equal(t, "application/pdf", blob.MIMEType)
+	pdf, err := base64.StdEncoding.DecodeString(blob.Blob)
+	if assert.NoError(t, err) {
+		pages, err := api.PageCount(bytes.NewReader(pdf), nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, pages)
+	}
+}
+
+func TestPerformanceMetrics(t *testing.T) {
+	ts := setupTestServer(t, `<html><body><h1>Painted</h1></body></html>`)
+	pi := newTestIntegration(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	page, err := pi.NavigateToURL(ctx, ts.URL, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer page.Close()
+
+	metrics, err := pi.PerformanceMetrics(ctx, page)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "navigation_timing", metrics.Source)
+	if assert.NotNil(t, metrics.TTFB) && assert.NotNil(t, metrics.Load) {
+		assert.GreaterOrEqual(t, *metrics.Load, *metrics.TTFB)
+	}
+	assert.NotNil(t, metrics.FirstContentfulPaint, "Chromium reports paint entries for pages with text")
+}
+
+func TestRecordTrace(t *testing.T) {
+	ts := setupTestServer(t, `<html><body><h1>Traced</h1></body></html>`)
+	pi := newTestIntegration(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	page, err := pi.NewPage(ctx, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer page.Close()
+
+	trace, err := pi.RecordTrace(ctx, page, func() error {
+		_, err := pi.NavigateToURLOnPage(ctx, page, ts.URL, nil)
+		return err
+	})
+	if assert.NoError(t, err) {
+		reader, err := zip.NewReader(bytes.NewReader(trace), int64(len(trace)))
+		if assert.NoError(t, err) {
+			var names []string
+			for _, f := range reader.File {
+				names = append(names, f.Name)
+			}
+			assert.Contains(t, names, "trace.trace")
+		}
+	}
+
+	_, err = pi.RecordTrace(ctx, page, func() error { return errors.New("navigation failed") })
+	assert.EqualError(t, err, "navigation failed")
+}
+
+func TestRecordCoverage(t *testing.T) {
+	ts := setupTestServer(t, `<html><head><style>h1 { color: red; } .unused { color: blue; }</style></head>
+<body><h1>Covered</h1><script>function used() { return 1; } function unused() { return 2; } used();</script></body></html>`)
+	pi := newTestIntegration(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	page, err := pi.NewPage(ctx, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer page.Close()
+
+	entries, err := pi.RecordCoverage(ctx, page, func() error {
+		_, err := pi.NavigateToURLOnPage(ctx, page, ts.URL, nil)
+		return err
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	types := map[string]playwright_integration.CoverageEntry{}
+	for _, entry := range entries {
+		types[entry.Type] = entry
+	}
+	for _, kind := range []string{playwright_integration.CoverageTypeJS, playwright_integration.CoverageTypeCSS} {
+		entry, ok := types[kind]
+		if assert.True(t, ok, kind) {
+			assert.Greater(t, entry.UsedBytes, 0, kind)
+			assert.Less(t, entry.UsedBytes, entry.TotalBytes, kind)
+		}
+	}
+}
+
+func TestGetWebSocketActivityHandler(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle("/ws", websocket.Handler(func(conn *websocket.Conn) {
+		var message string
+		for websocket.Message.Receive(conn, &message) == nil {
+			websocket.Message.Send(conn, "echo: "+message)
+		}
+	}))
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<html><body><script>
+			const ws = new WebSocket('ws://' + location.host + '/ws');
+			ws.onopen = () => ws.send('hello');
+		</script></body></html>`)
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	var request mcp.CallToolRequest
+	request.Params.Name = "get_websocket_activity"
+	request.Params.Arguments = map[string]any{"url": ts.URL, "settle_ms": 1000}
+	result, err := GetWebSocketActivityHandler(newTestIntegration(t), config.Default())(context.Background(), request)
+	if !assert.NoError(t, err) {
+		return
+	}
+	var sockets []playwright_integration.WebSocketActivity
+	if !assert.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &sockets)) || !assert.Len(t, sockets, 1) {
+		return
+	}
+	assert.True(t, strings.HasSuffix(sockets[0].URL, "/ws"))
+	var frames []string
+	for _, frame := range sockets[0].Frames {
+		frames = append(frames, frame.Direction+" "+frame.Payload)
+	}
+	assert.Equal(t, []string{"sent hello", "received echo: hello"}, frames)
+}
+
+func TestGetHTMLHandler_Source(t *testing.T) {
+	source := `<html><body><div id="app"></div><script>document.getElementById('app').textContent = 'Rendered';</script></body></html>`
+	ts := setupTestServer(t, source)
+
+	handler := GetHTMLHandler(newTestIntegration(t), config.Default(), nil, nil)
+	call := func(args map[string]any) (*mcp.CallToolResult, error) {
+		var request mcp.CallToolRequest
+		request.Params.Name = "get_html"
+		request.Params.Arguments = args
+		return handler(context.Background(), request)
+	}
+
+	result, err := call(map[string]any{"url": ts.URL})
+	if assert.NoError(t, err) {
+		assert.Contains(t, result.Content[0].(mcp.TextContent).Text, `<div id="app">Rendered</div>`)
+	}
+
+	result, err = call(map[string]any{"url": ts.URL, "rendered": false})
+	if assert.NoError(t, err) {
+		assert.Equal(t, source, result.Content[0].(mcp.TextContent).Text)
+	}
+
+	_, err = call(map[string]any{"url": ts.URL, "rendered": false, "mode": "head"})
+	assert.ErrorContains(t, err, "only full is supported")
+}
+
+func TestGetManifestHandler(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/manifest.webmanifest", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name":"Test App","display":"standalone","icons":[{"src":"icon.png","sizes":"192x192"}]}`)
+	})
+	mux.HandleFunc("/plain", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<html><body>No manifest</body></html>`)
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<html><head><link rel="manifest" href="/manifest.webmanifest"></head><body>App</body></html>`)
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	handler := GetManifestHandler(summary_tool.NewSummaryTool(newTestIntegration(t), logger), config.Default())
+	call := func(url string) (*mcp.CallToolResult, error) {
+		var request mcp.CallToolRequest
+		request.Params.Name = "get_manifest"
+		request.Params.Arguments = map[string]any{"url": url}
+		return handler(context.Background(), request)
+	}
+
+	result, err := call(ts.URL)
+	if assert.NoError(t, err) {
+		var manifest summary_tool.WebAppManifest
+		if assert.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &manifest)) {
+			assert.Equal(t, "Test App", manifest.Name)
+			assert.Equal(t, "standalone", manifest.Display)
+			assert.Equal(t, []summary_tool.ManifestIcon{{Src: ts.URL + "/icon.png", Sizes: "192x192"}}, manifest.Icons)
+		}
+	}
+
+	result, err = call(ts.URL + "/plain")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "null", result.Content[0].(mcp.TextContent).Text)
+	}
+}
+
+func TestGetHTMLHandler_Latin1AndGzip(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/data.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		fmt.Fprint(gz, `{"dish":"crème brûlée"}`)
+		gz.Close()
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=iso-8859-1")
+		fmt.Fprint(w, "<html><body><p>Caf\xe9</p><script>fetch('/data.json')</script></body></html>")
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	pi := newTestIntegration(t)
+	handler := GetHTMLHandler(pi, config.Default(), nil, nil)
+	var request mcp.CallToolRequest
+	request.Params.Name = "get_html"
+	request.Params.Arguments = map[string]any{"url": ts.URL, "rendered": false, "wait_until": "networkidle"}
+	result, err := handler(context.Background(), request)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "<p>Café</p>")
+	assert.Contains(t, result.Content[1].(mcp.TextContent).Text, `"charset":"iso-8859-1"`)
+
+	// The handler's page and its capture are gone once it returns, so capture on a page of our own.
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	page, err := pi.NewPage(ctx, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer page.Close()
+	if !assert.NoError(t, pi.SetupNetworkInterception(ctx, page, nil)) {
+		return
+	}
+	_, err = pi.NavigateToURLOnPage(ctx, page, ts.URL, &playwright_integration.NavigationOptions{WaitUntil: playwright.WaitUntilStateNetworkidle})
+	if !assert.NoError(t, err) {
+		return
+	}
+	var body string
+	for _, activity := range pi.GetCapturedNetworkData(page) {
+		if strings.HasSuffix(activity.Request.URL, "/data.json") {
+			body = activity.Response.Body
+		}
+	}
+	assert.Equal(t, `{"dish":"crème brûlée"}`, body)
+
+	request.Params.Arguments = map[string]any{"url": ts.URL}
+	result, err = handler(context.Background(), request)
+	if assert.NoError(t, err) {
+		assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "<p>Café</p>")
+		assert.Contains(t, result.Content[1].(mcp.TextContent).Text, `"charset":"windows-1252"`)
+	}
+}
+
+func TestClickElement_SavesDebugScreenshot(t *testing.T) {
+	ts := setupTestServer(t, `<html><body><button id="real">Go</button></body></html>`)
+	pi := newTestIntegration(t)
+	dir := t.TempDir()
+	pi.SetDebugScreenshotDir(dir)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	page, err := pi.NavigateToURL(ctx, ts.URL, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer page.Close()
+
+	_, err = pi.ClickElement(ctx, page, "#missing", playwright_integration.ClickOptions{Timeout: 500 * time.Millisecond})
+	var debugErr *playwright_integration.DebugScreenshotError
+	if assert.ErrorAs(t, err, &debugErr) {
+		assert.ErrorIs(t, err, playwright.ErrTimeout)
+		data, err := os.ReadFile(debugErr.Path)
+		assert.NoError(t, err)
+		_, format, err := image.DecodeConfig(bytes.NewReader(data))
+		assert.NoError(t, err)
+		assert.Equal(t, "png", format)
+	}
+}
+
+func TestRunAudit(t *testing.T) {
+	ts := setupTestServer(t, `<html><body><img src="a.png"><img src="b.png" alt="B"></body></html>`)
+	pi := newTestIntegration(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	page, err := pi.NavigateToURL(ctx, ts.URL, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer page.Close()
+
+	result, err := pi.RunAudit(ctx, page, "document.querySelectorAll('img:not([alt])').length;")
+	assert.NoError(t, err)
+	assert.JSONEq(t, `1`, string(result))
+
+	// A function body runs once even though it is first tried as an expression.
+	result, err = pi.RunAudit(ctx, page, "window.runs = (window.runs || 0) + 1; return {runs: window.runs, title: document.title};")
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"runs": 1, "title": ""}`, string(result))
+
+	// A SyntaxError thrown at runtime is reported, not mistaken for a function body.
+	_, err = pi.RunAudit(ctx, page, "JSON.parse('{')")
+	var scriptErr *playwright_integration.ScriptError
+	if assert.ErrorAs(t, err, &scriptErr) {
+		assert.Contains(t, scriptErr.Stack, "SyntaxError")
+	}
+
+	_, err = pi.RunAudit(ctx, page, "throw new Error('audit failed')")
+	if assert.ErrorAs(t, err, &scriptErr) {
+		assert.Equal(t, "audit failed", scriptErr.Message)
+		assert.NotEmpty(t, scriptErr.Stack)
+	}
+
+	_, err = pi.RunAudit(ctx, page, "const a = {}; a.self = a; return a;")
+	if assert.ErrorAs(t, err, &scriptErr) {
+		assert.Contains(t, scriptErr.Message, "JSON-serializable")
+	}
+}
+
+func TestNavigateToURL_IgnoreHTTPSErrors(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "<html><body>Self-signed</body></html>")
+	}))
+	t.Cleanup(ts.Close)
+	pi := newTestIntegration(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	_, err := pi.NavigateToURL(ctx, ts.URL, nil)
+	assert.Error(t, err, "the test server's certificate is self-signed")
+
+	pi.SetIgnoreHTTPSErrors(true)
+	page, err := pi.NavigateToURL(ctx, ts.URL, nil)
+	if assert.NoError(t, err) {
+		defer page.Close()
+		content, err := page.Content()
+		assert.NoError(t, err)
+		assert.Contains(t, content, "Self-signed")
+	}
+}
+
+func TestExtractArticle(t *testing.T) {
+	ts := setupTestServer(t, `
+		<!DOCTYPE html>
+		<html>
+		<head>
+			<title>Tending a Sourdough Starter | The Bakery Blog</title>
+			<meta name="author" content="Jo Baker">
+		</head>
+		<body>
+			<nav><a href="/">Home</a> <a href="/recipes">Recipes</a> <a href="/about">About</a></nav>
+			<div class="sidebar"><a href="/ad">Buy flour now, limited offer for subscribers only</a></div>
+			<article class="post">
+				<h1>Tending a Sourdough Starter</h1>
+				<p>A sourdough starter is a living culture of flour, water, wild yeast and bacteria, and it needs regular feeding to stay active.</p>
+				<p>Feed it once a day at room temperature, discarding half before adding equal weights of fresh flour and water, and keep it loosely covered.</p>
+				<p>When it doubles within a few hours of feeding, smells pleasantly sour and is full of bubbles, it is ready to leaven a loaf.</p>
+			</article>
+			<footer>Copyright, all rights reserved</footer>
+		</body>
+		</html>
+	`)
+	empty := setupTestServer(t, `<html><body><form><input name="q"></form></body></html>`)
+
+	pi := newTestIntegration(t)
+	st := summary_tool.NewSummaryTool(pi, logger)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	page, err := pi.NavigateToURL(ctx, ts.URL, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer page.Close()
+
+	article, err := st.ExtractArticle(ctx, page)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Tending a Sourdough Starter", article.Title)
+		assert.Equal(t, "Jo Baker", article.Byline)
+		assert.Contains(t, article.TextContent, "ready to leaven a loaf")
+		assert.NotContains(t, article.TextContent, "Buy flour now")
+		assert.NotContains(t, article.TextContent, "Copyright")
+		assert.Equal(t, len([]rune(article.TextContent)), article.Length)
+	}
+
+	emptyPage, err := pi.NavigateToURL(ctx, empty.URL, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer emptyPage.Close()
+
+	_, err = st.ExtractArticle(ctx, emptyPage)
+	assert.ErrorIs(t, err, summary_tool.ErrNotArticle)
+}
+
+func TestCaptureScreenshot_TallPage(t *testing.T) {
+	ts := setupTestServer(t, `<html><body style="margin:0"><div style="height:50000px;background:linear-gradient(red,blue)"></div></body></html>`)
+	pi := newTestIntegration(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	page, err := pi.NavigateToURL(ctx, ts.URL, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer page.Close()
+
+	_, err = pi.CaptureScreenshot(ctx, page, playwright_integration.PageScreenshotOptions{FullPage: true})
+	var tooTall *playwright_integration.ScreenshotTooTallError
+	if assert.ErrorAs(t, err, &tooTall) {
+		assert.Equal(t, 50000, tooTall.Height)
+		assert.Equal(t, playwright_integration.DefaultMaxScreenshotHeight, tooTall.MaxHeight)
+	}
+
+	segments, err := pi.CaptureScreenshotSegments(ctx, page, playwright_integration.PageScreenshotOptions{})
+	if assert.NoError(t, err) && assert.Len(t, segments, 4) {
+		assert.Equal(t, 49152, segments[3].Y)
+		assert.Equal(t, 848, segments[3].Height)
+		img, _, err := image.DecodeConfig(bytes.NewReader(segments[3].Data))
+		assert.NoError(t, err)
+		assert.Equal(t, 848, img.Height)
+	}
+}
+
+func TestNavigateToURL_TimeoutAgainstSlowServer(t *testing.T) {
+	ts := setupSlowServer(t)
+	pi := newTestIntegration(t)
+
+	start := time.Now()
+	_, err := pi.NavigateToURL(context.Background(), ts.URL, &playwright_integration.NavigationOptions{Timeout: 500 * time.Millisecond})
+	var timeoutErr *playwright_integration.PhaseTimeoutError
+	if assert.ErrorAs(t, err, &timeoutErr) {
+		assert.Equal(t, playwright_integration.PhaseNavigation, timeoutErr.Phase)
+	}
+	assert.Less(t, time.Since(start), 5*time.Second, "the navigation timeout reaches page.Goto")
+
+	// A context deadline shorter than the navigation timeout bounds the navigation too.
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	start = time.Now()
+	_, err = pi.NavigateToURL(ctx, ts.URL, &playwright_integration.NavigationOptions{Timeout: 30 * time.Second})
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), 5*time.Second, "the context deadline bounds page.Goto")
+}
+
+// lockedBuffer is a bytes.Buffer safe for concurrent log writes and reads.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// hangUp closes the connection of r without a response, which Chromium reports as
+// net::ERR_EMPTY_RESPONSE.
+func hangUp(t *testing.T, w http.ResponseWriter) {
+	conn, _, err := http.NewResponseController(w).Hijack()
+	if assert.NoError(t, err) {
+		conn.Close()
+	}
+}
+
+func TestNavigateToURLOnPage_RetriesNetworkFailures(t *testing.T) {
+	var requests atomic.Int32
+	var cancelNavigation atomic.Pointer[context.CancelFunc]
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		if cancel := cancelNavigation.Load(); cancel != nil {
+			requests.Add(1)
+			(*cancel)()
+			hangUp(t, w)
+			return
+		}
+		if requests.Add(1) <= 2 {
+			hangUp(t, w)
+			return
+		}
+		fmt.Fprint(w, "<html><body>Third time lucky</body></html>")
+	}))
+	t.Cleanup(ts.Close)
+
+	var logs lockedBuffer
+	pi, err := playwright_integration.NewPlaywrightIntegration(browserManager, slog.New(slog.NewTextHandler(&logs, nil)))
+	if !assert.NoError(t, err) {
+		return
+	}
+	t.Cleanup(pi.Close)
+	navigation := &playwright_integration.NavigationOptions{Timeout: 10 * time.Second, MaxRetries: 2, RetryDelay: 10 * time.Millisecond}
+
+	page, err := pi.NewPage(context.Background(), nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer page.Close()
+	status, err := pi.NavigateToURLOnPage(context.Background(), page, ts.URL, navigation)
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusOK, status.StatusCode)
+	}
+	assert.Equal(t, int32(3), requests.Load(), "each net::ERR failure is retried")
+	assert.Contains(t, logs.String(), `msg="Retrying navigation"`)
+	assert.Contains(t, logs.String(), "retry=2")
+
+	// A navigation whose context is cancelled fails at once instead of being retried.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cancelNavigation.Store(&cancel)
+	requests.Store(0)
+	page, err = pi.NewPage(context.Background(), nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer page.Close()
+	_, err = pi.NavigateToURLOnPage(ctx, page, ts.URL, navigation)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, int32(1), requests.Load(), "a cancelled navigation is not retried")
+}
+
+func TestNavigateToURL_URLPolicyBlocksPrivateTargets(t *testing.T) {
+	var privateRequests atomic.Int32
+	private := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		privateRequests.Add(1)
+		fmt.Fprint(w, "<html><body>internal</body></html>")
+	}))
+	t.Cleanup(private.Close)
+	// Both servers listen on loopback: the page is allowed by its address, the private
+	// server is reached through "localhost", which the policy blocks.
+	privateURL := strings.Replace(private.URL, "127.0.0.1", "localhost", 1)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/redirect" {
+			http.Redirect(w, r, privateURL+"/", http.StatusFound)
+			return
+		}
+		fmt.Fprintf(w, `<html><body><img src="%s/pixel.png"></body></html>`, privateURL)
+	}))
+	t.Cleanup(ts.Close)
+
+	pi := newTestIntegration(t)
+	pi.SetURLPolicy(safety.NewPolicy([]string{"127.0.0.1"}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	_, err := pi.NavigateToURL(ctx, ts.URL+"/redirect", nil)
+	var blocked *safety.BlockedError
+	assert.ErrorAs(t, err, &blocked, "a redirect to a private address is blocked")
+
+	page, err := pi.NavigateToURL(ctx, ts.URL, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer page.Close()
+	assert.Zero(t, privateRequests.Load(), "neither the redirect nor the subresource reaches the private server")
+}
+
+func TestNavigateToURL_SendsReferer(t *testing.T) {
+	var referer atomic.Value
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/" {
+			referer.Store(r.Header.Get("Referer"))
+		}
+		fmt.Fprint(w, "<html><body>ok</body></html>")
+	}))
+	t.Cleanup(ts.Close)
+	pi := newTestIntegration(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	page, err := pi.NavigateToURL(ctx, ts.URL, &playwright_integration.NavigationOptions{Referer: "https://search.example/"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer page.Close()
+	assert.Equal(t, "https://search.example/", referer.Load())
+}
+
+func TestElementsInsideIframe(t *testing.T) {
+	ts := setupTestServer(t, `
+		<!DOCTYPE html>
+		<html>
+		<body>
+			<p class="item">main</p>
+			<iframe id="widget" srcdoc="<p class='item'>framed</p><button onclick=&quot;document.querySelector('.item').textContent='clicked'&quot;>Go</button>"></iframe>
+		</body>
+		</html>
+	`)
+	pi := newTestIntegration(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	page, err := pi.NavigateToURL(ctx, ts.URL, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer page.Close()
+
+	values, err := pi.QueryAll(ctx, page, "#widget", ".item", "")
+	if assert.NoError(t, err) && assert.Len(t, values, 1) {
+		assert.Equal(t, "framed", *values[0])
+	}
+
+	_, err = pi.ClickElement(ctx, page, "button", playwright_integration.ClickOptions{Frame: "#widget", Timeout: 5 * time.Second})
+	assert.NoError(t, err)
+	values, err = pi.QueryAll(ctx, page, "#widget", ".item", "")
+	if assert.NoError(t, err) && assert.Len(t, values, 1) {
+		assert.Equal(t, "clicked", *values[0])
+	}
+	values, err = pi.QueryAll(ctx, page, "", ".item", "")
+	if assert.NoError(t, err) && assert.Len(t, values, 1) {
+		assert.Equal(t, "main", *values[0], "the main frame is untouched")
+	}
+
+	_, err = pi.CountElements(ctx, page, "#missing", ".item")
+	assert.ErrorIs(t, err, playwright_integration.ErrFrameNotFound)
+}
+
+func TestCapturePageSummary_UsesOnePageAndClosesIt(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/style.css" {
+			w.Header().Set("Content-Type", "text/css")
+			fmt.Fprint(w, "body { color: navy; }")
+			return
+		}
+		fmt.Fprint(w, `<html><head><link rel="stylesheet" href="/style.css"></head><body>Styled</body></html>`)
+	}))
+	t.Cleanup(ts.Close)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	browser, err := browserManager.GetBrowserInstance(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+	openContexts := len(browser.Contexts())
+
+	st := summary_tool.NewSummaryTool(newTestIntegration(t), logger)
+	pageSummary, err := st.CapturePageSummary(ctx, ts.URL, summary_tool.SummaryOptions{})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	var urls []string
+	for _, activity := range pageSummary.NetworkActivity {
+		urls = append(urls, activity.Request.URL)
+	}
+	assert.Contains(t, urls, ts.URL+"/style.css", "the intercepted page is the one that navigated")
+	assert.Len(t, browser.Contexts(), openContexts, "no pages are left open")
 }
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/Camelket/mcp-browser-tools/internal/metrics"
+)
+
+// toolMetricsMiddleware counts tool calls and failed tool calls per tool.
+func toolMetricsMiddleware() server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			result, err := next(ctx, request)
+			metrics.ToolCalls.Inc(request.Params.Name)
+			if err != nil || (result != nil && result.IsError) {
+				metrics.ToolErrors.Inc(request.Params.Name)
+			}
+			return result, err
+		}
+	}
+}
+
+// serveMetrics serves the Prometheus metrics at /metrics on addr in the background. A listener
+// that fails is logged but does not stop the MCP server.
+func serveMetrics(addr string, logger *slog.Logger) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+	srv := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		logger.Info("Serving metrics", "addr", addr)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("Metrics listener failed", "addr", addr, "error", err)
+		}
+	}()
+	return srv
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Camelket/mcp-browser-tools/internal/config"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScreenshotOptionsFromRequest_FullPage(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		args            map[string]any
+		defaultFullPage bool
+		want            bool
+	}{
+		{"boolean true", map[string]any{"full_page": true}, false, true},
+		{"boolean false", map[string]any{"full_page": false}, false, false},
+		{"missing", map[string]any{}, false, false},
+		{"missing with full page default", map[string]any{}, true, true},
+		{"false overrides default", map[string]any{"full_page": false}, true, false},
+		{"legacy string", map[string]any{"full_page": "true"}, false, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var request mcp.CallToolRequest
+			request.Params.Arguments = tc.args
+			options, err := screenshotOptionsFromRequest(request, tc.defaultFullPage)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, options.FullPage)
+		})
+	}
+
+	var request mcp.CallToolRequest
+	request.Params.Arguments = map[string]any{"full_page": "yes please"}
+	_, err := screenshotOptionsFromRequest(request, false)
+	assert.ErrorContains(t, err, "invalid 'full_page' argument")
+}
+
+func TestParseNavigationArgs(t *testing.T) {
+	var request mcp.CallToolRequest
+	request.Params.Arguments = map[string]any{"timeout_ms": 5000, "block_resources": []any{"image"}}
+
+	nr, err := parseNavigationArgs(request, config.Default())
+	if assert.NoError(t, err) {
+		assert.Empty(t, nr.URL)
+		assert.Equal(t, 5*time.Second, nr.Navigation.Timeout)
+		assert.Equal(t, []string{"image"}, nr.Interception.BlockResources)
+	}
+
+	_, err = parseNavigationRequest(request, config.Default())
+	assert.ErrorContains(t, err, "missing or invalid 'url' argument")
+}
+
+func TestNavigationFromRequest_CapsSettleDelay(t *testing.T) {
+	cfg := config.Default()
+	var request mcp.CallToolRequest
+	request.Params.Arguments = map[string]any{"settle_ms": 1500}
+
+	navigation, err := navigationFromRequest(request, cfg)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 1500*time.Millisecond, navigation.SettleDelay)
+		assert.Equal(t, cfg.NavigationTimeout+1500*time.Millisecond, navigation.Budget())
+	}
+
+	request.Params.Arguments = map[string]any{"settle_ms": cfg.MaxNavigationTimeout.Milliseconds() + 1}
+	_, err = navigationFromRequest(request, cfg)
+	assert.ErrorContains(t, err, "invalid 'settle_ms' argument")
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/Camelket/mcp-browser-tools/internal/playwright_integration"
+)
+
+// progressToken returns the progress token of a tool call, or nil if the client did not ask
+// for progress notifications.
+func progressToken(request mcp.CallToolRequest) mcp.ProgressToken {
+	if request.Params.Meta == nil {
+		return nil
+	}
+	return request.Params.Meta.ProgressToken
+}
+
+// progressNotifier returns a ProgressFunc that sends MCP progress notifications for token to
+// the client of ctx, as a percentage, or nil if there is no token. Notifications that cannot
+// be delivered are dropped; progress is advisory.
+func progressNotifier(ctx context.Context, token mcp.ProgressToken) playwright_integration.ProgressFunc {
+	if token == nil {
+		return nil
+	}
+	s := server.ServerFromContext(ctx)
+	if s == nil {
+		return nil
+	}
+	return func(done float64, message string) {
+		_ = s.SendNotificationToClient(ctx, "notifications/progress", map[string]any{
+			"progressToken": token,
+			"progress":      done * 100,
+			"total":         100,
+			"message":       message,
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/Camelket/mcp-browser-tools/internal/config"
+	"github.com/Camelket/mcp-browser-tools/internal/playwright_integration"
+	"github.com/Camelket/mcp-browser-tools/internal/resources"
+	"github.com/Camelket/mcp-browser-tools/internal/summary_tool"
+)
+
+// asResourceDescription documents the as_resource parameter of the tools returning large output.
+const asResourceDescription = "Whether to store the output as an MCP resource and return its capture:// URI with a small preview instead of the full content. Output larger than the server threshold (BROWSER_RESOURCE_THRESHOLD_BYTES, 1MB) is always returned this way. Resources expire after BROWSER_RESOURCE_TTL_MS (15 minutes) and when the browser restarts. Defaults to false."
+
+// Preview sizes of outputs returned as resources.
+const (
+	htmlPreviewBytes    = 2 * 1024
+	screenshotThumbnail = 320 // Width in pixels
+)
+
+// wantsResource reports whether an output of size bytes is returned as a resource.
+func wantsResource(request mcp.CallToolRequest, cfg *config.Config, size int) bool {
+	return request.GetBool("as_resource", false) || (cfg.ResourceThresholdBytes > 0 && size > cfg.ResourceThresholdBytes)
+}
+
+// storeHTML stores htmlContent as a resource if the request asks for it or it is over the
+// threshold. It then returns a preview to send instead, records the URI in metadata, and
+// reports true; otherwise it leaves htmlContent to be returned inline.
+func storeHTML(request mcp.CallToolRequest, cfg *config.Config, store *resources.Store, htmlContent string, metadata map[string]any) (string, bool, error) {
+	if !wantsResource(request, cfg, len(htmlContent)) {
+		return htmlContent, false, nil
+	}
+	uri, err := store.Put("page.html", "text/html", []byte(htmlContent))
+	if err != nil {
+		return "", false, fmt.Errorf("failed to store HTML: %w", err)
+	}
+	metadata["html_resource"] = uri
+	metadata["html_bytes"] = len(htmlContent)
+	preview, _ := summary_tool.TruncateHTML(htmlContent, htmlPreviewBytes)
+	return preview, true, nil
+}
+
+// storeScreenshot stores a screenshot as a resource if the request asks for it or it is over
+// the threshold. It then returns a thumbnail to send instead, records the URI in metadata, and
+// reports true; otherwise it leaves the screenshot to be returned inline.
+func storeScreenshot(request mcp.CallToolRequest, cfg *config.Config, store *resources.Store, screenshot []byte, opts playwright_integration.PageScreenshotOptions, metadata map[string]any) ([]byte, bool, error) {
+	if !wantsResource(request, cfg, len(screenshot)) {
+		return screenshot, false, nil
+	}
+	thumbnail, err := opts.Thumbnail(screenshot, screenshotThumbnail)
+	if err != nil {
+		return nil, false, err
+	}
+	name := "screenshot.png"
+	if opts.Format == playwright_integration.ScreenshotFormatJPEG {
+		name = "screenshot.jpeg"
+	}
+	uri, err := store.Put(name, opts.MIMEType(), screenshot)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to store screenshot: %w", err)
+	}
+	metadata["screenshot_resource"] = uri
+	metadata["screenshot_bytes"] = len(screenshot)
+	return thumbnail, true, nil
+}
+
+// captureResourceHandler serves the resources stored by the tools.
+func captureResourceHandler(store *resources.Store) server.ResourceTemplateHandlerFunc {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		r, ok := store.Get(request.Params.URI)
+		if !ok {
+			return nil, fmt.Errorf("resource %s not found; it may have expired or been dropped when the browser restarted", request.Params.URI)
+		}
+		if resources.IsText(r.MIMEType) {
+			return []mcp.ResourceContents{mcp.TextResourceContents{URI: r.URI, MIMEType: r.MIMEType, Text: string(r.Data)}}, nil
+		}
+		return []mcp.ResourceContents{mcp.BlobResourceContents{URI: r.URI, MIMEType: r.MIMEType, Blob: base64.StdEncoding.EncodeToString(r.Data)}}, nil
+	}
+}